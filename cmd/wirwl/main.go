@@ -5,7 +5,7 @@ import (
 	"fyne.io/fyne/app"
 	"github.com/pkg/errors"
 	"os"
-	"path/filepath"
+	"strconv"
 	wirwl "wirwl/internal"
 	"wirwl/internal/log"
 )
@@ -13,6 +13,12 @@ import (
 func main() {
 	flags := readCommandLineFlags()
 	configurator := wirwl.NewAppConfigurator(flags["configDirPath"])
+	readOnly := flags["readOnly"] == "true"
+	configurator.SetReadOnly(readOnly)
+	//Commands are run without the GUI, e.g. 'wirwl -c ~/wirwl list -status Planned'
+	if flag.NArg() > 0 {
+		os.Exit(wirwl.RunCommand(configurator, flag.Args(), os.Stdout, os.Stderr))
+	}
 	config, err := configurator.LoadConfig()
 	if err == nil {
 		err = configurator.SetupNeededPaths(config)
@@ -22,8 +28,11 @@ func main() {
 		}
 		cleanup := configurator.SetupLoggerIn(config.AppDataDirPath)
 		defer cleanup()
-		dataProvider := configurator.LoadDataProvider(filepath.Join(config.AppDataDirPath, "data.db"))
+		dataProvider := configurator.LoadDataProvider(config.DbFilePath())
 		wirwlApp := wirwl.NewApp(app.New(), config, dataProvider, configurator.LoadingErrors())
+		if readOnly {
+			wirwlApp.OpenReadOnly()
+		}
 		err = wirwlApp.LoadAndDisplay()
 		if err != nil {
 			err = errors.Wrap(err, "An error occurred when loading the application preventing it from continuing")
@@ -43,8 +52,12 @@ func readCommandLineFlags() map[string]string {
 			"If not provided it will default to: \n"+
 			"On Unix: $XDG_CONFIG_HOME/wirwl/ and if this env variable is not set, then $HOME/.config/wirwl/\n"+
 			"On Windows %AppData%/wirwl/")
+	readOnly := flag.Bool("r", false,
+		"Opens the collection read-only, e.g. when it's already opened by another instance of the application. "+
+			"Changes can still be made but they cannot be saved.")
 	flag.Parse()
 	return map[string]string{
 		"configDirPath": *configDirPath,
+		"readOnly":      strconv.FormatBool(*readOnly),
 	}
 }
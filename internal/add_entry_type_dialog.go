@@ -1,6 +1,7 @@
 package wirwl
 
 import (
+	"strings"
 	"wirwl/internal/data"
 	"wirwl/internal/widget"
 )
@@ -39,7 +40,10 @@ func (app *App) addNewEntryType() error {
 
 func (app *App) getNewEntryType() data.EntryType {
 	return data.EntryType{
-		Name:       app.addEntryTypeDialog.ItemValue("Name"),
-		ImageQuery: app.addEntryTypeDialog.ItemValue("Image query"),
+		Name:          app.addEntryTypeDialog.ItemValue("Name"),
+		ImageQuery:    app.addEntryTypeDialog.ItemValue("Image query"),
+		MetadataURL:   app.addEntryTypeDialog.ItemValue("Metadata URL"),
+		Statuses:      app.addEntryTypeDialog.ItemValue("Statuses"),
+		ScoringSystem: data.ScoringSystem(strings.TrimSpace(app.addEntryTypeDialog.ItemValue("Scoring system"))),
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+/*
+Commands that would be run from the command line can be run by the server instead, so they don't change the collection
+at the same time as the process running the server does. Outputs of a command are returned once the command finishes.
+*/
+
+//Runs the command with the arguments and returns its exit code
+type CommandRunner func(request CommandRequest, output io.Writer, errorOutput io.Writer) int
+
+//Body of a request running a command
+type CommandRequest struct {
+	//The command's name followed by its arguments
+	Args []string
+	//Relative paths in arguments are relative to this directory
+	WorkingDirPath string
+}
+
+//Body of the response of a request running a command
+type CommandResult struct {
+	ExitCode    int
+	Output      string
+	ErrorOutput string
+}
+
+//Time after which forwarding a command fails, no command should take that long
+const commandTimeout = time.Minute
+
+//Commands can be run under /commands only after a runner has been set and only if the server has a token, as they can
+//read and write any files the server can
+func (server *Server) SetCommandRunner(runner CommandRunner) {
+	server.commandRunner = runner
+}
+
+func (server *Server) runCommand(body []byte) (int, interface{}) {
+	commandRequest := CommandRequest{}
+	err := decodeBody(body, &commandRequest)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	output, errorOutput := bytes.Buffer{}, bytes.Buffer{}
+	exitCode := server.commandRunner(commandRequest, &output, &errorOutput)
+	return http.StatusOK, CommandResult{exitCode, output.String(), errorOutput.String()}
+}
+
+//Runs the command by a server listening on the address, which is expected to be on the same machine
+func ForwardCommand(address string, token string, commandRequest CommandRequest) (CommandResult, error) {
+	body, err := json.Marshal(commandRequest)
+	if err != nil {
+		return CommandResult{}, errors.Wrap(err, "Failed to encode the command")
+	}
+	request, err := http.NewRequest(http.MethodPost, "http://"+address+"/commands", bytes.NewReader(body))
+	if err != nil {
+		return CommandResult{}, errors.Wrap(err, "Failed to prepare forwarding of the command")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := http.Client{Timeout: commandTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return CommandResult{}, errors.Wrap(err, "Failed to forward the command to "+address)
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return CommandResult{}, errors.Wrap(err, "Failed to read the result of the command forwarded to "+address)
+	}
+	if response.StatusCode != http.StatusOK {
+		errorResponse := ErrorResponse{}
+		_ = json.Unmarshal(responseBody, &errorResponse)
+		return CommandResult{}, errors.New("Command forwarded to " + address + " has been rejected: " + errorResponse.Error)
+	}
+	result := CommandResult{}
+	err = json.Unmarshal(responseBody, &result)
+	return result, errors.Wrap(err, "Failed to decode the result of the command forwarded to "+address)
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestThatCommandIsRunByServerItIsForwardedTo(t *testing.T) {
+	server := startTestServer("token")
+	defer server.Close()
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusNotFound, server.request(http.MethodPost, "/commands", `{"Args": ["types"]}`, &errorResponse))
+	server.Config.Handler.(*Server).SetCommandRunner(func(request CommandRequest, output io.Writer, errorOutput io.Writer) int {
+		fmt.Fprint(output, strings.Join(request.Args, " ")+" in "+request.WorkingDirPath)
+		fmt.Fprint(errorOutput, "warning")
+		return 3
+	})
+	address := strings.TrimPrefix(server.URL, "http://")
+	result, err := ForwardCommand(address, "token", CommandRequest{Args: []string{"list", "-type", "music"}, WorkingDirPath: "/home"})
+	assert.Nil(t, err)
+	assert.Equal(t, CommandResult{ExitCode: 3, Output: "list -type music in /home", ErrorOutput: "warning"}, result)
+	_, err = ForwardCommand(address, "wrong token", CommandRequest{Args: []string{"types"}})
+	assert.EqualError(t, err, "Command forwarded to "+address+" has been rejected: Missing or wrong token")
+}
+
+func TestThatCommandsCannotBeRunByServerWithoutToken(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	server.Config.Handler.(*Server).SetCommandRunner(func(request CommandRequest, output io.Writer, errorOutput io.Writer) int {
+		return 0
+	})
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusNotFound, server.request(http.MethodPost, "/commands", `{"Args": ["types"]}`, &errorResponse))
+}
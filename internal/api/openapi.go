@@ -0,0 +1,203 @@
+package api
+
+//OpenAPI description of the routes of the server, served under /openapi.json so clients can be generated from it
+const openAPIDescription = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "wirwl",
+    "description": "Entry types and entries of a wirwl collection. Requests have to have the header 'Authorization: Bearer <token>' if the server has been started with a token.",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/types": {
+      "get": {
+        "summary": "Lists entry types sorted by their names",
+        "responses": {"200": {"description": "Entry types", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/EntryType"}}}}}}
+      },
+      "post": {
+        "summary": "Adds an entry type",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryType"}}}},
+        "responses": {
+          "201": {"description": "Added entry type", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryType"}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/types/{typeName}": {
+      "parameters": [{"$ref": "#/components/parameters/TypeName"}],
+      "get": {
+        "summary": "Returns an entry type",
+        "responses": {
+          "200": {"description": "Entry type", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryType"}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "put": {
+        "summary": "Replaces an entry type, which renames it if the name is different",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryType"}}}},
+        "responses": {
+          "200": {"description": "Updated entry type", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/EntryType"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Deletes an entry type with all of its entries",
+        "responses": {"204": {"description": "Entry type has been deleted"}, "404": {"$ref": "#/components/responses/Error"}}
+      }
+    },
+    "/types/{typeName}/entries": {
+      "parameters": [{"$ref": "#/components/parameters/TypeName"}],
+      "get": {
+        "summary": "Lists entries of an entry type",
+        "responses": {
+          "200": {"description": "Entries", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Entry"}}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "summary": "Adds an entry with a new id, which gets the planned status of the entry type if it has no status",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+        "responses": {
+          "201": {"description": "Added entry", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/types/{typeName}/entries/{id}": {
+      "parameters": [{"$ref": "#/components/parameters/TypeName"}, {"$ref": "#/components/parameters/Id"}],
+      "get": {
+        "summary": "Returns an entry",
+        "responses": {
+          "200": {"description": "Entry", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "put": {
+        "summary": "Replaces an entry, except for its id and consumption history",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+        "responses": {
+          "200": {"description": "Updated entry", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Deletes an entry",
+        "responses": {"204": {"description": "Entry has been deleted"}, "404": {"$ref": "#/components/responses/Error"}}
+      }
+    },
+    "/types/{typeName}/entries/{id}/progress": {
+      "parameters": [{"$ref": "#/components/parameters/TypeName"}, {"$ref": "#/components/parameters/Id"}],
+      "post": {
+        "summary": "Changes the amount of completed elements of an entry by the given amount, which can be negative",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ProgressChange"}}}},
+        "responses": {
+          "200": {"description": "Updated entry", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Entry"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/search": {
+      "get": {
+        "summary": "Finds entries of all entry types matching all of the given criteria, lists of values are separated with commas",
+        "parameters": [
+          {"name": "type", "in": "query", "schema": {"type": "string"}, "description": "Names of entry types, one of which the entries have to belong to"},
+          {"name": "status", "in": "query", "schema": {"type": "string"}, "description": "Statuses, one of which the entries have to have or belong to"},
+          {"name": "tags", "in": "query", "schema": {"type": "string"}, "description": "Tags that the entries have to have"},
+          {"name": "title", "in": "query", "schema": {"type": "string"}, "description": "Text that titles of the entries have to contain, ignoring case"},
+          {"name": "minScore", "in": "query", "schema": {"type": "integer"}, "description": "Lowest score of the entries on the scale from 1 to 10 that scores of all scoring systems are normalized to"},
+          {"name": "notTouchedForDays", "in": "query", "schema": {"type": "integer"}},
+          {"name": "relatedAs", "in": "query", "schema": {"type": "string"}, "description": "Kinds of relations in which the entries have to be to other entries, e.g. 'adaptation' for adaptations of other entries"},
+          {"name": "sort", "in": "query", "schema": {"type": "string", "enum": ["type", "score"]}, "description": "Order of the entries, by names of their entry types or from the highest normalized score"}
+        ],
+        "responses": {
+          "200": {"description": "Found entries", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/FoundEntry"}}}}},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/stats": {
+      "get": {
+        "summary": "Returns statistics of the whole collection",
+        "responses": {"200": {"description": "Statistics", "content": {"application/json": {"schema": {"type": "object"}}}}}
+      }
+    },
+    "/commands": {
+      "post": {
+        "summary": "Runs a command of the command line, available only when the server is run by the application",
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/CommandRequest"}}}},
+        "responses": {
+          "200": {"description": "Result of the command", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/CommandResult"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "TypeName": {"name": "typeName", "in": "path", "required": true, "schema": {"type": "string"}},
+      "Id": {"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}
+    },
+    "responses": {
+      "Error": {"description": "Request failed", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+    },
+    "schemas": {
+      "EntryType": {
+        "type": "object",
+        "properties": {
+          "Name": {"type": "string"},
+          "CompletionElementName": {"type": "string"},
+          "ImageQuery": {"type": "string"},
+          "MetadataURL": {"type": "string"},
+          "Statuses": {"type": "string", "description": "Comma separated statuses with built-in statuses they belong to, e.g. 'Backlog=Planned, Playing=In progress, Completed', the built-in ones if empty"},
+          "ScoringSystem": {"type": "string", "enum": ["", "1-10", "1-100", "5 stars", "thumbs"], "description": "1-10 if empty"}
+        }
+      },
+      "Entry": {
+        "type": "object",
+        "properties": {
+          "Id": {"type": "integer", "readOnly": true},
+          "Status": {"type": "string", "description": "One of the statuses of the entry type"},
+          "Title": {"type": "string"},
+          "ElementsCompleted": {"type": "integer"},
+          "TotalAmountOfElementsToComplete": {"type": "integer"},
+          "Score": {"type": "integer", "description": "From 1 to the highest score of the scoring system of the entry type, 0 if not scored, stars are counted in halves and thumbs are 1 for down and 2 for up"},
+          "StartDate": {"type": "string", "description": "Date in format DD/MM/YYYY"},
+          "FinishDate": {"type": "string", "description": "Date in format DD/MM/YYYY"},
+          "Link": {"type": "string"},
+          "Description": {"type": "string"},
+          "Comment": {"type": "string"},
+          "Tags": {"type": "string", "description": "Tags separated with commas"},
+          "ImageQuery": {"type": "string"},
+          "ConsumptionHistory": {"type": "array", "readOnly": true, "items": {"type": "object"}}
+        }
+      },
+      "FoundEntry": {
+        "allOf": [{"$ref": "#/components/schemas/Entry"}, {"type": "object", "properties": {"Type": {"type": "string"}}}]
+      },
+      "ProgressChange": {
+        "type": "object",
+        "properties": {"Amount": {"type": "integer"}}
+      },
+      "CommandRequest": {
+        "type": "object",
+        "properties": {
+          "Args": {"type": "array", "items": {"type": "string"}, "description": "Name of the command followed by its arguments"},
+          "WorkingDirPath": {"type": "string", "description": "Directory to which relative paths in arguments are relative"}
+        }
+      },
+      "CommandResult": {
+        "type": "object",
+        "properties": {"ExitCode": {"type": "integer"}, "Output": {"type": "string"}, "ErrorOutput": {"type": "string"}}
+      },
+      "Error": {
+        "type": "object",
+        "properties": {"Error": {"type": "string"}}
+      }
+    }
+  }
+}`
@@ -0,0 +1,444 @@
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/stats"
+)
+
+/*
+Server exposes entries of a container over a REST API, so they can be read and updated by other applications, e.g.
+browser extensions or dashboards. Bodies of requests and responses are JSON with the same fields as entry types and
+entries have, routes are described in the OpenAPI description served under /openapi.json. Every change is saved right
+after it's made. Requests are handled one at a time, as the container can only be used by one goroutine at a time, but
+they are only let in once they have been authorized and their bodies have been read, so a slow client can't block others.
+Requests are only accepted for hosts given by IP addresses or localhost, so a web page can't reach the server through
+a domain name that resolves to a local address, and their bodies have to be JSON, so a web page can't send them as forms.
+*/
+type Server struct {
+	entriesContainer *data.EntriesContainer
+	saveChanges      func() error
+	token            string
+	//Held while a request is handled, it can be shared with others using the container so they don't use it at the same time
+	locker        sync.Locker
+	commandRunner CommandRunner
+}
+
+//Body of every response of a request that failed
+type ErrorResponse struct {
+	Error string
+}
+
+//Entries found by a search can belong to many entry types, so they are returned with names of their types
+type FoundEntry struct {
+	Type string
+	data.Entry
+}
+
+//Body of a request changing progress of an entry, see data.Entry.WithProgressChangedBy
+type ProgressChange struct {
+	Amount int
+}
+
+//Requests with bigger bodies are rejected, as no entry is that big
+const maxRequestSize = 1024 * 1024
+
+func NewServer(entriesContainer *data.EntriesContainer, saveChanges func() error) *Server {
+	return &Server{entriesContainer: entriesContainer, saveChanges: saveChanges, locker: &sync.Mutex{}}
+}
+
+//Requests have to have the token in their Authorization header, e.g. 'Authorization: Bearer <token>', unless it's empty
+func (server *Server) SetToken(token string) {
+	server.token = token
+}
+
+func (server *Server) SetLocker(locker sync.Locker) {
+	server.locker = locker
+}
+
+func (server *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if !isAllowedHost(request.Host) {
+		writeResponse(writer, http.StatusForbidden, ErrorResponse{"Host " + request.Host + " is not allowed"})
+		return
+	}
+	//Token is compared in constant time, so it can't be guessed from how long the comparison takes
+	if server.token != "" && subtle.ConstantTimeCompare([]byte(request.Header.Get("Authorization")), []byte("Bearer "+server.token)) != 1 {
+		writeResponse(writer, http.StatusUnauthorized, ErrorResponse{"Missing or wrong token"})
+		return
+	}
+	path, err := pathSegments(request.URL)
+	if err != nil {
+		writeResponse(writer, http.StatusBadRequest, ErrorResponse{err.Error()})
+		return
+	}
+	body, err := readBody(request)
+	if err != nil {
+		writeResponse(writer, http.StatusBadRequest, ErrorResponse{err.Error()})
+		return
+	} else if len(body) > 0 && !hasJSONContentType(request) {
+		writeResponse(writer, http.StatusUnsupportedMediaType, ErrorResponse{"Body of the request has to be sent as application/json"})
+		return
+	}
+	status, responseBody := server.handle(request, path, body)
+	writeEncodedResponse(writer, status, responseBody)
+}
+
+//Response is encoded while the container is locked, as it can refer to values of the container
+func (server *Server) handle(request *http.Request, path []string, body []byte) (int, []byte) {
+	server.locker.Lock()
+	defer server.locker.Unlock()
+	status, responseBody := server.route(request, path, body)
+	return status, encodeResponse(status, responseBody)
+}
+
+//Host can be given with or without a port, e.g. 'localhost:8420' or '[::1]'
+func isAllowedHost(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return strings.EqualFold(host, "localhost") || net.ParseIP(host) != nil
+}
+
+func hasJSONContentType(request *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+//Segments are unescaped, so names of entry types can contain any characters, including slashes
+func pathSegments(requestURL *url.URL) ([]string, error) {
+	segments := []string{}
+	for _, segment := range strings.Split(strings.Trim(requestURL.EscapedPath(), "/"), "/") {
+		unescapedSegment, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, errors.New("Path " + requestURL.EscapedPath() + " is not escaped properly")
+		}
+		segments = append(segments, unescapedSegment)
+	}
+	return segments, nil
+}
+
+func writeResponse(writer http.ResponseWriter, status int, body interface{}) {
+	writeEncodedResponse(writer, status, encodeResponse(status, body))
+}
+
+//Body that can't be encoded is replaced by an error, so the client doesn't get a response that is cut off
+func encodeResponse(status int, body interface{}) []byte {
+	if status == http.StatusNoContent {
+		return nil
+	}
+	encodedBody, err := json.Marshal(body)
+	if err != nil {
+		log.Error(errors.Wrap(err, "Failed to encode response of the API server"))
+		encodedBody, _ = json.Marshal(ErrorResponse{"Failed to encode the response"})
+	}
+	return append(encodedBody, '\n')
+}
+
+func writeEncodedResponse(writer http.ResponseWriter, status int, body []byte) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_, err := writer.Write(body)
+	if err != nil {
+		log.Error(errors.Wrap(err, "Failed to write response of the API server"))
+	}
+}
+
+func errorResponse(status int, message string) (int, interface{}) {
+	return status, ErrorResponse{message}
+}
+
+//Returns the status and the body of the response
+func (server *Server) route(request *http.Request, path []string, body []byte) (int, interface{}) {
+	handlers := map[string]func() (int, interface{}){}
+	switch {
+	case len(path) == 1 && path[0] == "openapi.json":
+		handlers[http.MethodGet] = func() (int, interface{}) { return http.StatusOK, json.RawMessage(openAPIDescription) }
+	case len(path) == 1 && path[0] == "types":
+		handlers[http.MethodGet] = server.getEntryTypes
+		handlers[http.MethodPost] = func() (int, interface{}) { return server.addEntryType(body) }
+	case len(path) == 2 && path[0] == "types":
+		handlers[http.MethodGet] = func() (int, interface{}) { return server.getEntryType(path[1]) }
+		handlers[http.MethodPut] = func() (int, interface{}) { return server.updateEntryType(body, path[1]) }
+		handlers[http.MethodDelete] = func() (int, interface{}) { return server.deleteEntryType(path[1]) }
+	case len(path) == 3 && path[0] == "types" && path[2] == "entries":
+		handlers[http.MethodGet] = func() (int, interface{}) { return server.getEntries(path[1]) }
+		handlers[http.MethodPost] = func() (int, interface{}) { return server.addEntry(body, path[1]) }
+	case len(path) == 4 && path[0] == "types" && path[2] == "entries":
+		handlers[http.MethodGet] = func() (int, interface{}) { return server.getEntry(path[1], path[3]) }
+		handlers[http.MethodPut] = func() (int, interface{}) { return server.updateEntry(body, path[1], path[3]) }
+		handlers[http.MethodDelete] = func() (int, interface{}) { return server.deleteEntry(path[1], path[3]) }
+	case len(path) == 5 && path[0] == "types" && path[2] == "entries" && path[4] == "progress":
+		handlers[http.MethodPost] = func() (int, interface{}) { return server.changeProgress(body, path[1], path[3]) }
+	case len(path) == 1 && path[0] == "search":
+		handlers[http.MethodGet] = func() (int, interface{}) { return server.search(request.URL.Query()) }
+	case len(path) == 1 && path[0] == "stats":
+		handlers[http.MethodGet] = func() (int, interface{}) {
+			return http.StatusOK, stats.Calculate(server.entriesContainer.EntriesGroupedByType())
+		}
+	case len(path) == 1 && path[0] == "commands" && server.commandRunner != nil && server.token != "":
+		handlers[http.MethodPost] = func() (int, interface{}) { return server.runCommand(body) }
+	default:
+		return errorResponse(http.StatusNotFound, "There is nothing under path "+request.URL.EscapedPath())
+	}
+	handler, exists := handlers[request.Method]
+	if !exists {
+		return errorResponse(http.StatusMethodNotAllowed, "Method "+request.Method+" is not allowed for path "+request.URL.EscapedPath())
+	}
+	return handler()
+}
+
+func readBody(request *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(&io.LimitedReader{R: request.Body, N: maxRequestSize + 1})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the body of the request")
+	}
+	if len(body) > maxRequestSize {
+		return nil, errors.New("Body of the request is too big")
+	}
+	return body, nil
+}
+
+func decodeBody(body []byte, value interface{}) error {
+	err := json.Unmarshal(body, value)
+	if err != nil {
+		return errors.New("Body of the request is not valid JSON: " + err.Error())
+	}
+	return nil
+}
+
+//Changes that can't be made are the client's fault, but changes that can't be saved are not
+func (server *Server) makeChange(change func() error, status int, body func() interface{}) (int, interface{}) {
+	err := change()
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	err = server.saveChanges()
+	if err != nil {
+		log.Error(err)
+		return errorResponse(http.StatusInternalServerError, "Change has been made but it could not be saved: "+err.Error())
+	}
+	return status, body()
+}
+
+//Entry types are sorted by their names
+func (server *Server) getEntryTypes() (int, interface{}) {
+	entryTypes := []data.EntryType{}
+	for entryType := range server.entriesContainer.EntriesGroupedByType() {
+		entryTypes = append(entryTypes, entryType)
+	}
+	sort.Slice(entryTypes, func(i, j int) bool { return entryTypes[i].Name < entryTypes[j].Name })
+	return http.StatusOK, entryTypes
+}
+
+func (server *Server) getEntryType(typeName string) (int, interface{}) {
+	entryType, err := server.entriesContainer.EntryTypeWithName(typeName)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	return http.StatusOK, entryType
+}
+
+func (server *Server) addEntryType(body []byte) (int, interface{}) {
+	entryType := data.EntryType{}
+	err := decodeBody(body, &entryType)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	return server.makeChange(func() error { return server.entriesContainer.AddEntryType(entryType) },
+		http.StatusCreated, func() interface{} { return entryType })
+}
+
+func (server *Server) updateEntryType(body []byte, typeName string) (int, interface{}) {
+	if _, err := server.entriesContainer.EntryTypeWithName(typeName); err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	entryType := data.EntryType{}
+	err := decodeBody(body, &entryType)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	return server.makeChange(func() error { return server.entriesContainer.UpdateEntryType(typeName, entryType) },
+		http.StatusOK, func() interface{} { return entryType })
+}
+
+func (server *Server) deleteEntryType(typeName string) (int, interface{}) {
+	if _, err := server.entriesContainer.EntryTypeWithName(typeName); err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	return server.makeChange(func() error { return server.entriesContainer.DeleteEntryType(typeName) },
+		http.StatusNoContent, func() interface{} { return nil })
+}
+
+func (server *Server) getEntries(typeName string) (int, interface{}) {
+	entryType, err := server.entriesContainer.EntryTypeWithName(typeName)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	return http.StatusOK, append([]data.Entry{}, server.entriesContainer.EntriesGroupedByType()[entryType]...)
+}
+
+//Entry is not found if its type doesn't exist or if there is no entry with the id given as text
+func (server *Server) findEntry(typeName string, idText string) (data.Entry, error) {
+	entryType, err := server.entriesContainer.EntryTypeWithName(typeName)
+	if err != nil {
+		return data.Entry{}, err
+	}
+	id, err := strconv.Atoi(idText)
+	if err == nil {
+		for _, entry := range server.entriesContainer.EntriesGroupedByType()[entryType] {
+			if entry.Id == id {
+				return entry, nil
+			}
+		}
+	}
+	return data.Entry{}, errors.New("There is no entry with id " + idText + " in entry type '" + typeName + "'")
+}
+
+func (server *Server) getEntry(typeName string, idText string) (int, interface{}) {
+	entry, err := server.findEntry(typeName, idText)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	return http.StatusOK, entry
+}
+
+//Entry gets a new id and, if it has no status, it gets the planned status of its type, see data.EntryType.StatusIn
+func (server *Server) addEntry(body []byte, typeName string) (int, interface{}) {
+	entryType, err := server.entriesContainer.EntryTypeWithName(typeName)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	entry := data.Entry{}
+	err = decodeBody(body, &entry)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	if entry.Status == "" {
+		entry.Status = entryType.StatusIn(data.PlannedStatus)
+	}
+	if !entryType.HasStatus(entry.Status) {
+		return invalidStatusResponse(entry.Status, entryType)
+	}
+	id := 0
+	return server.makeChange(func() error {
+		id, err = server.entriesContainer.AddEntry(typeName, entry)
+		return err
+	}, http.StatusCreated, func() interface{} {
+		addedEntry, _ := server.findEntry(typeName, strconv.Itoa(id))
+		return addedEntry
+	})
+}
+
+//Id of the entry can't be changed, so the id in the body is ignored
+func (server *Server) updateEntry(body []byte, typeName string, idText string) (int, interface{}) {
+	entry, err := server.findEntry(typeName, idText)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	updatedEntry := data.Entry{}
+	err = decodeBody(body, &updatedEntry)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	entryType, _ := server.entriesContainer.EntryTypeWithName(typeName)
+	if updatedEntry.Status != entry.Status && !entryType.HasStatus(updatedEntry.Status) {
+		return invalidStatusResponse(updatedEntry.Status, entryType)
+	}
+	updatedEntry.Id = entry.Id
+	return server.makeChange(func() error { return server.entriesContainer.UpdateEntry(typeName, updatedEntry) },
+		http.StatusOK, func() interface{} {
+			updatedEntry, _ = server.findEntry(typeName, idText)
+			return updatedEntry
+		})
+}
+
+func invalidStatusResponse(status data.EntryStatus, entryType data.EntryType) (int, interface{}) {
+	return errorResponse(http.StatusBadRequest, "'"+string(status)+"' is not a status of entry type '"+entryType.Name+"'")
+}
+
+func (server *Server) deleteEntry(typeName string, idText string) (int, interface{}) {
+	entry, err := server.findEntry(typeName, idText)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	return server.makeChange(func() error { return server.entriesContainer.DeleteEntry(typeName, entry.Id) },
+		http.StatusNoContent, func() interface{} { return nil })
+}
+
+func (server *Server) changeProgress(body []byte, typeName string, idText string) (int, interface{}) {
+	entry, err := server.findEntry(typeName, idText)
+	if err != nil {
+		return errorResponse(http.StatusNotFound, err.Error())
+	}
+	progressChange := ProgressChange{}
+	err = decodeBody(body, &progressChange)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+	return server.makeChange(func() error {
+		return server.entriesContainer.ChangeEntryProgress(typeName, entry.Id, progressChange.Amount)
+	}, http.StatusOK, func() interface{} {
+		entry, _ = server.findEntry(typeName, idText)
+		return entry
+	})
+}
+
+//Criteria of the search are the same as criteria of data.Filter, lists of values are separated with commas,
+//e.g. '/search?status=Planned,On hold&tags=horror'. Found entries are sorted by names of their entry types or,
+//with 'sort=score', from the highest normalized score, see data.SortedByScore.
+func (server *Server) search(query url.Values) (int, interface{}) {
+	if sortOrder := query.Get("sort"); sortOrder != "" && sortOrder != "type" && sortOrder != "score" {
+		return errorResponse(http.StatusBadRequest, "Entries cannot be sorted by '"+sortOrder+"'")
+	}
+	filter := data.Filter{
+		TypeNames:     data.SplitCommaSeparatedValues(query.Get("type")),
+		Tags:          data.SplitCommaSeparatedValues(query.Get("tags")),
+		TitleContains: query.Get("title"),
+	}
+	for _, status := range data.SplitCommaSeparatedValues(query.Get("status")) {
+		if !server.entriesContainer.IsValidStatus(data.EntryStatus(status)) {
+			return errorResponse(http.StatusBadRequest, "'"+status+"' is not a valid status of an entry")
+		}
+		filter.Statuses = append(filter.Statuses, data.EntryStatus(status))
+	}
+	for _, kind := range data.SplitCommaSeparatedValues(query.Get("relatedAs")) {
+		if !data.IsValidRelationKind(data.RelationKind(kind)) {
+			return errorResponse(http.StatusBadRequest, "'"+kind+"' is not a valid kind of relation")
+		}
+		filter.RelatedAs = append(filter.RelatedAs, data.RelationKind(kind))
+	}
+	var err error
+	for parameter, value := range map[string]*int{"minScore": &filter.MinScore, "notTouchedForDays": &filter.NotTouchedForDays} {
+		if query.Get(parameter) == "" {
+			continue
+		}
+		*value, err = strconv.Atoi(query.Get(parameter))
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, "Parameter "+parameter+" has to be a number")
+		}
+	}
+	foundEntries := []FoundEntry{}
+	filteredEntries := server.entriesContainer.EntriesMatching(filter, time.Now())
+	if query.Get("sort") == "score" {
+		filteredEntries = data.SortedByScore(filteredEntries)
+	}
+	for _, filteredEntry := range filteredEntries {
+		foundEntries = append(foundEntries, FoundEntry{filteredEntry.Type.Name, filteredEntry.Entry})
+	}
+	return http.StatusOK, foundEntries
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+)
+
+type testServer struct {
+	*httptest.Server
+	entriesContainer *data.EntriesContainer
+	savesAmount      int
+	saveError        error
+}
+
+func startTestServer(token string) *testServer {
+	entriesContainer := data.NewEntriesContainer(data.NewSampleTestDataProvider(""))
+	err := entriesContainer.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := &testServer{entriesContainer: entriesContainer}
+	apiServer := NewServer(entriesContainer, func() error {
+		server.savesAmount++
+		return server.saveError
+	})
+	apiServer.SetToken(token)
+	server.Server = httptest.NewServer(apiServer)
+	return server
+}
+
+//Returns the status of the response and decodes its body into the given value, unless it's nil
+func (server *testServer) request(method string, path string, body string, response interface{}) int {
+	request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		log.Fatal(err)
+	}
+	request.Header.Set("Authorization", "Bearer token")
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := server.Client().Do(request)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer httpResponse.Body.Close()
+	if response != nil {
+		responseData, err := ioutil.ReadAll(httpResponse.Body)
+		if err == nil {
+			err = json.Unmarshal(responseData, response)
+		}
+		if err != nil {
+			log.Fatal(errors.Wrap(err, "Failed to decode response "+string(responseData)))
+		}
+	}
+	return httpResponse.StatusCode
+}
+
+func TestThatEntryTypesAndEntriesCanBeRead(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	entryTypes := []data.EntryType{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/types", "", &entryTypes))
+	assert.Equal(t, 3, len(entryTypes))
+	assert.Equal(t, "comics", entryTypes[0].Name)
+	entries := []data.Entry{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/types/comics/entries", "", &entries))
+	assert.Equal(t, data.GetExampleComicEntries(), entries)
+	entry := data.Entry{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/types/comics/entries/1", "", &entry))
+	assert.Equal(t, data.GetExampleComicEntries()[1], entry)
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusNotFound, server.request(http.MethodGet, "/types/comics/entries/7", "", &errorResponse))
+	assert.Equal(t, "There is no entry with id 7 in entry type 'comics'", errorResponse.Error)
+	assert.Equal(t, http.StatusNotFound, server.request(http.MethodGet, "/types/books", "", &errorResponse))
+	assert.Equal(t, http.StatusMethodNotAllowed, server.request(http.MethodDelete, "/types", "", &errorResponse))
+	assert.Equal(t, 0, server.savesAmount)
+}
+
+func TestThatEntryTypesCanBeAddedRenamedAndDeleted(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	entryType := data.EntryType{}
+	assert.Equal(t, http.StatusCreated, server.request(http.MethodPost, "/types", `{"Name": "home movies"}`, &entryType))
+	assert.Equal(t, data.EntryType{Name: "home movies"}, entryType)
+	assert.Equal(t, http.StatusOK, server.request(http.MethodPut, "/types/home%20movies", `{"Name": "home/movies"}`, &entryType))
+	_, err := server.entriesContainer.EntryTypeWithName("home/movies")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, server.request(http.MethodDelete, "/types/home%2Fmovies", "", nil))
+	assert.Equal(t, 3, server.entriesContainer.AmountOfTypes())
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodPost, "/types", `{"Name": "comics"}`, &errorResponse))
+	assert.Equal(t, "Entry type with name 'comics' already exists", errorResponse.Error)
+	assert.Equal(t, 3, server.savesAmount)
+}
+
+func TestThatEntriesCanBeAddedUpdatedAndDeleted(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	entry := data.Entry{}
+	assert.Equal(t, http.StatusCreated, server.request(http.MethodPost, "/types/music/entries", `{"Id": 0, "Title": "new album"}`, &entry))
+	assert.Equal(t, data.Entry{Id: 2, Title: "new album", Status: data.PlannedStatus}, entry)
+	assert.Equal(t, http.StatusOK, server.request(http.MethodPut, "/types/music/entries/2", `{"Title": "new album", "Status": "Dropped", "Score": 2}`, &entry))
+	assert.Equal(t, data.DroppedStatus, entry.Status)
+	assert.Equal(t, 2, entry.Score)
+	assert.Equal(t, 1, len(entry.ConsumptionHistory))
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodPut, "/types/music/entries/2", `{"Status": "Forgotten"}`, &errorResponse))
+	assert.Equal(t, "'Forgotten' is not a status of entry type 'music'", errorResponse.Error)
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodPost, "/types/music/entries", `{"Title": `, &errorResponse))
+	assert.Contains(t, errorResponse.Error, "Body of the request is not valid JSON")
+	assert.Equal(t, http.StatusNoContent, server.request(http.MethodDelete, "/types/music/entries/2", "", nil))
+	musicType, _ := server.entriesContainer.EntryTypeWithName("music")
+	assert.Equal(t, data.GetExampleMusicEntries(), server.entriesContainer.EntriesGroupedByType()[musicType])
+}
+
+func TestThatProgressOfEntryCanBeChanged(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	entry := data.Entry{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodPost, "/types/videos/entries/0/progress", `{"Amount": 1}`, &entry))
+	assert.Equal(t, data.GetExampleVideoEntries()[0].ElementsCompleted+1, entry.ElementsCompleted)
+	assert.NotEmpty(t, entry.ConsumptionHistory)
+	assert.Equal(t, 1, server.savesAmount)
+}
+
+func TestThatEntriesCanBeSearchedFor(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	foundEntries := []FoundEntry{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/search?type=music,videos&title=2", "", &foundEntries))
+	assert.Equal(t, []FoundEntry{{"music", data.GetExampleMusicEntries()[1]}, {"videos", data.GetExampleVideoEntries()[1]}}, foundEntries)
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodGet, "/search?minScore=high", "", &errorResponse))
+	assert.Equal(t, "Parameter minScore has to be a number", errorResponse.Error)
+}
+
+func TestThatFoundEntriesCanBeSortedByScores(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	musicType, _ := server.entriesContainer.EntryTypeWithName("music")
+	musicType.ScoringSystem = data.HundredPointScoring
+	assert.Nil(t, server.entriesContainer.UpdateEntryType("music", musicType))
+	foundEntries := []FoundEntry{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/search?type=comics,music&sort=score", "", &foundEntries))
+	assert.Equal(t, []string{"comics", "music", "comics", "music"}, []string{foundEntries[0].Type, foundEntries[1].Type, foundEntries[2].Type, foundEntries[3].Type})
+	assert.Equal(t, []int{6, 56, 3, 23}, []int{foundEntries[0].Score, foundEntries[1].Score, foundEntries[2].Score, foundEntries[3].Score})
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodGet, "/search?sort=title", "", &errorResponse))
+	assert.Equal(t, "Entries cannot be sorted by 'title'", errorResponse.Error)
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodPut, "/types/music/entries/0", `{"Title": "too good", "Status": "In progress", "Score": 101}`, &errorResponse))
+	assert.Equal(t, "Entry 'too good' cannot have score 101 as entry type 'music' scores from 1 to 100", errorResponse.Error)
+}
+
+func TestThatEntriesRelatedToOtherEntriesCanBeSearchedFor(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	relation := data.Relation{Kind: data.SequelRelation, TypeName: "music", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 1}
+	assert.Nil(t, server.entriesContainer.AddRelation(relation))
+	foundEntries := []FoundEntry{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/search?relatedAs=prequel", "", &foundEntries))
+	assert.Equal(t, 1, len(foundEntries))
+	assert.Equal(t, "some music1", foundEntries[0].Title)
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusBadRequest, server.request(http.MethodGet, "/search?relatedAs=remake", "", &errorResponse))
+	assert.Equal(t, "'remake' is not a valid kind of relation", errorResponse.Error)
+}
+
+func TestThatStatsAndOpenAPIDescriptionAreServed(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	stats := map[string]interface{}{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/stats", "", &stats))
+	assert.Equal(t, float64(6), stats["TotalEntries"])
+	description := map[string]interface{}{}
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/openapi.json", "", &description))
+	assert.Equal(t, "3.0.3", description["openapi"])
+	assert.Contains(t, description["paths"], "/types/{typeName}/entries/{id}/progress")
+}
+
+func TestThatRequestsWithoutTokenAreRejected(t *testing.T) {
+	server := startTestServer("secret")
+	defer server.Close()
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusUnauthorized, server.request(http.MethodGet, "/types", "", &errorResponse))
+	assert.Equal(t, "Missing or wrong token", errorResponse.Error)
+	server = startTestServer("token")
+	defer server.Close()
+	assert.Equal(t, http.StatusOK, server.request(http.MethodGet, "/types", "", nil))
+}
+
+func TestThatRequestsForOtherHostsOrWithBodiesThatAreNotJSONAreRejected(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/types", nil)
+	request.Host = "attacker.com:8420"
+	response, err := server.Client().Do(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	for _, host := range []string{"localhost:8420", "127.0.0.1", "[::1]:8420"} {
+		assert.True(t, isAllowedHost(host))
+	}
+	response, err = server.Client().Post(server.URL+"/types", "text/plain", strings.NewReader(`{"Name": "books"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnsupportedMediaType, response.StatusCode)
+	_, err = server.entriesContainer.EntryTypeWithName("books")
+	assert.NotNil(t, err)
+}
+
+func TestThatContainerIsNotLockedWhileBodyOfRequestIsRead(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	locker := &sync.Mutex{}
+	server.Config.Handler.(*Server).SetLocker(locker)
+	bodyReader, bodyWriter := io.Pipe()
+	statuses := make(chan int)
+	go func() {
+		response, err := server.Client().Post(server.URL+"/types", "application/json", bodyReader)
+		if err != nil {
+			log.Fatal(err)
+		}
+		_ = response.Body.Close()
+		statuses <- response.StatusCode
+	}()
+	_, _ = bodyWriter.Write([]byte(`{"Name": `))
+	locker.Lock()
+	locker.Unlock()
+	_, _ = bodyWriter.Write([]byte(`"books"}`))
+	_ = bodyWriter.Close()
+	assert.Equal(t, http.StatusCreated, <-statuses)
+}
+
+func TestThatFailureToSaveChangeIsReported(t *testing.T) {
+	server := startTestServer("")
+	defer server.Close()
+	server.saveError = errors.New("Disk is full")
+	errorResponse := ErrorResponse{}
+	assert.Equal(t, http.StatusInternalServerError, server.request(http.MethodPost, "/types", `{"Name": "books"}`, &errorResponse))
+	assert.Equal(t, "Change has been made but it could not be saved: Disk is full", errorResponse.Error)
+}
@@ -4,46 +4,136 @@ import (
 	"fmt"
 	"fyne.io/fyne"
 	"fyne.io/fyne/container"
-	"fyne.io/fyne/theme"
 	fyneWidget "fyne.io/fyne/widget"
 	"github.com/pkg/errors"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"wirwl/internal/covers"
 	"wirwl/internal/data"
+	"wirwl/internal/images"
 	"wirwl/internal/input"
 	"wirwl/internal/log"
+	"wirwl/internal/metadata"
 	"wirwl/internal/widget"
 )
 
 type App struct {
-	fyneApp                  fyne.App
-	mainWindow               fyne.Window
-	config                   Config
-	loadingErrors            map[string]string
-	addEntryTypeDialog       *widget.FormDialog
-	msgDialog                *widget.MsgDialog
-	confirmationDialog       *widget.ConfirmationDialog
-	entriesTypesTabs         *widget.TabContainer
-	recentlyPressedKeysLabel *fyneWidget.Label
-	entriesContainer         *data.EntriesContainer
-	editEntryTypeDialog      *widget.FormDialog
-	inputHandler             input.Handler
-	entriesTables            map[data.EntryType]*widget.Table
+	fyneApp                    fyne.App
+	mainWindow                 fyne.Window
+	config                     Config
+	loadingErrors              map[string]string
+	addEntryTypeDialog         *widget.FormDialog
+	msgDialog                  *widget.MsgDialog
+	confirmationDialog         *widget.ConfirmationDialog
+	entriesTypesTabs           *widget.TabContainer
+	recentlyPressedKeysLabel   *fyneWidget.Label
+	statusLabel                *fyneWidget.Label
+	entriesContainer           *data.EntriesContainer
+	editEntryTypeDialog        *widget.FormDialog
+	inputHandler               input.Handler
+	entriesTables              map[data.EntryType]*widget.Table
+	editColumnsLayoutDialog    *widget.FormDialog
+	cellEditors                map[cellEditorType]widget.FormDialogEmbeddableWidget
+	entriesCoverGrids          map[data.EntryType]*widget.CoverGrid
+	listsTables                map[string]*widget.Table
+	createListDialog           *widget.FormDialog
+	deleteListDialog           *widget.ConfirmationDialog
+	listsMenu                  *widget.PopUpMenu
+	smartListsTables           map[string]*widget.Table
+	createSmartListDialog      *widget.FormDialog
+	seriesTable                *widget.Table
+	seriesRows                 []seriesRow
+	expandedSeries             map[string]bool
+	createSeriesDialog         *widget.FormDialog
+	deleteSeriesDialog         *widget.ConfirmationDialog
+	seriesMenu                 *widget.PopUpMenu
+	duplicatesMenu             *widget.PopUpMenu
+	duplicatesComparisonDialog *widget.ComparisonDialog
+	mergeMenu                  *widget.PopUpMenu
+	markedEntries              map[string]map[int]bool
+	entryTypesMenu             *widget.PopUpMenu
+	addRelationDialog          *widget.FormDialog
+	relationsMenu              *widget.PopUpMenu
+	typesInCoverDisplayMode    map[string]bool
+	imageStore                 *images.Store
+	importCoverDialog          *widget.FormDialog
+	coverSource                covers.Source
+	//Every entry type has its own metadata URL, so a provider is created for the type of the searched entry
+	metadataProviderFor      func(urlTemplate string) metadata.Provider
+	coverPickerDialog        *widget.CoverPickerDialog
+	progressDialog           *widget.ProgressDialog
+	metadataCandidatesMenu   *widget.PopUpMenu
+	enrichConfirmationDialog *widget.ConfirmationDialog
+	unsavedChangesDialog     *widget.ConfirmationDialog
+	journal                  *data.Journal
+	journalReplayDialog      *widget.ConfirmationDialog
+	entryDetailsDialog       *widget.DetailsDialog
+	statsDialog              *widget.StatsDialog
+	stopAutosave             func()
+	readOnly                 bool
+	instanceLock             *data.InstanceLock
+	commandsListener         net.Listener
+	commandsToken            string
+	commandsServer           *http.Server
+	//Held while entries or the GUI are changed by key actions, confirmations, forwarded commands, background tasks or
+	//autosave, which run on different goroutines. Fyne provides no way to run code on the goroutine that handles its
+	//events, so changing them only while holding the mutex is what keeps the changes from interleaving.
+	entriesMutex sync.Mutex
+	//Long running tasks, e.g. downloading, are run using this function so they don't block the GUI
+	runInBackground func(task func())
+	//Background tasks apply their results using this function, so they change entries and the GUI only when key actions
+	//and others holding the entries mutex don't
+	runInForeground func(task func())
 }
 
 const configLoadError = "CONFIG_LOAD_ERROR"
 const entriesLoadError = "ENTRIES_LOAD_ERROR"
+const imagesLoadError = "IMAGES_LOAD_ERROR"
 
 func NewApp(fyneApp fyne.App, config Config, dataProvider data.Provider, loadingErrors map[string]string) *App {
-	return &App{
-		fyneApp:          fyneApp,
-		config:           config,
-		entriesContainer: data.NewEntriesContainer(dataProvider),
-		loadingErrors:    loadingErrors,
-		entriesTables:    map[data.EntryType]*widget.Table{}}
+	app := &App{
+		fyneApp:                 fyneApp,
+		config:                  config,
+		entriesContainer:        data.NewEntriesContainer(dataProvider),
+		loadingErrors:           loadingErrors,
+		entriesTables:           map[data.EntryType]*widget.Table{},
+		entriesCoverGrids:       map[data.EntryType]*widget.CoverGrid{},
+		listsTables:             map[string]*widget.Table{},
+		smartListsTables:        map[string]*widget.Table{},
+		expandedSeries:          map[string]bool{},
+		typesInCoverDisplayMode: map[string]bool{},
+		markedEntries:           map[string]map[int]bool{},
+		imageStore:              images.NewStore(filepath.Join(config.AppDataDirPath, imagesDirName)),
+		coverSource:             covers.NewHTTPSource(config.CoverSearchURL),
+		metadataProviderFor:     func(urlTemplate string) metadata.Provider { return metadata.NewHTTPProvider(urlTemplate) },
+		journal:                 data.NewJournal(config.JournalFilePath()),
+		runInBackground:         func(task func()) { go task() }}
+	app.runInForeground = app.runWithEntriesLocked
+	return app
+}
+
+func (app *App) runWithEntriesLocked(task func()) {
+	app.entriesMutex.Lock()
+	defer app.entriesMutex.Unlock()
+	task()
 }
 
 func (app *App) LoadAndDisplay() error {
+	if !app.readOnly {
+		err := app.acquireInstanceLock()
+		if err != nil {
+			return err
+		}
+	}
 	app.prepare()
 	app.displayLoadingErrors()
+	if !app.readOnly {
+		app.offerReplayingJournal()
+		app.startAutosaving()
+		app.startServingCommands()
+	}
 	app.mainWindow.ShowAndRun()
 	app.shutdown()
 	return nil
@@ -53,19 +143,23 @@ func (app *App) prepare() {
 	app.setupBasicSettings()
 	app.setupInputHandler()
 	app.loadEntries()
+	app.loadImages()
 	app.loadEntriesTypesTabs()
 	app.prepareDialogs()
+	app.prepareCellEditors()
 	app.prepareMainWindowContent()
 	app.mainWindow.Canvas().SetOnTypedKey(app.onKeyPressed)
 }
 
 func (app *App) setupBasicSettings() {
-	app.mainWindow = app.fyneApp.NewWindow("wirwl")
-	app.fyneApp.Settings().SetTheme(theme.LightTheme())
+	app.mainWindow = app.fyneApp.NewWindow(appName)
+	app.mainWindow.SetCloseIntercept(app.onCloseRequested)
+	app.applyThemeFromConfig()
 }
 
 func (app *App) setupInputHandler() {
 	app.inputHandler = input.NewHandler(app.config.Keymap)
+	app.inputHandler.SetLocker(&app.entriesMutex)
 	app.inputHandler.SetOnKeyPressedCallbackFunction(func(keyCombination input.KeyCombination) {
 		app.recentlyPressedKeysLabel.SetText("Recently pressed keys: " + keyCombination.String())
 	})
@@ -75,7 +169,39 @@ func (app *App) setupInputHandler() {
 	app.inputHandler.BindFunctionToAction(appName, input.AddEntryTypeAction, func() { app.displayDialogForAddingNewEntryType() })
 	app.inputHandler.BindFunctionToAction(appName, input.EditCurrentEntryTypeAction, func() { app.editCurrentEntryType() })
 	app.inputHandler.BindFunctionToAction(appName, input.RemoveEntryTypeAction, func() { app.tryDeletingCurrentEntryType() })
-	app.inputHandler.BindFunctionToAction(appName, input.EnterInputModeAction, func() { app.getCurrentEntryTypeTable().EnterInputMode() })
+	app.inputHandler.BindFunctionToAction(appName, input.EnterInputModeAction, func() { app.enterCurrentEntriesView() })
+	app.inputHandler.BindFunctionToAction(appName, input.EditColumnsLayoutAction, func() { app.displayDialogForEditingColumnsLayout() })
+	app.inputHandler.BindFunctionWithCountToAction(appName, input.IncrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(count) })
+	app.inputHandler.BindFunctionWithCountToAction(appName, input.DecrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(-count) })
+	app.inputHandler.BindFunctionToAction(appName, input.ToggleDisplayModeAction, func() { app.toggleCoverDisplayModeOfCurrentEntryType() })
+	app.inputHandler.BindFunctionToAction(appName, input.ImportCoverAction, func() { app.displayDialogForImportingCover() })
+	app.inputHandler.BindFunctionToAction(appName, input.CreateBackupAction, func() { app.tryCreatingBackup() })
+	app.inputHandler.BindFunctionToAction(appName, input.DownloadCoverAction, func() { app.tryFindingCoversForCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.EnrichEntryAction, func() { app.trySearchingForMetadataOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.UndoAction, func() { app.undoLastChange() })
+	app.inputHandler.BindFunctionToAction(appName, input.RedoAction, func() { app.redoLastUndoneChange() })
+	app.inputHandler.BindFunctionToAction(appName, input.ShowEntryDetailsAction, func() { app.displayDetailsOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.StartRewatchAction, func() { app.startRewatchingCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.ShowStatsAction, func() { app.displayStats() })
+	app.inputHandler.BindFunctionToAction(appName, input.CreateListAction, func() { app.displayDialogForCreatingList() })
+	app.inputHandler.BindFunctionToAction(appName, input.DeleteListAction, func() { app.tryDeletingCurrentList() })
+	app.inputHandler.BindFunctionToAction(appName, input.AddToListAction, func() { app.displayListsToAddCurrentEntryTo() })
+	app.inputHandler.BindFunctionToAction(appName, input.RemoveFromListAction, func() { app.removeCurrentEntryFromList() })
+	app.inputHandler.BindFunctionWithCountToAction(appName, input.MoveDownInListAction, func(count int) { app.moveCurrentEntryInList(count) })
+	app.inputHandler.BindFunctionWithCountToAction(appName, input.MoveUpInListAction, func(count int) { app.moveCurrentEntryInList(-count) })
+	app.inputHandler.BindFunctionToAction(appName, input.CreateSmartListAction, func() { app.displayDialogForCreatingSmartList() })
+	app.inputHandler.BindFunctionToAction(appName, input.SwitchThemeAction, func() { app.switchToNextTheme() })
+	app.inputHandler.BindFunctionToAction(appName, input.AddRelationAction, func() { app.displayDialogForAddingRelation() })
+	app.inputHandler.BindFunctionToAction(appName, input.RemoveRelationAction, func() { app.displayRelationsToRemoveFromCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.GoToRelatedEntryAction, func() { app.displayEntriesRelatedToCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.CreateSeriesAction, func() { app.displayDialogForCreatingSeries() })
+	app.inputHandler.BindFunctionToAction(appName, input.DeleteSeriesAction, func() { app.tryDeletingCurrentSeries() })
+	app.inputHandler.BindFunctionToAction(appName, input.AddToSeriesAction, func() { app.displaySeriesToAddCurrentEntryTo() })
+	app.inputHandler.BindFunctionToAction(appName, input.RemoveFromSeriesAction, func() { app.removeCurrentEntryFromSeries() })
+	app.inputHandler.BindFunctionToAction(appName, input.FindDuplicatesAction, func() { app.displayDuplicateEntries() })
+	app.inputHandler.BindFunctionToAction(appName, input.ToggleEntryMarkAction, func() { app.toggleMarkOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(appName, input.MoveEntriesAction, func() { app.displayEntryTypesToMoveEntriesTo() })
+	app.inputHandler.BindFunctionToAction(appName, input.CopyEntriesAction, func() { app.displayEntryTypesToCopyEntriesTo() })
 }
 
 func (app *App) loadEntries() {
@@ -85,6 +211,14 @@ func (app *App) loadEntries() {
 		err = errors.Wrap(err, msg)
 		log.Error(err)
 		app.loadingErrors[entriesLoadError] = msg
+	} else {
+		app.removeOrphanedColumnsLayouts()
+	}
+	if app.config.UndoDepth > 0 {
+		err = app.entriesContainer.SetUndoDepth(app.config.UndoDepth)
+		if err != nil {
+			log.Error(err)
+		}
 	}
 	if app.entriesContainer.AmountOfTypes() == 0 {
 		noEntriesType := data.EntryType{
@@ -97,7 +231,15 @@ func (app *App) loadEntries() {
 			log.Error(err)
 		}
 	}
-	app.entriesContainer.SubscribeToChanges(app.reloadGUI)
+	//Placeholder type is not journaled as it's added every time there are no entry types anyway.
+	//Journal of an instance that opened the collection read-only would mix its changes with the changes of another instance.
+	if !app.readOnly {
+		app.entriesContainer.SetJournal(app.journal)
+	}
+	app.entriesContainer.SubscribeToChanges(app.updateGUIAfterChange)
+	app.entriesContainer.SubscribeToChanges(func(data.ChangeEvent) { app.updateWindowTitle() })
+	app.entriesContainer.SubscribeToChanges(func(data.ChangeEvent) { app.autosaveAfterChange() })
+	app.updateWindowTitle()
 }
 
 func (app *App) loadEntriesTypesTabs() {
@@ -111,10 +253,19 @@ func (app *App) createTabsWithEntriesTableForEachEntryType() map[string][]fyne.C
 	entriesGroupedByType := app.entriesContainer.EntriesGroupedByType()
 	tabsData := make(map[string][]fyne.CanvasObject, len(entriesGroupedByType))
 	for entryType, entries := range entriesGroupedByType {
-		app.createEntriesTable(entryType, entries)
-		tabElements := make([]fyne.CanvasObject, 0, 1)
-		tabElements = append(tabElements, app.entriesTables[entryType])
-		tabsData[entryType.Name] = tabElements
+		tabsData[entryType.Name] = app.createEntriesViews(entryType, entries)
+	}
+	for _, list := range app.entriesContainer.Lists() {
+		app.listsTables[list.Name] = app.createListTable(list.Items)
+		tabsData[listTabName(list.Name)] = []fyne.CanvasObject{app.listsTables[list.Name]}
+	}
+	for smartListName := range app.config.SmartLists {
+		app.smartListsTables[smartListName] = app.createListTable(app.smartListItems(smartListName))
+		tabsData[smartListTabName(smartListName)] = []fyne.CanvasObject{app.smartListsTables[smartListName]}
+	}
+	if len(app.entriesContainer.AllSeries()) > 0 {
+		app.seriesTable = app.createSeriesTable()
+		tabsData[seriesTabName] = []fyne.CanvasObject{app.seriesTable}
 	}
 	return tabsData
 }
@@ -153,7 +304,9 @@ func getNoEntriesTab() map[string][]string {
 
 func (app *App) prepareMainWindowContent() {
 	app.recentlyPressedKeysLabel = fyneWidget.NewLabel("Recently pressed keys: ")
-	content := container.NewBorder(app.entriesTypesTabs, app.recentlyPressedKeysLabel, nil, nil)
+	app.statusLabel = fyneWidget.NewLabel("")
+	statusBar := container.NewHBox(app.recentlyPressedKeysLabel, app.statusLabel)
+	content := container.NewBorder(app.entriesTypesTabs, statusBar, nil, nil)
 	app.mainWindow.SetContent(content)
 }
 
@@ -172,18 +325,35 @@ func (app *App) displayLoadingErrors() {
 	}
 }
 
+//Confirmed or cancelled changes are made while holding the entries mutex, like changes made by key actions
+func (app *App) newConfirmationDialog() *widget.ConfirmationDialog {
+	dialog := widget.NewConfirmationDialog(app.mainWindow.Canvas())
+	dialog.SetLocker(&app.entriesMutex)
+	return dialog
+}
+
 func (app *App) prepareDialogs() {
 	app.msgDialog = widget.NewMsgPopUp(app.mainWindow.Canvas())
-	app.confirmationDialog = widget.NewConfirmationDialog(app.mainWindow.Canvas())
+	app.confirmationDialog = app.newConfirmationDialog()
 	app.confirmationDialog.OnConfirm = app.deleteCurrentEntryType
 	app.createAddEntryTypeDialog()
 	app.editEntryTypeDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Editing entry type: "+app.getCurrentTabText(), app.createEntryTypeRelatedDialogElements()...)
 	app.editEntryTypeDialog.OnEnterPressed = app.applyChangesToCurrentEntryType
-}
-
-func (app *App) reloadGUI() {
-	app.loadEntriesTypesTabs()
-	app.prepareMainWindowContent()
+	app.createEditColumnsLayoutDialog()
+	app.createImportCoverDialog()
+	app.coverPickerDialog = widget.NewCoverPickerDialog(app.mainWindow.Canvas(), app.inputHandler)
+	app.progressDialog = widget.NewProgressDialog(app.mainWindow.Canvas())
+	app.enrichConfirmationDialog = app.newConfirmationDialog()
+	app.createUnsavedChangesDialog()
+	app.createJournalReplayDialog()
+	app.entryDetailsDialog = widget.NewDetailsDialog(app.mainWindow.Canvas())
+	app.statsDialog = widget.NewStatsDialog(app.mainWindow.Canvas())
+	app.createListDialogs()
+	app.prepareSmartListDialog()
+	app.createAddRelationDialog()
+	app.createSeriesDialogs()
+	app.createDuplicatesDialogs()
+	app.createMovingEntriesDialogs()
 }
 
 func (app *App) createEntryTypeRelatedDialogElements() []*widget.FormDialogFormItem {
@@ -191,9 +361,13 @@ func (app *App) createEntryTypeRelatedDialogElements() []*widget.FormDialogFormI
 	entryTypeRelatedDialogElements := []*widget.FormDialogFormItem{}
 	entryTypeRelatedDialogElements = append(entryTypeRelatedDialogElements, formItemFactory.FormItemWithInputField("Name"))
 	entryTypeRelatedDialogElements = append(entryTypeRelatedDialogElements, formItemFactory.FormItemWithInputField("Image query"))
+	entryTypeRelatedDialogElements = append(entryTypeRelatedDialogElements, formItemFactory.FormItemWithInputField("Metadata URL"))
+	entryTypeRelatedDialogElements = append(entryTypeRelatedDialogElements, formItemFactory.FormItemWithInputField("Statuses"))
+	entryTypeRelatedDialogElements = append(entryTypeRelatedDialogElements, formItemFactory.FormItemWithInputField("Scoring system"))
 	return entryTypeRelatedDialogElements
 }
 
+//Columns layout and cover images of the deleted type are kept so the deletion can be undone
 func (app *App) deleteCurrentEntryType() {
 	nameOfTypeToDelete := app.getCurrentTabText()
 	err := app.entriesContainer.DeleteEntryType(nameOfTypeToDelete)
@@ -203,7 +377,13 @@ func (app *App) deleteCurrentEntryType() {
 	}
 }
 
+//When a list is selected, the current entry type is the type of the list's selected entry
 func (app *App) getCurrentEntryType() data.EntryType {
+	if _, isList := app.currentListTable(); isList {
+		item, _ := app.currentListItem()
+		entryType, _ := app.entriesContainer.EntryTypeWithName(item.TypeName)
+		return entryType
+	}
 	currentEntryTypeName := app.getCurrentTabText()
 	currentEntryType, err := app.entriesContainer.EntryTypeWithName(currentEntryTypeName)
 	if err != nil {
@@ -235,14 +415,23 @@ func (app *App) displayDialogForAddingNewEntryType() {
 }
 
 func (app *App) editCurrentEntryType() {
+	if app.failIfListIsSelected() {
+		return
+	}
 	currentEntryType := app.getCurrentEntryType()
 	app.editEntryTypeDialog.SetItemValue("Name", currentEntryType.Name)
 	app.editEntryTypeDialog.SetItemValue("Image query", currentEntryType.ImageQuery)
+	app.editEntryTypeDialog.SetItemValue("Metadata URL", currentEntryType.MetadataURL)
+	app.editEntryTypeDialog.SetItemValue("Statuses", data.FormatStatuses(currentEntryType.StatusesList()))
+	app.editEntryTypeDialog.SetItemValue("Scoring system", string(currentEntryType.Scoring()))
 	app.editEntryTypeDialog.Display()
 }
 
 func (app *App) tryDeletingCurrentEntryType() {
-	if len(app.entriesTypesTabs.Items()) > 1 {
+	if app.failIfListIsSelected() {
+		return
+	}
+	if app.entriesContainer.AmountOfTypes() > 1 {
 		app.confirmationDialog.Display("Are you sure you want to delete entry type '" + app.entriesTypesTabs.CurrentTab().Text + "'?")
 	} else {
 		app.msgDialog.Display(widget.WarningPopUp, "You cannot remove the only remaining entry type!")
@@ -250,19 +439,27 @@ func (app *App) tryDeletingCurrentEntryType() {
 }
 
 func (app *App) trySavingChangesToDb() {
+	if app.readOnly {
+		app.msgDialog.Display(widget.WarningPopUp, readOnlySavingWarning)
+		return
+	}
 	err := app.entriesContainer.SaveData()
 	if err != nil {
 		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
 	} else {
 		app.msgDialog.Display(widget.SuccessPopUp, "Changes saved.")
 	}
+	app.updateWindowTitle()
 }
 
+//Config of an instance that opened the collection read-only is not saved, as it's used by another instance
 func (app *App) shutdown() {
-	if _, configLoadingErrorExists := app.loadingErrors[configLoadError]; !configLoadingErrorExists {
+	app.stopAutosaving()
+	if _, configLoadingErrorExists := app.loadingErrors[configLoadError]; !configLoadingErrorExists && !app.readOnly {
 		err := app.config.save()
 		if err != nil {
 			log.Error(err)
 		}
 	}
+	app.releaseInstanceLock()
 }
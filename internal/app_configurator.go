@@ -17,6 +17,8 @@ type AppConfigurator struct {
 	configDirPath string
 	logFile       *os.File
 	loadingErrors map[string]string
+	//Whether the collection should be opened read-only
+	readOnly bool
 }
 
 func NewAppConfigurator(configDirPath string) AppConfigurator {
@@ -37,7 +39,14 @@ func (configurator *AppConfigurator) LoadConfig() (Config, error) {
 	return config, nil
 }
 
+func (configurator *AppConfigurator) SetReadOnly(readOnly bool) {
+	configurator.readOnly = readOnly
+}
+
 func (configurator *AppConfigurator) LoadDataProvider(dbPath string) data.Provider {
+	if configurator.readOnly {
+		return data.NewReadOnlyBoltProvider(dbPath)
+	}
 	return data.NewBoltProvider(dbPath)
 }
 
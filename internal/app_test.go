@@ -3,15 +3,24 @@ package wirwl
 import (
 	"errors"
 	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/theme"
 	fyneWidget "fyne.io/fyne/widget"
 	"github.com/stretchr/testify/assert"
+	"image/color"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 	"wirwl/internal/data"
+	"wirwl/internal/images"
 	"wirwl/internal/input"
 	"wirwl/internal/log"
+	"wirwl/internal/metadata"
+	"wirwl/internal/stats"
+	"wirwl/internal/widget"
 )
 
 func TestThatApplicationDisplaysNoEntriesTabWhenRunForFirstTime(t *testing.T) {
@@ -343,13 +352,14 @@ func TestThatRecentlyPressedKeysLabelDisplaysTheCorrectInformation(t *testing.T)
 	assert.Equal(t, "Recently pressed keys: Escape", app.recentlyPressedKeysLabel.Text)
 }
 
+//Image query column is hidden by default as it's only used internally
 func TestThatEntriesTablesHaveCorrectAmountOfHeaderColumns(t *testing.T) {
 	configurator := NewTestAppConfigurator()
 	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
 	defer cleanup()
 	for entryType, entriesTable := range app.entriesTables {
 		amountOfHeaderColumns := len(entriesTable.HeaderColumns())
-		assert.Equal(t, 14, amountOfHeaderColumns, "The table for entry type "+entryType.Name+" has incorrect amount of header columns")
+		assert.Equal(t, 13, amountOfHeaderColumns, "The table for entry type "+entryType.Name+" has incorrect amount of header columns")
 	}
 }
 
@@ -368,7 +378,6 @@ func TestThatEntriesTablesHaveCorrectHeaderLabels(t *testing.T) {
 		"Description",
 		"Comment",
 		"Tags",
-		"Image query",
 	}
 	configurator := NewTestAppConfigurator()
 	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
@@ -388,3 +397,1456 @@ func TestThatEntriesTableGetsFocusedWhenPressingKey(t *testing.T) {
 	app.simulateKeyPress(fyne.KeyI)
 	assert.Equal(t, app.getCurrentEntryTypeTable(), app.mainWindow.Canvas().Focused())
 }
+
+func TestThatEditingColumnsLayoutChangesColumnsOfCurrentEntryTypeTableOnly(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateSwitchingToNextEntryType()
+	app.simulateEditionOfColumnsLayout(map[string]string{
+		"Title":       "1:300",
+		"Status":      "2",
+		"Image query": "3",
+	})
+	assert.Equal(t, "music", app.getCurrentTabText())
+	headerColumns := app.getCurrentEntryTypeTable().HeaderColumns()
+	assert.Equal(t, 3, len(headerColumns))
+	assert.Equal(t, "Title", headerColumns[0].(*fyneWidget.Label).Text)
+	assert.Equal(t, "Status", headerColumns[1].(*fyneWidget.Label).Text)
+	assert.Equal(t, "Image query", headerColumns[2].(*fyneWidget.Label).Text)
+	assert.Equal(t, []ColumnSettings{{"Title", 300}, {"Status", 0}, {"Image query", 0}}, app.config.ColumnsLayoutFor("music"))
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, 13, len(app.entriesTables[comicsType].HeaderColumns()))
+}
+
+func TestThatColumnsLayoutDialogDisplaysCurrentLayout(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfColumnsLayout(map[string]string{"Title": "2:250", "Num": "1"})
+	app.simulateOpeningDialogForEditingColumnsLayout()
+	assert.True(t, app.editColumnsLayoutDialog.Visible())
+	assert.Equal(t, "1", app.editColumnsLayoutDialog.ItemValue("Num"))
+	assert.Equal(t, "2:250", app.editColumnsLayoutDialog.ItemValue("Title"))
+	assert.Equal(t, "", app.editColumnsLayoutDialog.ItemValue("Status"))
+}
+
+func TestThatErrorIsDisplayedAndLayoutIsNotChangedWhenColumnsLayoutIsIncorrect(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfColumnsLayout(map[string]string{"Title": "first"})
+	assert.True(t, app.msgDialog.Visible())
+	assert.Equal(t, "ERROR", app.msgDialog.Title())
+	assert.Equal(t, "Position of column 'Title' has to be a number greater than 0", app.msgDialog.Msg())
+	assert.Equal(t, defaultColumnsLayout(), app.config.ColumnsLayoutFor("comics"))
+	app.simulateKeyPress(fyne.KeyEscape)
+	assert.True(t, app.editColumnsLayoutDialog.Visible())
+}
+
+func TestThatColumnsLayoutFollowsEntryTypeWhenItIsRenamedAndIsRemovedAfterItGetsDeleted(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfColumnsLayout(map[string]string{"Title": "1"})
+	app.simulateEditionOfCurrentEntryTypeTo("2")
+	assert.Equal(t, []ColumnSettings{{"Title", 0}}, app.config.ColumnsLayoutFor("2comics"))
+	assert.Equal(t, 1, len(app.getCurrentEntryTypeTable().HeaderColumns()))
+	app.simulateDeletionOfCurrentEntryType()
+	_, layoutExists := app.config.ColumnsLayouts["2comics"]
+	assert.True(t, layoutExists)
+	//Layouts of deleted types are removed the next time the application starts
+	app.removeOrphanedColumnsLayouts()
+	_, layoutExists = app.config.ColumnsLayouts["2comics"]
+	assert.False(t, layoutExists)
+}
+
+func TestThatColumnsLayoutPersistsAfterReopeningTheApplication(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfColumnsLayout(map[string]string{"Title": "1:300", "Score": "2"})
+	app, cleanup = configurator.getRunningTestApplication()
+	defer cleanup()
+	loadedConfig := NewConfig(testConfigDirPath)
+	err := loadedConfig.load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, []ColumnSettings{{"Title", 300}, {"Score", 0}}, loadedConfig.ColumnsLayoutFor("comics"))
+	assert.Equal(t, 2, len(app.getCurrentEntryTypeTable().HeaderColumns()))
+}
+
+//Tasks are run by key actions which already hold the entries mutex, so running them in foreground can't lock it again
+func (app *App) runTasksSynchronously() {
+	app.runInBackground = func(task func()) { task() }
+	app.runInForeground = func(task func()) { task() }
+}
+
+func (app *App) getCurrentEntryTypeEntries() []data.Entry {
+	return app.entriesContainer.EntriesGroupedByType()[app.getCurrentEntryType()]
+}
+
+func TestThatEditingTextCellInEntriesTableUpdatesEntry(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfCellInCurrentEntryTypeTable(1, 3, "new title")
+	assert.Equal(t, "new title", app.getCurrentEntryTypeEntries()[1].Title)
+	assert.Equal(t, "some comic1", app.getCurrentEntryTypeEntries()[0].Title)
+	table := app.getCurrentEntryTypeTable()
+	assert.False(t, table.IsEditing())
+	assert.Equal(t, table, app.mainWindow.Canvas().Focused())
+	assert.Equal(t, 1, table.CurrentRowNum())
+	assert.Equal(t, 3, table.CurrentColumnNum())
+}
+
+func TestThatEditingNumericAndDateCellsInEntriesTableUpdatesEntry(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfCellInCurrentEntryTypeTable(0, 4, "15")
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateTypingIntoCellEditor("31/12/2020")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, 15, app.getCurrentEntryTypeEntries()[0].ElementsCompleted)
+	assert.Equal(t, "31/12/2020", app.getCurrentEntryTypeEntries()[0].StartDate)
+}
+
+func TestThatEditingStatusCellInEntriesTableUpdatesEntry(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(0, 2)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, data.CompletedStatus, app.getCurrentEntryTypeEntries()[0].Status)
+	assert.Equal(t, app.getCurrentEntryTypeTable(), app.mainWindow.Canvas().Focused())
+}
+
+func TestThatStatusesOfEntryTypeCanBeEditedAndAreOfferedWhenEditingStatusCell(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyT)
+	app.simulateKeyPress(fyne.KeyE)
+	assert.Equal(t, "In progress, Completed, On hold, Dropped, Planned", app.editEntryTypeDialog.ItemValue("Statuses"))
+	app.editEntryTypeDialog.SetItemValue("Statuses", "Reading=In progress, Read=Completed, Planned")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, data.EntryStatus("Reading"), app.getCurrentEntryTypeEntries()[0].Status)
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(0, 2)
+	assert.Equal(t, []string{"Reading", "Read", "Planned"}, app.cellEditors[statusCellEditor].(*widget.Select).Options)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, data.EntryStatus("Read"), app.getCurrentEntryTypeEntries()[0].Status)
+}
+
+func TestThatScoresAreDisplayedAndEditedInScoringSystemOfEntryType(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyT)
+	app.simulateKeyPress(fyne.KeyE)
+	assert.Equal(t, "1-10", app.editEntryTypeDialog.ItemValue("Scoring system"))
+	app.editEntryTypeDialog.SetItemValue("Scoring system", "5 stars")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, data.FiveStarScoring, app.getCurrentEntryType().ScoringSystem)
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(0, 6)
+	assert.Equal(t, "★½", app.cellEditors[textCellEditor].GetText())
+	app.simulateTypingIntoCellEditor("4,5")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, 9, app.getCurrentEntryTypeEntries()[0].Score)
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(0, 6)
+	app.simulateTypingIntoCellEditor("6")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, "Score '6' has to be an amount of stars from 0.5 to 5 in halves, e.g. 3.5", app.getCurrentEntryTypeTable().EditingError())
+}
+
+func TestThatEntryTypeCannotBeEditedToHaveIncorrectStatuses(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyT)
+	app.simulateKeyPress(fyne.KeyE)
+	app.editEntryTypeDialog.SetItemValue("Statuses", "Reading")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, "ERROR", app.msgDialog.Title())
+	assert.Contains(t, app.msgDialog.Msg(), "Status 'Reading' has to belong to one of the built-in statuses")
+	assert.Equal(t, data.InProgressStatus, app.getCurrentEntryTypeEntries()[0].Status)
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.True(t, app.editEntryTypeDialog.Visible())
+	assert.Equal(t, "Reading", app.editEntryTypeDialog.ItemValue("Statuses"))
+}
+
+func TestThatCancellingCellEditionDoesNotChangeEntry(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(0, 3)
+	app.simulateTypingIntoCellEditor("new title")
+	app.simulateKeyPress(fyne.KeyEscape)
+	assert.Equal(t, "some comic1", app.getCurrentEntryTypeEntries()[0].Title)
+	assert.False(t, app.getCurrentEntryTypeTable().IsEditing())
+	assert.Equal(t, app.getCurrentEntryTypeTable(), app.mainWindow.Canvas().Focused())
+}
+
+func TestThatIncorrectValuesInEditedCellsDisplayErrorAndDoNotChangeEntry(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateEditionOfCellInCurrentEntryTypeTable(0, 3, "")
+	table := app.getCurrentEntryTypeTable()
+	assert.True(t, table.IsEditing())
+	assert.Equal(t, "Title cannot be empty", table.EditingError())
+	assert.Equal(t, table.Editor(), app.mainWindow.Canvas().Focused())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateTypingIntoCellEditor("31/31/2020")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, "Finish date has to be a date in format DD/MM/YYYY", table.EditingError())
+	assert.Equal(t, data.GetExampleComicEntries()[0], app.getCurrentEntryTypeEntries()[0])
+}
+
+func TestThatNotEditableCellsCannotBeEdited(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(0, 0)
+	assert.False(t, app.getCurrentEntryTypeTable().IsEditing())
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyI)
+	assert.False(t, app.getCurrentEntryTypeTable().IsEditing())
+}
+
+func TestThatProgressOfEntryCanBeChangedWithoutEnteringTable(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	entry := app.getCurrentEntryTypeEntries()[0]
+	assert.Equal(t, 2, entry.ElementsCompleted)
+	assert.Equal(t, data.CompletedStatus, entry.Status)
+	app.simulateKeyPress(fyne.KeyMinus)
+	entry = app.getCurrentEntryTypeEntries()[0]
+	assert.Equal(t, 1, entry.ElementsCompleted)
+	assert.Equal(t, data.InProgressStatus, entry.Status)
+	assert.Empty(t, entry.FinishDate)
+	assert.NotEqual(t, app.getCurrentEntryTypeTable(), app.mainWindow.Canvas().Focused())
+}
+
+func TestThatProgressOfSelectedEntryCanBeChangedByCountTypedBeforeAction(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.Key3)
+	app.simulateKeyPress(fyne.KeyMinus)
+	assert.Equal(t, 1, app.getCurrentEntryTypeEntries()[1].ElementsCompleted)
+	assert.Equal(t, data.GetExampleComicEntries()[0], app.getCurrentEntryTypeEntries()[0])
+	table := app.getCurrentEntryTypeTable()
+	assert.Equal(t, table, app.mainWindow.Canvas().Focused())
+	assert.Equal(t, 1, table.CurrentRowNum())
+	assert.Equal(t, 1, table.CurrentColumnNum())
+	app.simulateKeyPress(fyne.Key1)
+	app.simulateKeyPress(fyne.Key0)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Equal(t, 5, app.getCurrentEntryTypeEntries()[1].ElementsCompleted)
+	assert.Equal(t, data.CompletedStatus, app.getCurrentEntryTypeEntries()[1].Status)
+}
+
+func TestThatCoverDisplayModeCanBeToggledForCurrentEntryType(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	grid := app.getCurrentEntryTypeCoverGrid()
+	assert.NotNil(t, grid)
+	assert.True(t, widget.ContainsWidget(app.entriesTypesTabs.CurrentTab().Content, grid))
+	assert.Equal(t, 2, grid.ItemAmount())
+	app.simulateSwitchingToNextEntryType()
+	assert.True(t, widget.ContainsWidget(app.entriesTypesTabs.CurrentTab().Content, app.getCurrentEntryTypeTable()))
+	app.simulateSwitchingToPreviousEntryType()
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	assert.True(t, widget.ContainsWidget(app.entriesTypesTabs.CurrentTab().Content, app.getCurrentEntryTypeTable()))
+	assert.Nil(t, app.getCurrentEntryTypeCoverGrid())
+}
+
+func TestThatSelectedEntryAndFocusStayTheSameAfterTogglingDisplayMode(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	grid := app.getCurrentEntryTypeCoverGrid()
+	assert.Equal(t, grid, app.mainWindow.Canvas().Focused())
+	assert.Equal(t, 1, grid.CurrentItemNum())
+	grid.SelectItem(0)
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	table := app.getCurrentEntryTypeTable()
+	assert.Equal(t, table, app.mainWindow.Canvas().Focused())
+	assert.Equal(t, 0, table.CurrentRowNum())
+}
+
+func TestThatCoverGridCanBeEnteredAndLeftAndProgressCanBeChangedInIt(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	app.simulateKeyPress(fyne.KeyI)
+	grid := app.getCurrentEntryTypeCoverGrid()
+	assert.Equal(t, grid, app.mainWindow.Canvas().Focused())
+	grid.SelectItem(1)
+	app.simulateKeyPress(fyne.KeyMinus)
+	assert.Equal(t, 3, app.getCurrentEntryTypeEntries()[1].ElementsCompleted)
+	grid = app.getCurrentEntryTypeCoverGrid()
+	assert.Equal(t, grid, app.mainWindow.Canvas().Focused())
+	assert.Equal(t, 1, grid.CurrentItemNum())
+	app.simulateKeyPress(fyne.KeySpace)
+	assert.Nil(t, app.mainWindow.Canvas().Focused())
+}
+
+func TestThatCoverImagesAreDisplayedInImageColumnAndCoverGrid(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeySpace)
+	app.simulateImportingCover(createTestImageFile("cover.png"))
+	coverPath, exists := app.imageStore.ThumbnailPath(app.getCurrentEntryTypeEntries()[1].Cover, images.MediumThumbnail)
+	assert.True(t, exists)
+	assert.Empty(t, app.getCurrentEntryTypeEntries()[0].Cover)
+	imageColumnNum := 1
+	table := app.getCurrentEntryTypeTable()
+	assert.Empty(t, table.Cell(0, imageColumnNum).(*canvas.Image).File)
+	assert.Equal(t, coverPath, table.Cell(1, imageColumnNum).(*canvas.Image).File)
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	assert.Equal(t, coverPath, app.getCurrentEntryTypeCoverGrid().Item(1).Cover.(*canvas.Image).File)
+}
+
+func TestThatErrorIsDisplayedWhenImportedCoverIsNotAnImage(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	notImagePath := filepath.Join(testDataDirPath, "not_image.png")
+	err := ioutil.WriteFile(notImagePath, []byte("not really an image"), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	app.simulateImportingCover(notImagePath)
+	assert.False(t, app.msgDialog.Hidden)
+	assert.Contains(t, app.msgDialog.Msg(), "Given file is not an image")
+	assert.Empty(t, app.getCurrentEntryTypeEntries()[0].Cover)
+}
+
+func TestThatCoverImagesFollowEntriesAndAreRemovedOnlyAfterChangesAreSaved(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateImportingCover(createTestImageFile("cover.png"))
+	cover := app.getCurrentEntryTypeEntries()[0].Cover
+	assert.True(t, app.imageStore.HasImage(cover))
+	app.simulateEditionOfCurrentEntryTypeTo("2")
+	assert.Equal(t, cover, app.getCurrentEntryTypeEntries()[0].Cover)
+	err := app.entriesContainer.DeleteEntry("2comics", 1)
+	assert.Nil(t, err)
+	err = app.entriesContainer.DeleteEntry("2comics", 0)
+	assert.Nil(t, err)
+	id, err := app.entriesContainer.AddEntry("2comics", data.Entry{Title: "new comic", Status: data.InProgressStatus})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, id)
+	assert.Empty(t, app.getCurrentEntryTypeEntries()[0].Cover)
+	//Unsaved deletion can still be undone, so the image is kept
+	app.removeOrphanedImages()
+	assert.True(t, app.imageStore.HasImage(cover))
+	app.simulateUndo()
+	app.simulateUndo()
+	assert.Equal(t, cover, app.getCurrentEntryTypeEntries()[0].Cover)
+	app.simulateDeletionOfCurrentEntryType()
+	app.simulateSavingChanges()
+	app.removeOrphanedImages()
+	assert.False(t, app.imageStore.HasImage(cover))
+}
+
+func TestThatBackupContainsDatabaseAndCoverImages(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateImportingCover(createTestImageFile("cover.png"))
+	backupDirPath, err := app.createBackup(time.Date(2020, time.March, 14, 15, 9, 26, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(testAppDataDirPath, "backups", "2020-03-14_15-09-26"), backupDirPath)
+	assert.FileExists(t, filepath.Join(backupDirPath, "data.db"))
+	backupImageStore := images.NewStore(filepath.Join(backupDirPath, "images"))
+	err = backupImageStore.Load()
+	assert.Nil(t, err)
+	imagePath, exists := backupImageStore.ImagePath(app.getCurrentEntryTypeEntries()[0].Cover)
+	assert.True(t, exists)
+	assert.FileExists(t, imagePath)
+}
+
+func TestThatCoverCanBeDownloadedFromCoverSource(t *testing.T) {
+	var requestedPaths []string
+	server := startTestCoverServer(3, &requestedPaths)
+	defer server.Close()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.CoverSearchURL = server.URL + "/search?q={title}+{query}"
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.runTasksSynchronously()
+	app.simulateFindingCovers()
+	assert.False(t, app.coverPickerDialog.Hidden)
+	assert.Equal(t, 3, app.coverPickerDialog.ItemAmount())
+	assert.Equal(t, []string{"/search", "/thumbnail0.png", "/thumbnail1.png", "/thumbnail2.png"}, requestedPaths)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.True(t, app.coverPickerDialog.Hidden)
+	assert.True(t, app.progressDialog.Hidden)
+	assert.Equal(t, "/image1.png", requestedPaths[len(requestedPaths)-1])
+	assert.True(t, app.imageStore.HasImage(app.getCurrentEntryTypeEntries()[0].Cover))
+}
+
+func TestThatWarningIsDisplayedWhenCoverSearchURLIsNotSet(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateFindingCovers()
+	assert.False(t, app.msgDialog.Hidden)
+	assert.Equal(t, "WARNING", app.msgDialog.Title())
+	assert.True(t, app.coverPickerDialog.Hidden)
+}
+
+func TestThatErrorIsDisplayedWhenCoverSourceFails(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.CoverSearchURL = "http://127.0.0.1:1/search?q={title}"
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.runTasksSynchronously()
+	app.simulateFindingCovers()
+	assert.False(t, app.msgDialog.Hidden)
+	assert.Equal(t, "ERROR", app.msgDialog.Title())
+	assert.Contains(t, app.msgDialog.Msg(), "Failed to search for covers")
+}
+
+func TestThatMetadataURLOfEntryTypeCanBeEdited(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyT)
+	app.simulateKeyPress(fyne.KeyE)
+	app.editEntryTypeDialog.SetItemValue("Metadata URL", "http://example.com/search?q={title}")
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Equal(t, "http://example.com/search?q={title}", app.getCurrentEntryType().MetadataURL)
+	app.simulateKeyPress(fyne.KeyT)
+	app.simulateKeyPress(fyne.KeyE)
+	assert.Equal(t, "http://example.com/search?q={title}", app.editEntryTypeDialog.ItemValue("Metadata URL"))
+}
+
+func TestThatEntryCanBeEnrichedWithMetadataAfterConfirmingProposedChanges(t *testing.T) {
+	server := startTestMetadataServer()
+	defer server.Close()
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.runTasksSynchronously()
+	err := app.entriesContainer.UpdateEntryType("comics", data.EntryType{Name: "comics", MetadataURL: server.URL + "/search?q={title}"})
+	assert.Nil(t, err)
+	entryBefore := app.getCurrentEntryTypeEntries()[0]
+	app.simulateSearchingForMetadataOfCurrentEntry()
+	assert.True(t, app.metadataCandidatesMenu.Visible())
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.False(t, app.enrichConfirmationDialog.Hidden)
+	assert.Contains(t, app.enrichConfirmationDialog.Msg(), "Description: '"+entryBefore.Description+"' -> 'Found description'")
+	assert.Contains(t, app.enrichConfirmationDialog.Msg(), "Link: '"+entryBefore.Link+"' -> '"+server.URL+"/works/1'")
+	assert.Contains(t, app.enrichConfirmationDialog.Msg(), "Cover: will be downloaded from "+server.URL+"/covers/1.png")
+	assert.NotContains(t, app.enrichConfirmationDialog.Msg(), "Total amount")
+	app.simulateKeyPress(fyne.KeyY)
+	entryAfter := app.getCurrentEntryTypeEntries()[0]
+	assert.Equal(t, "Found description", entryAfter.Description)
+	assert.Equal(t, server.URL+"/works/1", entryAfter.Link)
+	assert.Equal(t, entryBefore.Title, entryAfter.Title)
+	assert.True(t, app.imageStore.HasImage(entryAfter.Cover))
+}
+
+type testMetadataProvider struct {
+	candidates []metadata.Candidate
+}
+
+func (provider testMetadataProvider) Search(string) ([]metadata.Candidate, error) {
+	return provider.candidates, nil
+}
+
+func TestThatMetadataIsAppliedToEntryAsItIsWhenChangesAreConfirmed(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.runTasksSynchronously()
+	app.metadataProviderFor = func(string) metadata.Provider {
+		return testMetadataProvider{[]metadata.Candidate{{Title: "found title", Description: "Found description"}}}
+	}
+	err := app.entriesContainer.UpdateEntryType("comics", data.EntryType{Name: "comics", MetadataURL: "unused"})
+	assert.Nil(t, err)
+	app.simulateSearchingForMetadataOfCurrentEntry()
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.False(t, app.enrichConfirmationDialog.Hidden)
+	changedEntry := app.getCurrentEntryTypeEntries()[0]
+	changedEntry.Title = "changed title"
+	err = app.entriesContainer.UpdateEntry("comics", changedEntry)
+	assert.Nil(t, err)
+	app.simulateKeyPress(fyne.KeyY)
+	entryAfter := app.getCurrentEntryTypeEntries()[0]
+	assert.Equal(t, "changed title", entryAfter.Title)
+	assert.Equal(t, "Found description", entryAfter.Description)
+}
+
+func TestThatEntryIsNotChangedWhenProposedMetadataChangesAreRejected(t *testing.T) {
+	server := startTestMetadataServer()
+	defer server.Close()
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.runTasksSynchronously()
+	err := app.entriesContainer.UpdateEntryType("comics", data.EntryType{Name: "comics", MetadataURL: server.URL + "/search?q={title}"})
+	assert.Nil(t, err)
+	entryBefore := app.getCurrentEntryTypeEntries()[0]
+	app.simulateSearchingForMetadataOfCurrentEntry()
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.Contains(t, app.enrichConfirmationDialog.Msg(), "Total amount")
+	assert.NotContains(t, app.enrichConfirmationDialog.Msg(), "Cover")
+	app.simulateKeyPress(fyne.KeyN)
+	assert.True(t, app.enrichConfirmationDialog.Hidden)
+	assert.Equal(t, entryBefore, app.getCurrentEntryTypeEntries()[0])
+}
+
+func TestThatWarningIsDisplayedWhenSearchingForMetadataWithoutMetadataURL(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateSearchingForMetadataOfCurrentEntry()
+	assert.False(t, app.msgDialog.Hidden)
+	assert.Equal(t, "WARNING", app.msgDialog.Title())
+	assert.Nil(t, app.metadataCandidatesMenu)
+}
+
+func TestThatWindowTitleIndicatesUnsavedChangesUntilTheyAreSaved(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	assert.Equal(t, "wirwl", app.mainWindow.Title())
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Equal(t, "wirwl (modified)", app.mainWindow.Title())
+	app.simulateSavingChanges()
+	assert.Equal(t, "wirwl", app.mainWindow.Title())
+}
+
+func TestThatWindowClosesWithoutAskingWhenThereAreNoUnsavedChanges(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateClosingWindow()
+	assert.True(t, app.unsavedChangesDialog.Hidden)
+	assert.NotContains(t, app.fyneApp.Driver().AllWindows(), app.mainWindow)
+}
+
+func TestThatUnsavedChangesCanBeSavedWhenClosingWindow(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateClosingWindow()
+	assert.True(t, app.unsavedChangesDialog.Visible())
+	assert.Equal(t, "There are unsaved changes. Do you want to save them before closing? (y)es, (n)o or (c)ancel?", app.unsavedChangesDialog.Msg())
+	app.simulateKeyPress(fyne.KeyY)
+	assert.NotContains(t, app.fyneApp.Driver().AllWindows(), app.mainWindow)
+	assert.False(t, app.entriesContainer.HasUnsavedChanges())
+	entries, err := data.NewBoltProvider(testDbCopyPath).LoadEntries()
+	assert.Nil(t, err)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, 2, entries[comicsType][0].ElementsCompleted)
+}
+
+func TestThatUnsavedChangesCanBeDiscardedWhenClosingWindow(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateClosingWindow()
+	app.simulateKeyPress(fyne.KeyN)
+	assert.NotContains(t, app.fyneApp.Driver().AllWindows(), app.mainWindow)
+	entries, err := data.NewBoltProvider(testDbCopyPath).LoadEntries()
+	assert.Nil(t, err)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, 1, entries[comicsType][0].ElementsCompleted)
+}
+
+func TestThatClosingWindowWithUnsavedChangesCanBeCancelled(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateClosingWindow()
+	app.simulateKeyPress(fyne.KeyC)
+	assert.True(t, app.unsavedChangesDialog.Hidden)
+	assert.Contains(t, app.fyneApp.Driver().AllWindows(), app.mainWindow)
+	assert.True(t, app.entriesContainer.HasUnsavedChanges())
+}
+
+func TestThatWindowIsNotClosedWhenSavingUnsavedChangesFails(t *testing.T) {
+	providerFailingOnSave := data.NewAbstractProvider()
+	providerFailingOnSave.SaveEntriesFunc = func(m map[data.EntryType][]data.Entry) error {
+		return errors.New("Testing that saving failed")
+	}
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.prepareConfiguratorForTestingWithExistingData().
+		setDataProvider(providerFailingOnSave).
+		createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.simulateClosingWindow()
+	app.simulateKeyPress(fyne.KeyY)
+	assert.Contains(t, app.fyneApp.Driver().AllWindows(), app.mainWindow)
+	assert.True(t, app.msgDialog.Visible())
+	assert.Equal(t, "ERROR", app.msgDialog.Title())
+	assert.Contains(t, app.msgDialog.Msg(), "Testing that saving failed")
+}
+
+func TestThatChangesAreSavedAutomaticallyWhenAutosaveOnEditIsEnabled(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.AutosaveOnEdit = true
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.False(t, app.entriesContainer.HasUnsavedChanges())
+	assert.Equal(t, "wirwl", app.mainWindow.Title())
+	entries, err := data.NewBoltProvider(testDbCopyPath).LoadEntries()
+	assert.Nil(t, err)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, 2, entries[comicsType][0].ElementsCompleted)
+}
+
+func TestThatChangesAreNotSavedAutomaticallyByDefault(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	assert.Nil(t, app.stopAutosave)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.True(t, app.entriesContainer.HasUnsavedChanges())
+}
+
+func TestThatPeriodicAutosaveIsStartedAndStoppedWhenItIsEnabled(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.AutosavePeriod = 60
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	//Test application is shut down as soon as it's displayed so autosaving has to be started again
+	app.startAutosaving()
+	assert.NotNil(t, app.stopAutosave)
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.autosave()
+	assert.False(t, app.entriesContainer.HasUnsavedChanges())
+	app.shutdown()
+	assert.Nil(t, app.stopAutosave)
+}
+
+func TestThatChangesAreRecordedInJournalUntilTheyAreSaved(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateKeyPress(fyne.KeyEqual)
+	records, err := data.NewJournal(filepath.Join(testAppDataDirPath, "journal.jsonl")).Records()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(records))
+	app.simulateSavingChanges()
+	records, err = app.journal.Records()
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestThatJournaledChangesCanBeRestoredAfterApplicationHasNotBeenClosedProperly(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	journal := data.NewJournal(configurator.config.JournalFilePath())
+	err := journal.Append(data.JournalRecord{Operation: data.ChangeEntryProgressOperation, TypeName: "comics", EntryId: 0, Amount: 1, Date: time.Now()})
+	assert.Nil(t, err)
+	err = journal.Append(data.JournalRecord{Operation: data.DeleteEntryTypeOperation, TypeName: "music"})
+	assert.Nil(t, err)
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	assert.True(t, app.journalReplayDialog.Visible())
+	assert.Contains(t, app.journalReplayDialog.Msg(), "2 unsaved changes have been found")
+	app.simulateKeyPress(fyne.KeyY)
+	assert.Equal(t, 2, len(app.entriesTypesTabs.Items()))
+	assert.Equal(t, 2, app.getCurrentEntryTypeEntries()[0].ElementsCompleted)
+	assert.True(t, app.entriesContainer.HasUnsavedChanges())
+	assert.Equal(t, "wirwl (modified)", app.mainWindow.Title())
+}
+
+func TestThatJournaledChangesAreDiscardedWhenUserDoesNotWantToRestoreThem(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	journal := data.NewJournal(configurator.config.JournalFilePath())
+	err := journal.Append(data.JournalRecord{Operation: data.DeleteEntryTypeOperation, TypeName: "music"})
+	assert.Nil(t, err)
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyN)
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestThatDiscardingChangesWhenClosingWindowClearsJournal(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateClosingWindow()
+	app.simulateKeyPress(fyne.KeyN)
+	records, err := app.journal.Records()
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestThatDeletedEntryTypeCanBeRestoredWithItsLayoutAndCoversByUndoing(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateImportingCover(createTestImageFile("cover.png"))
+	app.simulateEditionOfColumnsLayout(map[string]string{"Title": "1"})
+	app.simulateDeletionOfCurrentEntryType()
+	assert.Equal(t, 2, len(app.entriesTypesTabs.Items()))
+	app.simulateUndo()
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+	assert.Equal(t, "comics", app.getCurrentTabText())
+	assert.Equal(t, data.GetExampleComicEntries()[1], app.getCurrentEntryTypeEntries()[1])
+	assert.Equal(t, 1, len(app.getCurrentEntryTypeTable().HeaderColumns()))
+	assert.True(t, app.imageStore.HasImage(app.getCurrentEntryTypeEntries()[0].Cover))
+	assert.Equal(t, "Undone: deleting entry type 'comics'", app.statusLabel.Text)
+	app.simulateRedo()
+	assert.Equal(t, 2, len(app.entriesTypesTabs.Items()))
+	assert.Equal(t, "Redone: deleting entry type 'comics'", app.statusLabel.Text)
+}
+
+func TestThatUndoingRenamingOfEntryTypeMovesItsLayoutAndCoversBack(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateImportingCover(createTestImageFile("cover.png"))
+	app.simulateEditionOfColumnsLayout(map[string]string{"Title": "1"})
+	app.simulateEditionOfCurrentEntryTypeTo("2")
+	app.simulateUndo()
+	assert.Equal(t, "comics", app.getCurrentTabText())
+	assert.True(t, app.imageStore.HasImage(app.getCurrentEntryTypeEntries()[0].Cover))
+	assert.Equal(t, []ColumnSettings{{"Title", 0}}, app.config.ColumnsLayoutFor("comics"))
+	assert.Equal(t, 1, len(app.getCurrentEntryTypeTable().HeaderColumns()))
+	app.simulateRedo()
+	assert.Equal(t, "2comics", app.getCurrentTabText())
+	assert.True(t, app.imageStore.HasImage(app.getCurrentEntryTypeEntries()[0].Cover))
+	assert.Equal(t, []ColumnSettings{{"Title", 0}}, app.config.ColumnsLayoutFor("2comics"))
+}
+
+func TestThatChangeOfProgressCanBeUndoneAndRedoneInEntriesTable(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Equal(t, 2, app.getCurrentEntryTypeEntries()[0].ElementsCompleted)
+	app.simulateUndo()
+	assert.Equal(t, 1, app.getCurrentEntryTypeEntries()[0].ElementsCompleted)
+	assert.Equal(t, "Undone: changing progress of entry 'some comic1'", app.statusLabel.Text)
+	assert.True(t, app.isCurrentEntriesViewFocused())
+	app.simulateRedo()
+	assert.Equal(t, 2, app.getCurrentEntryTypeEntries()[0].ElementsCompleted)
+	assert.Equal(t, "Redone: changing progress of entry 'some comic1'", app.statusLabel.Text)
+}
+
+func TestThatStatusBarSaysWhenThereIsNothingToUndoOrRedo(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateUndo()
+	assert.Equal(t, "There is nothing to undo", app.statusLabel.Text)
+	app.simulateRedo()
+	assert.Equal(t, "There is nothing to redo", app.statusLabel.Text)
+}
+
+func TestThatOnlyChangesWithinUndoDepthFromConfigCanBeUndone(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.UndoDepth = 1
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateKeyPress(fyne.KeyMinus)
+	app.simulateUndo()
+	app.simulateUndo()
+	assert.Equal(t, 2, app.getCurrentEntryTypeEntries()[0].ElementsCompleted)
+	assert.Equal(t, "There is nothing to undo", app.statusLabel.Text)
+}
+
+func TestThatChangingEntryRecreatesOnlyViewsOfItsEntryType(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	tabs := app.entriesTypesTabs
+	comicsTable := app.getCurrentEntryTypeTable()
+	musicType, err := app.entriesContainer.EntryTypeWithName("music")
+	assert.Nil(t, err)
+	musicTable := app.entriesTables[musicType]
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Same(t, tabs, app.entriesTypesTabs)
+	assert.NotSame(t, comicsTable, app.getCurrentEntryTypeTable())
+	assert.Same(t, musicTable, app.entriesTables[musicType])
+	assert.Equal(t, 1, app.getCurrentEntryTypeTable().CurrentRowNum())
+	assert.True(t, app.isCurrentEntriesViewFocused())
+}
+
+func TestThatRenamedEntryTypeStaysSelectedWithItsDisplayMode(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateSwitchingToNextEntryType()
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	app.simulateEditionOfCurrentEntryTypeTo("z")
+	assert.Equal(t, "zmusic", app.getCurrentTabText())
+	assert.Equal(t, 2, app.entriesTypesTabs.CurrentTabIndex())
+	assert.True(t, app.isInCoverDisplayMode(app.getCurrentEntryType()))
+	_, oldTypeStillInCoverMode := app.typesInCoverDisplayMode["music"]
+	assert.False(t, oldTypeStillInCoverMode)
+}
+
+func TestThatAddingAndDeletingEntryTypesKeepsSelectedTab(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateSwitchingToNextEntryType()
+	app.simulateAddingNewEntryTypeWithName("books")
+	assert.Equal(t, "music", app.getCurrentTabText())
+	assert.Equal(t, 4, len(app.entriesTypesTabs.Items()))
+	assert.Equal(t, "books", app.entriesTypesTabs.Items()[0].Text)
+	assert.Nil(t, app.entriesContainer.DeleteEntryType("books"))
+	assert.Equal(t, "music", app.getCurrentTabText())
+	musicType := app.getCurrentEntryType()
+	app.simulateDeletionOfCurrentEntryType()
+	assert.Equal(t, "videos", app.getCurrentTabText())
+	_, tableExists := app.entriesTables[musicType]
+	assert.False(t, tableExists)
+}
+
+func TestThatDetailsOfEntryIncludeItsConsumptionHistory(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateDisplayingDetailsOfCurrentEntry()
+	assert.True(t, app.entryDetailsDialog.Visible())
+	assert.Equal(t, "some comic1", app.entryDetailsDialog.Title())
+	assert.Contains(t, app.entryDetailsDialog.Texts(), "Nothing has been recorded yet")
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateDisplayingDetailsOfCurrentEntry()
+	texts := app.entryDetailsDialog.Texts()
+	assert.Contains(t, texts, "Progress changed from '1' to '2'")
+	assert.Contains(t, texts, "Status changed from 'In progress' to 'Completed'")
+	status, _ := app.entryDetailsDialog.Value("Status")
+	assert.Equal(t, "Completed", status)
+}
+
+func TestThatCompletedEntryCanBeRewatched(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateStartingRewatchOfCurrentEntry()
+	assert.True(t, app.msgDialog.Visible())
+	assert.Equal(t, "Cannot start rewatching entry 'some comic1' as it has not been completed", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateStartingRewatchOfCurrentEntry()
+	entry := app.getCurrentEntryTypeEntries()[0]
+	assert.True(t, entry.IsBeingRewatched())
+	assert.Equal(t, 0, entry.ElementsCompleted)
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.False(t, app.getCurrentEntryTypeEntries()[0].IsBeingRewatched())
+	app.simulateDisplayingDetailsOfCurrentEntry()
+	assert.Equal(t, "Rewatch finished", app.entryDetailsDialog.Texts()[len(entriesTableTextColumns())*2+3])
+}
+
+func entriesTableTextColumns() []entriesTableColumn {
+	columns := []entriesTableColumn{}
+	for _, column := range entriesTableColumns {
+		if column.columnType == widget.TextColumn {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func TestThatConsumptionHistoryPersistsAfterReopeningTheApplication(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateSavingChanges()
+	app, cleanup = configurator.getRunningTestApplication()
+	defer cleanup()
+	assert.Equal(t, 2, len(app.getCurrentEntryTypeEntries()[0].ConsumptionHistory))
+}
+
+func TestThatStatsOfAllEntriesAreDisplayed(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateDisplayingStats()
+	assert.True(t, app.statsDialog.Visible())
+	perType, _ := app.statsDialog.Section("Entries per type")
+	assert.Equal(t, []widget.Bar{{Label: "comics", Value: 2}, {Label: "music", Value: 2}, {Label: "videos", Value: 2}}, perType.Bars)
+	summary, _ := app.statsDialog.Section("Summary")
+	assert.Equal(t, widget.Detail{Name: "Completion rate", Value: "0.0%"}, summary.Rows[1])
+	assert.Equal(t, widget.Detail{Name: "Mean score", Value: "4.50"}, summary.Rows[3])
+	tags, _ := app.statsDialog.Section("Most used tags")
+	assert.Equal(t, []widget.Detail{{Name: "some tags", Value: "6"}}, tags.Rows)
+	app.simulateKeyPress(fyne.KeyEscape)
+	assert.False(t, app.statsDialog.Visible())
+	app.simulateKeyPress(fyne.KeyEqual)
+	app.simulateDisplayingStats()
+	summary, _ = app.statsDialog.Section("Summary")
+	assert.Equal(t, widget.Detail{Name: "Completion rate", Value: "16.7%"}, summary.Rows[1])
+	perMonth, _ := app.statsDialog.Section("Elements consumed per month")
+	assert.Equal(t, []widget.Bar{{Label: time.Now().Format(stats.MonthLayout), Value: 1}}, perMonth.Bars)
+}
+
+func listTableTitles(table *widget.Table) []string {
+	titles := []string{}
+	for i := 0; i < table.RowAmount(); i++ {
+		titles = append(titles, table.Cell(i, 3).(*fyneWidget.Label).Text)
+	}
+	return titles
+}
+
+func TestThatEntriesOfManyTypesCanBeAddedToList(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyA)
+	app.simulateKeyPress(fyne.KeyL)
+	assert.Equal(t, "There are no lists to add the entry to!", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateCreatingListWithName("favourites")
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.simulateSwitchingToNextEntryType()
+	app.simulateAddingCurrentEntryToList("favourites")
+	list, err := app.entriesContainer.ListWithName("favourites")
+	assert.Nil(t, err)
+	assert.Equal(t, []data.ListItem{{TypeName: "comics", EntryId: 0}, {TypeName: "music", EntryId: 0}}, list.Items)
+	app.entriesTypesTabs.SelectTabWithName(listTabName("favourites"))
+	assert.Equal(t, []string{"some comic1", "some music1"}, listTableTitles(app.listsTables["favourites"]))
+	assert.Equal(t, "comics", app.listsTables["favourites"].Cell(0, 1).(*fyneWidget.Label).Text)
+}
+
+func TestThatActionsOnEntriesWorkOnEntriesSelectedInList(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingListWithName("favourites")
+	app.simulateSwitchingToNextEntryType()
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.simulateSwitchingToNextEntryType()
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.entriesTypesTabs.SelectTabWithName(listTabName("favourites"))
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Equal(t, 2, app.entriesContainer.EntriesGroupedByType()[app.getCurrentEntryType()][0].ElementsCompleted)
+	assert.Equal(t, "videos", app.getCurrentEntryType().Name)
+	assert.Equal(t, "2", app.listsTables["favourites"].Cell(1, 4).(*fyneWidget.Label).Text)
+	assert.True(t, app.isCurrentEntriesViewFocused())
+	assert.Equal(t, 1, app.listsTables["favourites"].CurrentRowNum())
+	app.simulateKeyPress(fyne.KeySpace)
+	app.simulateTogglingDisplayModeOfCurrentEntryType()
+	assert.Equal(t, "This action can only be made in a tab of an entry type!", app.msgDialog.Msg())
+	assert.False(t, app.isInCoverDisplayMode(app.getCurrentEntryType()))
+}
+
+func TestThatEntriesCanBeReorderedAndRemovedFromList(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingListWithName("favourites")
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeySpace)
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.entriesTypesTabs.SelectTabWithName(listTabName("favourites"))
+	table := app.listsTables["favourites"]
+	assert.Equal(t, []string{"some comic1", "some comic2"}, listTableTitles(table))
+	app.simulateMovingCurrentEntryDownInList()
+	table = app.listsTables["favourites"]
+	assert.Equal(t, []string{"some comic2", "some comic1"}, listTableTitles(table))
+	assert.Equal(t, 1, table.CurrentRowNum())
+	app.simulateMovingCurrentEntryDownInList()
+	assert.Equal(t, []string{"some comic2", "some comic1"}, listTableTitles(app.listsTables["favourites"]))
+	app.simulateRemovingCurrentEntryFromList()
+	assert.Equal(t, []string{"some comic2"}, listTableTitles(app.listsTables["favourites"]))
+	app.simulateSwitchingToNextEntryType()
+	app.simulateUndo()
+	assert.Equal(t, listTabName("favourites"), app.getCurrentTabText())
+	assert.Equal(t, []string{"some comic2", "some comic1"}, listTableTitles(app.listsTables["favourites"]))
+}
+
+func TestThatListCanBeDeletedAndItsEntriesFollowRenamedEntryType(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingListWithName("favourites")
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.simulateEditionOfCurrentEntryTypeTo("z")
+	app.entriesTypesTabs.SelectTabWithName(listTabName("favourites"))
+	assert.Equal(t, "zcomics", app.listsTables["favourites"].Cell(0, 1).(*fyneWidget.Label).Text)
+	app.simulateAttemptAtDeletionOfCurrentEntryType()
+	assert.Equal(t, "This action can only be made in a tab of an entry type!", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyY)
+	assert.Empty(t, app.entriesContainer.Lists())
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+}
+
+func TestThatListsPersistAfterReopeningTheApplication(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingListWithName("favourites")
+	app.simulateAddingCurrentEntryToList("favourites")
+	app.simulateSavingChanges()
+	app, cleanup = configurator.getRunningTestApplication()
+	defer cleanup()
+	app.entriesTypesTabs.SelectTabWithName(listTabName("favourites"))
+	assert.Equal(t, []string{"some comic1"}, listTableTitles(app.listsTables["favourites"]))
+}
+
+func TestThatSmartListDisplaysEntriesMatchingItsFilterAndUpdatesLive(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingSmartList("comics", map[string]string{"Statuses": "In progress", "Title contains": "COMIC"})
+	assert.Equal(t, data.Filter{Statuses: []data.EntryStatus{data.InProgressStatus}, Tags: []string{}, TypeNames: []string{}, TitleContains: "COMIC"},
+		app.config.SmartLists["comics"])
+	assert.Equal(t, []string{"some comic1", "some comic2"}, listTableTitles(app.smartListsTables["comics"]))
+	app.simulateEditionOfCellInCurrentEntryTypeTable(0, 3, "renamed")
+	assert.Equal(t, []string{"some comic2"}, listTableTitles(app.smartListsTables["comics"]))
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.entriesTypesTabs.SelectTabWithName(smartListTabName("comics"))
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyEqual)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, "some comic2", app.entriesContainer.EntriesGroupedByType()[comicsType][1].Title)
+	assert.Equal(t, data.CompletedStatus, app.entriesContainer.EntriesGroupedByType()[comicsType][1].Status)
+	assert.Empty(t, listTableTitles(app.smartListsTables["comics"]))
+	app.simulateUndo()
+	assert.Equal(t, []string{"some comic2"}, listTableTitles(app.smartListsTables["comics"]))
+}
+
+func TestThatInvalidSmartListIsNotCreated(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingSmartList("stale", map[string]string{"Statuses": "Forgotten"})
+	assert.Equal(t, "'Forgotten' is not a valid status of an entry", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateCreatingSmartList("stale", map[string]string{"Not touched for days": "a week"})
+	assert.Equal(t, "Not touched for days has to be a non-negative number", app.msgDialog.Msg())
+	assert.Empty(t, app.config.SmartLists)
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+}
+
+func TestThatSmartListsFromConfigAreDisplayedAndCanBeDeleted(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.SmartLists["good ones"] = data.Filter{MinScore: 5}
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	app.entriesTypesTabs.SelectTabWithName(smartListTabName("good ones"))
+	assert.Equal(t, smartListTabName("good ones"), app.getCurrentTabText())
+	assert.Equal(t, 3, app.smartListsTables["good ones"].RowAmount())
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyY)
+	assert.Empty(t, app.config.SmartLists)
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+}
+
+func acquireTestInstanceLock(owner data.LockOwner) (*data.InstanceLock, func()) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	owner.Address = listener.Addr().String()
+	lock, err := data.AcquireInstanceLock(testAppDataDirPath, owner)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return lock, func() {
+		_ = lock.Release()
+		_ = listener.Close()
+	}
+}
+
+func TestThatApplicationCannotBeStartedWhileCollectionIsUsedByAnotherProcess(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.createTestApplicationThatUsesExistingData()
+	defer cleanupAfterTestRun()
+	_, release := acquireTestInstanceLock(data.LockOwner{Pid: 123, Description: "the serve command", StartTime: time.Date(2020, 5, 1, 10, 30, 0, 0, time.Local)})
+	defer release()
+	err := configurator.app.LoadAndDisplay()
+	assert.EqualError(t, err, "The collection is already used by the serve command running as process 123 since "+
+		"01/05/2020 10:30:00. The collection can be opened read-only with the -r flag.")
+}
+
+func TestThatCollectionOpenedReadOnlyCanBeChangedButNotSaved(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData().
+		setDataProvider(data.NewReadOnlyBoltProvider(testDbCopyPath)).
+		createTestApplication()
+	configurator.app.OpenReadOnly()
+	owner := data.LockOwner{Pid: 123, Description: "the application", StartTime: time.Now()}
+	_, release := acquireTestInstanceLock(owner)
+	defer release()
+	app, cleanup := configurator.getRunningTestApplication()
+	defer cleanup()
+	assert.Equal(t, "wirwl (read-only)", app.mainWindow.Title())
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Equal(t, "wirwl (read-only) (modified)", app.mainWindow.Title())
+	app.simulateSavingChanges()
+	assert.Equal(t, "WARNING", app.msgDialog.Title())
+	assert.Equal(t, readOnlySavingWarning, app.msgDialog.Msg())
+	records, _ := app.journal.Records()
+	assert.Empty(t, records)
+	comicsType, _ := loadTestEntries().EntryTypeWithName("comics")
+	assert.Equal(t, data.GetExampleComicEntries(), loadTestEntries().EntriesGroupedByType()[comicsType])
+	app.simulateClosingWindow()
+	assert.False(t, app.unsavedChangesDialog.Hidden)
+	currentOwner, isOwned, _ := data.InstanceLockOwner(testAppDataDirPath)
+	assert.True(t, isOwned)
+	assert.Equal(t, 123, currentOwner.Pid)
+}
+
+func TestThatCommandsAreForwardedToRunningApplication(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	assert.Nil(t, app.acquireInstanceLock())
+	app.startServingCommands()
+	defer app.releaseInstanceLock()
+	exitCode, output, _ := runTestCommand("add", "-type", "comics", "-title", "new comic")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	assert.Equal(t, "2\n", output)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, "new comic", app.entriesContainer.EntriesGroupedByType()[comicsType][2].Title)
+	assert.Equal(t, "wirwl (modified)", app.mainWindow.Title())
+	assert.Equal(t, 2, len(loadTestEntries().EntriesGroupedByType()[comicsType]))
+	exitCode, _, errorOutput := runTestCommand("serve", "-address", "localhost:0")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Contains(t, errorOutput, "Error: The collection is already used by the application running as process")
+}
+
+func (app *App) simulateSwitchingTheme() {
+	app.simulateKeyPress(fyne.KeyV)
+	app.simulateKeyPress(fyne.KeyT)
+}
+
+func TestThatThemesAreSwitchedBetweenAndSavedInConfig(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	assert.Equal(t, theme.LightTheme().BackgroundColor(), app.fyneApp.Settings().Theme().BackgroundColor())
+	app.simulateSwitchingTheme()
+	assert.Equal(t, "dark", app.config.Theme)
+	assert.Equal(t, theme.DarkTheme().BackgroundColor(), app.fyneApp.Settings().Theme().BackgroundColor())
+	app.simulateSwitchingTheme()
+	assert.Equal(t, "light", app.config.Theme, "Custom theme should be skipped as it's not defined")
+	err := ioutil.WriteFile(app.config.CustomThemeFilePath(), []byte("Base = \"dark\"\n[Colors]\nBackground = \"#102030\"\n"), 0600)
+	if err != nil {
+		log.Fatal(err)
+	}
+	app.simulateSwitchingTheme()
+	app.simulateSwitchingTheme()
+	assert.Equal(t, "custom", app.config.Theme)
+	assert.Equal(t, color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}, app.fyneApp.Settings().Theme().BackgroundColor())
+	app.shutdown()
+	loadedConfig := NewConfig(testConfigDirPath)
+	assert.Nil(t, loadedConfig.load())
+	assert.Equal(t, "custom", loadedConfig.Theme)
+}
+
+func TestThatLightThemeIsUsedWhenThemeFromConfigCannotBeApplied(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	configurator.config.Theme = "custom"
+	app, cleanup := configurator.createTestApplication().getRunningTestApplication()
+	defer cleanup()
+	assert.Contains(t, app.loadingErrors[themeLoadError], "Theme 'custom' could not be applied so the light theme is used instead")
+	assert.True(t, app.msgDialog.Visible())
+	assert.Equal(t, theme.LightTheme().BackgroundColor(), app.fyneApp.Settings().Theme().BackgroundColor())
+}
+
+func TestThatRelatedEntriesAreDisplayedInDetailsAndCanBeGoneTo(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateAddingRelationToCurrentEntry(data.AdaptationRelation, "Some video2")
+	assert.False(t, app.msgDialog.Visible())
+	assert.Equal(t, []data.Relation{{Kind: data.AdaptationRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "videos", RelatedEntryId: 1}},
+		app.entriesContainer.RelationsOf("comics", 0))
+	app.simulateDisplayingDetailsOfCurrentEntry()
+	adaptation, _ := app.entryDetailsDialog.Value("adaptation")
+	assert.Equal(t, "some video2 (videos)", adaptation)
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateGoingToFirstRelatedEntry()
+	assert.Equal(t, "videos", app.getCurrentEntryType().Name)
+	assert.Equal(t, 1, app.getCurrentEntryTypeTable().CurrentRowNum())
+	app.simulateDisplayingDetailsOfCurrentEntry()
+	source, _ := app.entryDetailsDialog.Value("source")
+	assert.Equal(t, "some comic1 (comics)", source)
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateRemovingFirstRelationOfCurrentEntry()
+	assert.Empty(t, app.entriesContainer.RelationsOf("videos", 1))
+	app.simulateDisplayingDetailsOfCurrentEntry()
+	assert.Contains(t, app.entryDetailsDialog.Texts(), "There are no related entries")
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateUndo()
+	assert.Equal(t, 1, len(app.entriesContainer.RelationsOf("videos", 1)))
+}
+
+func TestThatRelationToEntryThatIsNotClearlyGivenIsNotAdded(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateAddingRelationToCurrentEntry(data.SequelRelation, "some comic3")
+	assert.Equal(t, "There is no entry with title 'some comic3'", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	assert.True(t, app.addRelationDialog.Visible())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateAddingRelationToCurrentEntry(data.SequelRelation, "some comic1")
+	assert.Equal(t, "Entry 'some comic1' cannot be related to itself", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateSwitchingToNextEntryType()
+	app.simulateEditionOfCellInCurrentEntryTypeTable(0, 3, "some comic2")
+	app.simulateKeyPress(fyne.KeySpace)
+	app.simulateSwitchingToPreviousEntryType()
+	app.simulateAddingRelationToCurrentEntry(data.SequelRelation, "some comic2")
+	assert.Equal(t, "There are many entries with title 'some comic2', choose one by its entry type, e.g. 'some comic2 (comics)'", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateAddingRelationToCurrentEntry(data.SequelRelation, "some comic2 (music)")
+	assert.Equal(t, []data.Relation{{Kind: data.SequelRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 0}},
+		app.entriesContainer.RelationsOf("comics", 0))
+}
+
+func TestThatTextInParenthesesThatIsNotEntryTypeIsPartOfRelatedEntryTitle(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateSwitchingToNextEntryType()
+	app.simulateEditionOfCellInCurrentEntryTypeTable(0, 3, "Solaris (1972)")
+	app.simulateKeyPress(fyne.KeySpace)
+	app.simulateSwitchingToPreviousEntryType()
+	app.simulateAddingRelationToCurrentEntry(data.AdaptationRelation, "solaris (1972)")
+	assert.False(t, app.msgDialog.Visible())
+	assert.Equal(t, []data.Relation{{Kind: data.AdaptationRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 0}},
+		app.entriesContainer.RelationsOf("comics", 0))
+}
+
+func TestThatSmartListCanHaveEntriesRelatedToOtherEntries(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingSmartList("adaptations", map[string]string{"Related as": "adaptation", "Statuses": "In progress"})
+	assert.Equal(t, []data.RelationKind{data.AdaptationRelation}, app.config.SmartLists["adaptations"].RelatedAs)
+	assert.Empty(t, listTableTitles(app.smartListsTables["adaptations"]))
+	app.entriesTypesTabs.SelectTabWithName("comics")
+	app.simulateAddingRelationToCurrentEntry(data.AdaptationRelation, "some video2")
+	assert.Equal(t, []string{"some video2"}, listTableTitles(app.smartListsTables["adaptations"]))
+	app.simulateCreatingSmartList("remakes", map[string]string{"Related as": "remake"})
+	assert.Equal(t, "'remake' is not a valid kind of relation", app.msgDialog.Msg())
+}
+
+func seriesTableColumnValues(table *widget.Table, columnNum int) []string {
+	values := []string{}
+	for i := 0; i < table.RowAmount(); i++ {
+		values = append(values, table.Cell(i, columnNum).(*fyneWidget.Label).Text)
+	}
+	return values
+}
+
+func TestThatEntriesOfManyTypesCanBeGroupedIntoSeriesWithAggregateProgress(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+	app.simulateKeyPress(fyne.KeyA)
+	app.simulateKeyPress(fyne.KeyF)
+	assert.Equal(t, "There are no series to add the entry to!", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateCreatingSeriesWithName("franchise")
+	assert.Equal(t, 4, len(app.entriesTypesTabs.Items()))
+	assert.Equal(t, "comics", app.getCurrentTabText())
+	app.simulateAddingCurrentEntryToSeries("franchise")
+	app.simulateSwitchingToNextEntryType()
+	app.simulateAddingCurrentEntryToSeries("franchise")
+	app.entriesTypesTabs.SelectTabWithName(seriesTabName)
+	assert.Equal(t, []string{"[+] franchise"}, seriesTableColumnValues(app.seriesTable, 0))
+	assert.Equal(t, []string{"In progress"}, seriesTableColumnValues(app.seriesTable, 2))
+	assert.Equal(t, []string{"0/2"}, seriesTableColumnValues(app.seriesTable, 5))
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyI)
+	assert.Equal(t, []string{"[-] franchise", "", ""}, seriesTableColumnValues(app.seriesTable, 0))
+	assert.Equal(t, []string{"", "some comic1", "some music1"}, seriesTableColumnValues(app.seriesTable, 3))
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyEqual)
+	assert.Equal(t, "music", app.getCurrentEntryType().Name)
+	entry, _ := app.currentEntry()
+	assert.Equal(t, "some music1", entry.Title)
+	assert.Equal(t, "2", app.seriesTable.Cell(2, 4).(*fyneWidget.Label).Text)
+	assert.Equal(t, 2, app.seriesTable.CurrentRowNum())
+	assert.True(t, app.isCurrentEntriesViewFocused())
+	app.simulateKeyPress(fyne.KeyI)
+	assert.Equal(t, "music", app.getCurrentTabText())
+}
+
+func TestThatMembersCanBeRemovedFromSeriesAndSeriesCanBeDeleted(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateCreatingSeriesWithName("franchise")
+	app.simulateCreatingSeriesWithName("other")
+	app.simulateAddingCurrentEntryToSeries("franchise")
+	app.simulateAddingCurrentEntryToSeries("other")
+	assert.Equal(t, "Entry 'some comic1' is already a member of series 'franchise'", app.msgDialog.Msg())
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateRemovingCurrentEntryFromSeries()
+	series, _ := app.entriesContainer.SeriesWithName("franchise")
+	assert.Empty(t, series.Members)
+	app.simulateUndo()
+	assert.Equal(t, seriesTabName, app.getCurrentTabText())
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyF)
+	app.simulateKeyPress(fyne.KeyY)
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyF)
+	app.simulateKeyPress(fyne.KeyY)
+	assert.Empty(t, app.entriesContainer.AllSeries())
+	assert.Equal(t, 3, len(app.entriesTypesTabs.Items()))
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyF)
+	assert.Equal(t, "Select a series in the tab of series to delete it!", app.msgDialog.Msg())
+}
+
+func TestThatDuplicateEntriesCanBeComparedAndMerged(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyF)
+	app.simulateKeyPress(fyne.KeyD)
+	app.duplicatesMenu.SelectChoiceWithText("some comic2 (comics) and some video2 (videos): same link")
+	assert.Equal(t, 3, app.duplicatesMenu.CurrentChoiceNum())
+	app.simulateKeyPress(fyne.KeyReturn)
+	assert.False(t, app.duplicatesComparisonDialog.Hidden)
+	assert.Equal(t, []string{"", "some comic2 (comics)", "some video2 (videos)", "Type", "comics", "videos"},
+		app.duplicatesComparisonDialog.Texts()[:6])
+	assert.Equal(t, []string{"Type", "Title"}, app.duplicatesComparisonDialog.Differences())
+	app.simulateKeyPress(fyne.KeyEscape)
+	assert.True(t, app.duplicatesComparisonDialog.Hidden)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyReturn)
+	videosType, _ := app.entriesContainer.EntryTypeWithName("videos")
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, []string{"some video1", "some video2"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[videosType]))
+	assert.Equal(t, []string{"some comic1"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[comicsType]))
+	assert.Equal(t, 1, app.entriesTables[comicsType].RowAmount())
+	app.simulateUndo()
+	assert.Equal(t, "Undone: merging entry 'some comic2' into entry 'some video2'", app.statusLabel.Text)
+	assert.Equal(t, []string{"some comic1", "some comic2"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[comicsType]))
+}
+
+func TestThatBothDuplicatesAreKeptWhenMergingIsCancelled(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyF)
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyReturn)
+	app.simulateKeyPress(fyne.KeyEscape)
+	app.simulateKeyPress(fyne.KeyEscape)
+	assert.Equal(t, 6, len(app.entriesContainer.DuplicateCandidates()))
+	assert.False(t, app.entriesContainer.HasUnsavedChanges())
+}
+
+func TestThatMarkedEntriesCanBeMovedToOtherEntryType(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyM)
+	app.simulateKeyPress(fyne.KeyM)
+	table := app.getCurrentEntryTypeTable()
+	assert.True(t, table.Cell(0, 0).(*fyneWidget.Label).TextStyle.Bold)
+	assert.False(t, table.Cell(1, 0).(*fyneWidget.Label).TextStyle.Bold)
+	app.simulateKeyPress(fyne.KeyJ)
+	app.simulateKeyPress(fyne.KeyM)
+	app.simulateKeyPress(fyne.KeyM)
+	app.simulateKeyPress(fyne.KeyM)
+	app.simulateKeyPress(fyne.KeyT)
+	assert.False(t, app.entryTypesMenu.Hidden)
+	app.entryTypesMenu.SelectChoiceWithText("videos")
+	app.simulateKeyPress(fyne.KeyReturn)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	videosType, _ := app.entriesContainer.EntryTypeWithName("videos")
+	assert.Empty(t, app.entriesContainer.EntriesGroupedByType()[comicsType])
+	assert.Equal(t, []string{"some video1", "some video2", "some comic1", "some comic2"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[videosType]))
+	assert.Equal(t, 4, app.entriesTables[videosType].RowAmount())
+	assert.Empty(t, app.markedEntries["comics"])
+	app.simulateUndo()
+	assert.Equal(t, "Undone: moving 2 entries to entry type 'videos'", app.statusLabel.Text)
+	assert.Equal(t, "comics", app.getCurrentTabText())
+	assert.Equal(t, []string{"some comic1", "some comic2"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[comicsType]))
+	assert.False(t, app.entriesTables[comicsType].Cell(0, 0).(*fyneWidget.Label).TextStyle.Bold)
+}
+
+func TestThatCurrentEntryIsCopiedToOtherEntryTypeWhenNoEntriesAreMarked(t *testing.T) {
+	configurator := NewTestAppConfigurator()
+	app, cleanup := configurator.createTestApplicationThatUsesExistingData().getRunningTestApplication()
+	defer cleanup()
+	app.simulateKeyPress(fyne.KeyC)
+	app.simulateKeyPress(fyne.KeyT)
+	assert.Equal(t, 0, app.entryTypesMenu.CurrentChoiceNum())
+	app.simulateKeyPress(fyne.KeyReturn)
+	comicsType, _ := app.entriesContainer.EntryTypeWithName("comics")
+	musicType, _ := app.entriesContainer.EntryTypeWithName("music")
+	assert.Equal(t, []string{"some comic1", "some comic2"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[comicsType]))
+	assert.Equal(t, []string{"some music1", "some music2", "some comic1"}, entriesTitles(app.entriesContainer.EntriesGroupedByType()[musicType]))
+}
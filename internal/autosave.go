@@ -0,0 +1,111 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"strconv"
+	"time"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Unsaved changes can be saved automatically, periodically and/or as soon as they are made, depending on the config.
+Independently of that, every change is recorded in a journal until it gets saved so if the application is not closed
+properly, e.g. because it crashed, the changes recorded in the journal can be replayed when it starts again.
+*/
+
+func (app *App) autosave() {
+	if !app.entriesContainer.HasUnsavedChanges() {
+		return
+	}
+	err := app.entriesContainer.SaveData()
+	if err != nil {
+		log.Error(errors.Wrap(err, "Failed to save changes automatically"))
+	}
+	app.updateWindowTitle()
+}
+
+func (app *App) autosaveAfterChange() {
+	if app.config.AutosaveOnEdit && !app.readOnly {
+		app.autosave()
+	}
+}
+
+func (app *App) startAutosaving() {
+	if app.config.AutosavePeriod <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(app.config.AutosavePeriod) * time.Second)
+	stop := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				app.entriesMutex.Lock()
+				app.autosave()
+				app.entriesMutex.Unlock()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	app.stopAutosave = func() {
+		ticker.Stop()
+		close(stop)
+	}
+}
+
+func (app *App) stopAutosaving() {
+	if app.stopAutosave != nil {
+		app.stopAutosave()
+		app.stopAutosave = nil
+	}
+}
+
+func (app *App) createJournalReplayDialog() {
+	app.journalReplayDialog = app.newConfirmationDialog()
+	app.journalReplayDialog.OnConfirm = app.replayJournal
+	app.journalReplayDialog.OnCancel = app.clearJournal
+}
+
+//Journal has records only if the application has not been closed properly since they were made
+func (app *App) offerReplayingJournal() {
+	if _, entriesLoadingErrorExists := app.loadingErrors[entriesLoadError]; entriesLoadingErrorExists {
+		return
+	}
+	records, err := app.journal.Records()
+	if err != nil {
+		log.Error(errors.Wrap(err, "Failed to check whether there are changes that can be restored"))
+		return
+	}
+	if len(records) == 0 {
+		return
+	}
+	display := func() {
+		app.journalReplayDialog.Display("The application has not been closed properly and " + strconv.Itoa(len(records)) +
+			" unsaved changes have been found. Do you want to restore them?")
+	}
+	if app.msgDialog.Visible() {
+		app.msgDialog.SetOneTimeOnHideCallback(display)
+	} else {
+		display()
+	}
+}
+
+func (app *App) replayJournal() {
+	_, err := app.entriesContainer.ReplayJournal()
+	if err != nil {
+		log.Error(err)
+		//The confirmation dialog hides after its callback so the error can only be displayed once that happens
+		app.journalReplayDialog.SetOneTimeOnHideCallback(func() {
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		})
+	}
+}
+
+func (app *App) clearJournal() {
+	err := app.journal.Clear()
+	if err != nil {
+		log.Error(err)
+	}
+}
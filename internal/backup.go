@@ -0,0 +1,51 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"time"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Backups are created in a separate directory for every backup inside application's data directory and they contain
+the database file, as it was last saved, along with all of the cover images.
+*/
+
+const backupDirNameLayout = "2006-01-02_15-04-05"
+
+func (app *App) tryCreatingBackup() {
+	backupDirPath, err := app.createBackup(time.Now())
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	} else {
+		app.msgDialog.Display(widget.SuccessPopUp, "Backup created in "+backupDirPath+".")
+	}
+}
+
+func (app *App) createBackup(creationTime time.Time) (string, error) {
+	backupsDirPath := filepath.Join(app.config.AppDataDirPath, backupsDirName)
+	backupDirPath := filepath.Join(backupsDirPath, creationTime.Format(backupDirNameLayout))
+	for _, dirPath := range []string{backupsDirPath, backupDirPath} {
+		err := data.CreateDirIfNotExist(dirPath)
+		if err != nil {
+			return "", errors.Wrap(err, "Failed to create a directory for the backup")
+		}
+	}
+	//Nothing has been saved yet if there is no database file
+	if _, err := os.Stat(app.config.DbFilePath()); err == nil {
+		err = data.CopyFile(app.config.DbFilePath(), filepath.Join(backupDirPath, dbFileName))
+		if err != nil {
+			return "", errors.Wrap(err, "Failed to copy the database into the backup")
+		}
+	}
+	err := app.imageStore.CopyTo(filepath.Join(backupDirPath, imagesDirName))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to copy cover images into the backup")
+	}
+	return backupDirPath, nil
+}
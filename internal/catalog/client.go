@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Catalogs are external services, e.g. databases of movies or books, that are searched for works by their titles to find
+covers or metadata of entries. A catalog is searched by sending a GET request to a URL created from a template with
+placeholders, e.g. 'https://example.com/search?q={title}', and it responds with JSON whose format depends on what is
+searched for. Responses and files downloaded from catalogs are limited in size, as catalogs are chosen by users and can
+send anything.
+*/
+type Client struct {
+	httpClient *http.Client
+}
+
+const TitlePlaceholder = "{title}"
+
+//Files are downloaded in chunks of this size, so the progress can be reported after each of them
+const DownloadChunkSize = 32 * 1024
+
+func NewClient() *Client {
+	return &Client{httpClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+//Values are escaped so they can be safely used in a URL, placeholders are e.g. TitlePlaceholder
+func SearchURL(urlTemplate string, valuesOfPlaceholders map[string]string) string {
+	searchURL := urlTemplate
+	for placeholder, value := range valuesOfPlaceholders {
+		searchURL = strings.ReplaceAll(searchURL, placeholder, url.QueryEscape(value))
+	}
+	return searchURL
+}
+
+//Relative URLs found in a response are resolved against the URL of the request, empty ones are left empty
+func ResolveURLs(searchURL string, references ...*string) {
+	baseURL, err := url.Parse(searchURL)
+	if err != nil {
+		return
+	}
+	for _, reference := range references {
+		if *reference == "" {
+			continue
+		}
+		referenceURL, err := url.Parse(*reference)
+		if err == nil {
+			*reference = baseURL.ResolveReference(referenceURL).String()
+		}
+	}
+}
+
+//Responses bigger than the maximum size are rejected, content describes what the response is expected to be,
+//e.g. "a cover", for the error. Progress function, if it's given, is called every time a chunk gets downloaded.
+//Total amount of bytes is -1 if it's unknown.
+func (client *Client) Get(url string, maxSize int64, content string, onProgress func(downloadedBytes int64, totalBytes int64)) ([]byte, error) {
+	response, err := client.httpClient.Get(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to connect to "+url)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New("Request to " + url + " failed with status " + strconv.Itoa(response.StatusCode))
+	}
+	tooBigError := errors.New("Response from " + url + " is too big to be " + content)
+	if response.ContentLength > maxSize {
+		return nil, tooBigError
+	}
+	if onProgress == nil {
+		onProgress = func(int64, int64) {}
+	}
+	buffer := bytes.Buffer{}
+	for {
+		readBytes, err := io.CopyN(&buffer, response.Body, DownloadChunkSize)
+		if readBytes > 0 {
+			onProgress(int64(buffer.Len()), response.ContentLength)
+		}
+		if int64(buffer.Len()) > maxSize {
+			return nil, tooBigError
+		}
+		if err == io.EOF {
+			return buffer.Bytes(), nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred when reading response from "+url)
+		}
+	}
+}
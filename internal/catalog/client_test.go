@@ -0,0 +1,36 @@
+package catalog
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestThatPlaceholdersAreReplacedWithEscapedValues(t *testing.T) {
+	searchURL := SearchURL("http://example.com/search?q={title}&title={title}", map[string]string{TitlePlaceholder: "A & B"})
+	assert.Equal(t, "http://example.com/search?q=A+%26+B&title=A+%26+B", searchURL)
+}
+
+func TestThatRelativeURLsAreResolvedAndEmptyOnesAreLeftEmpty(t *testing.T) {
+	relative, absolute, empty := "/images/a.png", "http://other.com/b.png", ""
+	ResolveURLs("http://example.com/search?q=a", &relative, &absolute, &empty)
+	assert.Equal(t, "http://example.com/images/a.png", relative)
+	assert.Equal(t, "http://other.com/b.png", absolute)
+	assert.Equal(t, "", empty)
+}
+
+func TestThatResponsesBiggerThanMaximumSizeAreRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Transfer-Encoding", "chunked")
+		_, _ = writer.Write([]byte(strings.Repeat("a", 2*DownloadChunkSize)))
+	}))
+	defer server.Close()
+	client := NewClient()
+	_, err := client.Get(server.URL, DownloadChunkSize, "a cover", nil)
+	assert.EqualError(t, err, "Response from "+server.URL+" is too big to be a cover")
+	response, err := client.Get(server.URL, 2*DownloadChunkSize, "a cover", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*DownloadChunkSize, len(response))
+}
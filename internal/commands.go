@@ -0,0 +1,648 @@
+package wirwl
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"wirwl/internal/api"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+)
+
+/*
+Commands give access to the collection without the GUI, so it can be scripted, e.g. from shell aliases or cron jobs.
+Every command loads the entries the same way the application does, makes its change and saves the entries right away.
+Commands can't change entries when there are changes of a previous session in the journal, as these would be lost.
+When the collection is used by another process, e.g. the running application, commands are forwarded to that process,
+which runs them and leaves their changes to be saved along with its own changes.
+*/
+
+//Exit codes of commands
+const (
+	CommandSucceeded = 0
+	CommandFailed    = 1
+	//Command doesn't exist or its arguments are wrong
+	CommandMisused = 2
+)
+
+const (
+	tableOutputFormat = "table"
+	jsonOutputFormat  = "json"
+)
+
+//Orders of listed entries, which are by default sorted by names of their entry types
+const (
+	typeOrder  = "type"
+	scoreOrder = "score"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(environment commandEnvironment, args []string) error
+	//Whether the command can be run by another process using the collection instead
+	forwardable bool
+}
+
+//Everything a command needs to run
+type commandEnvironment struct {
+	config           Config
+	entriesContainer *data.EntriesContainer
+	output           io.Writer
+	errorOutput      io.Writer
+	saveChanges      func() error
+	//Relative paths in arguments are relative to this directory, unless it's empty
+	workingDirPath string
+}
+
+//Usage errors are reported by flag sets on their own, so they only need to be distinguished from other errors
+type commandUsageError struct {
+	error
+}
+
+var commands []command
+
+//Commands are listed in init, as the serve command runs the commands that are forwarded to it
+func init() {
+	commands = []command{
+		{"types", "Lists entry types with amounts of their entries", runTypesCommand, true},
+		{"list", "Lists entries, optionally only the ones matching a filter", runListCommand, true},
+		{"add", "Adds an entry to an entry type and prints its id", runAddCommand, true},
+		{"set-progress", "Sets the amount of completed elements of an entry", runSetProgressCommand, true},
+		{"export", "Exports all entry types, entries and lists as JSON", runExportCommand, true},
+		{"import", "Imports entry types, entries and lists exported with the export command", runImportCommand, true},
+		{"serve", "Serves a REST API giving access to entry types and entries until stopped", runServeCommand, false},
+	}
+}
+
+func commandWithName(name string) (command, bool) {
+	for _, command := range commands {
+		if command.name == name {
+			return command, true
+		}
+	}
+	return command{}, false
+}
+
+//Runs the command named by the first argument with the rest of arguments and returns its exit code
+func RunCommand(configurator AppConfigurator, args []string, output io.Writer, errorOutput io.Writer) int {
+	command, exists := commandNamedIn(args, errorOutput)
+	if !exists {
+		return CommandMisused
+	}
+	config, err := loadCommandConfig(configurator)
+	if err != nil {
+		return exitCodeOf(err, errorOutput)
+	}
+	if command.forwardable {
+		owner, isOwned, err := data.InstanceLockOwner(config.AppDataDirPath)
+		if err != nil {
+			return exitCodeOf(err, errorOutput)
+		}
+		if isOwned {
+			return forwardCommand(owner, args, output, errorOutput)
+		}
+	}
+	environment, err := prepareCommandEnvironment(configurator, config, output, errorOutput)
+	if err == nil {
+		err = command.run(environment, args[1:])
+	}
+	return exitCodeOf(err, errorOutput)
+}
+
+func commandNamedIn(args []string, errorOutput io.Writer) (command, bool) {
+	if len(args) == 0 {
+		printCommandsUsage(errorOutput)
+		return command{}, false
+	}
+	command, exists := commandWithName(args[0])
+	if !exists {
+		fmt.Fprintln(errorOutput, "Unknown command '"+args[0]+"'")
+		printCommandsUsage(errorOutput)
+	}
+	return command, exists
+}
+
+func exitCodeOf(err error, errorOutput io.Writer) int {
+	if _, isUsageError := err.(commandUsageError); isUsageError {
+		return CommandMisused
+	} else if err != nil {
+		fmt.Fprintln(errorOutput, "Error: "+err.Error())
+		return CommandFailed
+	}
+	return CommandSucceeded
+}
+
+//Changes made directly in the database would be overwritten by the process using the collection when it saves its changes
+func forwardCommand(owner data.LockOwner, args []string, output io.Writer, errorOutput io.Writer) int {
+	if owner.Token == "" {
+		return exitCodeOf(errors.New("The collection is used by "+owner.Description+", which doesn't accept commands "+
+			"as it has no token"), errorOutput)
+	}
+	workingDirPath, err := os.Getwd()
+	if err != nil {
+		return exitCodeOf(errors.Wrap(err, "Failed to get the working directory"), errorOutput)
+	}
+	result, err := api.ForwardCommand(owner.Address, owner.Token, api.CommandRequest{Args: args, WorkingDirPath: workingDirPath})
+	if err != nil {
+		return exitCodeOf(err, errorOutput)
+	}
+	fmt.Fprint(output, result.Output)
+	fmt.Fprint(errorOutput, result.ErrorOutput)
+	return result.ExitCode
+}
+
+//Runs commands forwarded by other processes using the container of the environment
+func (environment commandEnvironment) runForwardedCommand(request api.CommandRequest, output io.Writer, errorOutput io.Writer) int {
+	environment.output, environment.errorOutput, environment.workingDirPath = output, errorOutput, request.WorkingDirPath
+	command, exists := commandNamedIn(request.Args, errorOutput)
+	if !exists {
+		return CommandMisused
+	}
+	if !command.forwardable {
+		return exitCodeOf(errors.New("Command '"+command.name+"' cannot be run while the collection is used by "+
+			"another process"), errorOutput)
+	}
+	return exitCodeOf(command.run(environment, request.Args[1:]), errorOutput)
+}
+
+func printCommandsUsage(errorOutput io.Writer) {
+	fmt.Fprintln(errorOutput, "Available commands:")
+	writer := tabwriter.NewWriter(errorOutput, 0, 4, 2, ' ', 0)
+	for _, command := range commands {
+		fmt.Fprintln(writer, "  "+command.name+"\t"+command.description)
+	}
+	writer.Flush()
+}
+
+//Config that can't be loaded is an error, as unlike the application, commands can't tell that the default one is used instead
+func loadCommandConfig(configurator AppConfigurator) (Config, error) {
+	config, err := configurator.LoadConfig()
+	if err != nil {
+		return config, err
+	}
+	if _, exists := configurator.LoadingErrors()[configLoadError]; exists {
+		return config, errors.New("Failed to load the config file in " + config.ConfigFilePath())
+	}
+	return config, nil
+}
+
+func prepareCommandEnvironment(configurator AppConfigurator, config Config, output io.Writer, errorOutput io.Writer) (commandEnvironment, error) {
+	entriesContainer := data.NewEntriesContainer(configurator.LoadDataProvider(config.DbFilePath()))
+	err := entriesContainer.LoadData()
+	if err != nil {
+		return commandEnvironment{}, errors.Wrap(err, "Failed to load entries")
+	}
+	environment := commandEnvironment{config: config, entriesContainer: entriesContainer, output: output, errorOutput: errorOutput}
+	environment.saveChanges = environment.saveChangesToDb
+	return environment, nil
+}
+
+func newCommandFlagSet(environment commandEnvironment, name string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(environment.errorOutput)
+	return flagSet
+}
+
+func parseCommandFlags(flagSet *flag.FlagSet, args []string) error {
+	err := flagSet.Parse(args)
+	if err != nil {
+		return commandUsageError{err}
+	}
+	if flagSet.NArg() > 0 {
+		fmt.Fprintln(flagSet.Output(), "Unexpected arguments: "+strings.Join(flagSet.Args(), " "))
+		return commandUsageError{errors.New("Unexpected arguments")}
+	}
+	return nil
+}
+
+//Flags with values that are required have to be set
+func requireCommandFlags(flagSet *flag.FlagSet, names ...string) error {
+	setFlags := map[string]bool{}
+	flagSet.Visit(func(setFlag *flag.Flag) { setFlags[setFlag.Name] = true })
+	for _, name := range names {
+		if !setFlags[name] {
+			fmt.Fprintln(flagSet.Output(), "Flag -"+name+" is required")
+			flagSet.Usage()
+			return commandUsageError{errors.New("Flag -" + name + " is required")}
+		}
+	}
+	return nil
+}
+
+func outputFormatFlag(flagSet *flag.FlagSet) *string {
+	return flagSet.String("format", tableOutputFormat, "Format of the output, either '"+tableOutputFormat+"' or '"+jsonOutputFormat+"'")
+}
+
+//Rows of the table are written below the header with columns aligned, values written as JSON are written as they are
+func (environment commandEnvironment) print(format string, value interface{}, header []string, rows [][]string) error {
+	switch format {
+	case jsonOutputFormat:
+		encoder := json.NewEncoder(environment.output)
+		encoder.SetIndent("", "  ")
+		return errors.Wrap(encoder.Encode(value), "Failed to write the output")
+	case tableOutputFormat:
+		writer := tabwriter.NewWriter(environment.output, 0, 4, 2, ' ', 0)
+		for _, row := range append([][]string{header}, rows...) {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return errors.Wrap(writer.Flush(), "Failed to write the output")
+	}
+	return errors.New("Unknown output format '" + format + "'")
+}
+
+//Changes of a previous session which are in the journal would be replayed over the changes made by a command
+func (environment commandEnvironment) saveChangesToDb() error {
+	records, err := data.NewJournal(environment.config.JournalFilePath()).Records()
+	if err != nil {
+		return err
+	}
+	if len(records) > 0 {
+		return errors.New("Changes have not been saved as there are unsaved changes of a previous session. " +
+			"Open the application to restore or discard them first.")
+	}
+	return errors.Wrap(environment.entriesContainer.SaveData(), "Failed to save changes")
+}
+
+func (environment commandEnvironment) path(path string) string {
+	if environment.workingDirPath == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(environment.workingDirPath, path)
+}
+
+//Entry types are sorted by name, entries of a type are in the order they are stored in
+func (environment commandEnvironment) sortedEntryTypes() []data.EntryType {
+	entryTypes := []data.EntryType{}
+	for entryType := range environment.entriesContainer.EntriesGroupedByType() {
+		entryTypes = append(entryTypes, entryType)
+	}
+	sort.Slice(entryTypes, func(i, j int) bool { return entryTypes[i].Name < entryTypes[j].Name })
+	return entryTypes
+}
+
+type typeSummary struct {
+	Name            string
+	AmountOfEntries int
+}
+
+func runTypesCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "types")
+	format := outputFormatFlag(flagSet)
+	err := parseCommandFlags(flagSet, args)
+	if err != nil {
+		return err
+	}
+	summaries := []typeSummary{}
+	rows := [][]string{}
+	for _, entryType := range environment.sortedEntryTypes() {
+		amount := len(environment.entriesContainer.EntriesGroupedByType()[entryType])
+		summaries = append(summaries, typeSummary{entryType.Name, amount})
+		rows = append(rows, []string{entryType.Name, strconv.Itoa(amount)})
+	}
+	return environment.print(*format, summaries, []string{"TYPE", "ENTRIES"}, rows)
+}
+
+//Entries are listed with the names of their types, as they can be of many types
+type listedEntry struct {
+	Type string
+	data.Entry
+}
+
+func runListCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "list")
+	format := outputFormatFlag(flagSet)
+	typeNames := flagSet.String("type", "", "Comma separated names of entry types of the listed entries")
+	statuses := flagSet.String("status", "", "Comma separated statuses of the listed entries")
+	tags := flagSet.String("tags", "", "Comma separated tags that the listed entries have to have")
+	title := flagSet.String("title", "", "Text that titles of the listed entries have to contain")
+	minScore := flagSet.Int("min-score", 0, "Minimal score of the listed entries on the scale from 1 to 10")
+	relatedAs := flagSet.String("related-as", "", "Comma separated kinds of relations in which the listed entries are to other entries, e.g. 'adaptation'")
+	order := flagSet.String("sort", typeOrder, "Order of the listed entries, by '"+typeOrder+"' or from the highest '"+scoreOrder+"'")
+	err := parseCommandFlags(flagSet, args)
+	if err == nil && *order != typeOrder && *order != scoreOrder {
+		err = errors.New("Entries cannot be sorted by '" + *order + "'")
+	}
+	if err != nil {
+		return err
+	}
+	filter := data.Filter{
+		TypeNames:     data.SplitCommaSeparatedValues(*typeNames),
+		Tags:          data.SplitCommaSeparatedValues(*tags),
+		TitleContains: *title,
+		MinScore:      *minScore,
+	}
+	for _, status := range data.SplitCommaSeparatedValues(*statuses) {
+		if !environment.entriesContainer.IsValidStatus(data.EntryStatus(status)) {
+			return errors.New("'" + status + "' is not a valid status of an entry")
+		}
+		filter.Statuses = append(filter.Statuses, data.EntryStatus(status))
+	}
+	for _, kind := range data.SplitCommaSeparatedValues(*relatedAs) {
+		if !data.IsValidRelationKind(data.RelationKind(kind)) {
+			return errors.New("'" + kind + "' is not a valid kind of relation")
+		}
+		filter.RelatedAs = append(filter.RelatedAs, data.RelationKind(kind))
+	}
+	filteredEntries := environment.entriesContainer.EntriesMatching(filter, time.Now())
+	if *order == scoreOrder {
+		filteredEntries = data.SortedByScore(filteredEntries)
+	}
+	entries := []listedEntry{}
+	rows := [][]string{}
+	for _, filteredEntry := range filteredEntries {
+		entry := filteredEntry.Entry
+		entries = append(entries, listedEntry{filteredEntry.Type.Name, entry})
+		rows = append(rows, []string{filteredEntry.Type.Name, strconv.Itoa(entry.Id), string(entry.Status), entry.Title,
+			strconv.Itoa(entry.ElementsCompleted) + "/" + strconv.Itoa(entry.TotalAmountOfElementsToComplete),
+			filteredEntry.Type.Scoring().FormatScore(entry.Score)})
+	}
+	return environment.print(*format, entries, []string{"TYPE", "ID", "STATUS", "TITLE", "PROGRESS", "SCORE"}, rows)
+}
+
+func runAddCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "add")
+	typeName := flagSet.String("type", "", "Name of the entry type to add the entry to")
+	entry := data.Entry{}
+	flagSet.StringVar(&entry.Title, "title", "", "Title of the entry")
+	status := flagSet.String("status", "", "Status of the entry, the planned status of the entry type by default")
+	flagSet.IntVar(&entry.TotalAmountOfElementsToComplete, "total", 0, "Total amount of elements of the entry to complete")
+	score := flagSet.String("score", "", "Score of the entry as it's displayed in the scoring system of the entry type, e.g. 3.5 stars")
+	flagSet.StringVar(&entry.Tags, "tags", "", "Comma separated tags of the entry")
+	flagSet.StringVar(&entry.Link, "link", "", "Link to the entry")
+	err := parseCommandFlags(flagSet, args)
+	if err == nil {
+		err = requireCommandFlags(flagSet, "type", "title")
+	}
+	if err != nil {
+		return err
+	}
+	entry.Status = data.EntryStatus(*status)
+	//Entry type that doesn't exist is reported when adding the entry
+	if entryType, err := environment.entriesContainer.EntryTypeWithName(*typeName); err == nil {
+		if *status == "" {
+			entry.Status = entryType.StatusIn(data.PlannedStatus)
+		} else if !entryType.HasStatus(entry.Status) {
+			return errors.New("'" + *status + "' is not a status of entry type '" + *typeName + "'")
+		}
+		entry.Score, err = entryType.Scoring().ParseScore(*score)
+		if err != nil {
+			return err
+		}
+	}
+	id, err := environment.entriesContainer.AddEntry(*typeName, entry)
+	if err != nil {
+		return err
+	}
+	err = environment.saveChanges()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(environment.output, id)
+	return nil
+}
+
+//Progress is changed the same way as in the application, so the change is recorded in the entry's history
+func runSetProgressCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "set-progress")
+	typeName := flagSet.String("type", "", "Name of the entry type of the entry")
+	id := flagSet.Int("id", 0, "Id of the entry, as printed by the list command")
+	elementsCompleted := flagSet.Int("to", 0, "Amount of completed elements of the entry")
+	err := parseCommandFlags(flagSet, args)
+	if err == nil {
+		err = requireCommandFlags(flagSet, "type", "id", "to")
+	}
+	if err != nil {
+		return err
+	}
+	entryType, err := environment.entriesContainer.EntryTypeWithName(*typeName)
+	if err != nil {
+		return err
+	}
+	entry, exists := entryWithIdIn(environment.entriesContainer.EntriesGroupedByType()[entryType], *id)
+	if !exists {
+		return errors.New("There is no entry with id " + strconv.Itoa(*id) + " in entry type '" + *typeName + "'")
+	}
+	if *elementsCompleted == entry.ElementsCompleted {
+		return nil
+	}
+	err = environment.entriesContainer.ChangeEntryProgress(*typeName, *id, *elementsCompleted-entry.ElementsCompleted)
+	if err != nil {
+		return err
+	}
+	return environment.saveChanges()
+}
+
+//Format of exported collection, which is also the format of imported collections
+type exportedCollection struct {
+	EntryTypes []exportedEntryType
+	Lists      []data.EntriesList
+	Relations  []data.Relation
+	Series     []data.Series
+}
+
+type exportedEntryType struct {
+	EntryType data.EntryType
+	Entries   []data.Entry
+}
+
+//Collection is written to the given file or, if there is none, to the output
+func runExportCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "export")
+	filePath := flagSet.String("o", "", "Path of the file to export to")
+	err := parseCommandFlags(flagSet, args)
+	if err != nil {
+		return err
+	}
+	collection := exportedCollection{EntryTypes: []exportedEntryType{}, Lists: environment.entriesContainer.Lists(),
+		Relations: environment.entriesContainer.Relations(), Series: environment.entriesContainer.AllSeries()}
+	for _, entryType := range environment.sortedEntryTypes() {
+		entries := append([]data.Entry{}, environment.entriesContainer.EntriesGroupedByType()[entryType]...)
+		collection.EntryTypes = append(collection.EntryTypes, exportedEntryType{entryType, entries})
+	}
+	collectionData, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode the exported collection")
+	}
+	if *filePath == "" {
+		_, err = environment.output.Write(append(collectionData, '\n'))
+		return errors.Wrap(err, "Failed to write the exported collection")
+	}
+	return errors.Wrap(ioutil.WriteFile(environment.path(*filePath), collectionData, 0600), "Failed to write the exported collection into "+*filePath)
+}
+
+//Imported entries are always added as new entries, to the entry types with the same names, which are added if they
+//don't exist. Lists and series that already exist are not imported, as their entries would be mixed with the imported ones.
+func runImportCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "import")
+	filePath := flagSet.String("i", "", "Path of the file to import from")
+	err := parseCommandFlags(flagSet, args)
+	if err == nil {
+		err = requireCommandFlags(flagSet, "i")
+	}
+	if err != nil {
+		return err
+	}
+	collectionData, err := ioutil.ReadFile(environment.path(*filePath))
+	if err != nil {
+		return errors.Wrap(err, "Failed to read the collection to import from "+*filePath)
+	}
+	collection := exportedCollection{}
+	err = json.Unmarshal(collectionData, &collection)
+	if err != nil {
+		return errors.Wrap(err, "Failed to decode the collection to import from "+*filePath)
+	}
+	importedIds, err := environment.importEntryTypes(collection.EntryTypes)
+	if err == nil {
+		err = environment.importLists(collection.Lists, importedIds)
+	}
+	if err == nil {
+		err = environment.importRelations(collection.Relations, importedIds)
+	}
+	if err == nil {
+		err = environment.importSeries(collection.Series, importedIds)
+	}
+	if err != nil {
+		return err
+	}
+	return environment.saveChanges()
+}
+
+//Returns new ids of imported entries, grouped by names of their types and their ids in the imported collection
+func (environment commandEnvironment) importEntryTypes(entryTypes []exportedEntryType) (map[string]map[int]int, error) {
+	importedIds := map[string]map[int]int{}
+	for _, exported := range entryTypes {
+		if _, err := environment.entriesContainer.EntryTypeWithName(exported.EntryType.Name); err != nil {
+			err = environment.entriesContainer.AddEntryType(exported.EntryType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		importedIds[exported.EntryType.Name] = map[int]int{}
+		for _, entry := range exported.Entries {
+			id, err := environment.entriesContainer.AddEntry(exported.EntryType.Name, entry)
+			if err != nil {
+				return nil, err
+			}
+			importedIds[exported.EntryType.Name][entry.Id] = id
+		}
+	}
+	return importedIds, nil
+}
+
+//Items of lists whose entries have not been imported are skipped
+func (environment commandEnvironment) importLists(lists []data.EntriesList, importedIds map[string]map[int]int) error {
+	for _, list := range lists {
+		if _, err := environment.entriesContainer.ListWithName(list.Name); err == nil {
+			continue
+		}
+		err := environment.entriesContainer.CreateList(list.Name)
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			id, imported := importedIds[item.TypeName][item.EntryId]
+			if !imported {
+				continue
+			}
+			err = environment.entriesContainer.AddToList(list.Name, item.TypeName, id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//Relations of entries that have not been imported are skipped
+func (environment commandEnvironment) importRelations(relations []data.Relation, importedIds map[string]map[int]int) error {
+	for _, relation := range relations {
+		id, imported := importedIds[relation.TypeName][relation.EntryId]
+		relatedId, relatedImported := importedIds[relation.RelatedTypeName][relation.RelatedEntryId]
+		if !imported || !relatedImported {
+			continue
+		}
+		relation.EntryId, relation.RelatedEntryId = id, relatedId
+		err := environment.entriesContainer.AddRelation(relation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Members of series whose entries have not been imported are skipped
+func (environment commandEnvironment) importSeries(allSeries []data.Series, importedIds map[string]map[int]int) error {
+	for _, series := range allSeries {
+		if _, err := environment.entriesContainer.SeriesWithName(series.Name); err == nil {
+			continue
+		}
+		err := environment.entriesContainer.CreateSeries(series.Name)
+		if err != nil {
+			return err
+		}
+		for _, member := range series.Members {
+			id, imported := importedIds[member.TypeName][member.EntryId]
+			if !imported {
+				continue
+			}
+			err = environment.entriesContainer.AddToSeries(series.Name, member.TypeName, id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//Server should only be reachable from the local network, so it listens on localhost unless told otherwise.
+//Commands run while it's serving are forwarded to it only if it has a token, as they can read and write any files.
+func runServeCommand(environment commandEnvironment, args []string) error {
+	flagSet := newCommandFlagSet(environment, "serve")
+	address := flagSet.String("address", "localhost:8420", "Address the server listens on")
+	token := flagSet.String("token", "", "Token that requests have to have in their 'Authorization: Bearer <token>' header")
+	err := parseCommandFlags(flagSet, args)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", *address)
+	if err != nil {
+		return errors.Wrap(err, "Failed to serve the API on "+*address)
+	}
+	defer listener.Close()
+	lock, err := data.AcquireInstanceLock(environment.config.AppDataDirPath, data.LockOwner{
+		Pid:         os.Getpid(),
+		Description: "the serve command",
+		StartTime:   time.Now(),
+		Address:     listener.Addr().String(),
+		Token:       *token,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := lock.Release()
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+	server := api.NewServer(environment.entriesContainer, environment.saveChanges)
+	server.SetToken(*token)
+	if *token != "" {
+		server.SetCommandRunner(environment.runForwardedCommand)
+	}
+	fmt.Fprintln(environment.output, "Serving the API on http://"+*address+", its description is under /openapi.json")
+	return errors.Wrap(http.Serve(listener, server), "Failed to serve the API on "+*address)
+}
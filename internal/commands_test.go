@@ -0,0 +1,235 @@
+package wirwl
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"path/filepath"
+	"testing"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+)
+
+func runTestCommand(args ...string) (int, string, string) {
+	output, errorOutput := bytes.Buffer{}, bytes.Buffer{}
+	exitCode := RunCommand(NewAppConfigurator(testConfigDirPath), args, &output, &errorOutput)
+	return exitCode, output.String(), errorOutput.String()
+}
+
+func loadTestEntries() *data.EntriesContainer {
+	entriesContainer := data.NewEntriesContainer(data.NewBoltProvider(testDbCopyPath))
+	err := entriesContainer.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return entriesContainer
+}
+
+func TestThatEntryTypesAreListedAsTableOrJSON(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	exitCode, output, _ := runTestCommand("types")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	assert.Equal(t, "TYPE    ENTRIES\ncomics  2\nmusic   2\nvideos  2\n", output)
+	exitCode, output, _ = runTestCommand("types", "-format", "json")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	summaries := []typeSummary{}
+	assert.Nil(t, json.Unmarshal([]byte(output), &summaries))
+	assert.Equal(t, []typeSummary{{"comics", 2}, {"music", 2}, {"videos", 2}}, summaries)
+}
+
+func TestThatOnlyEntriesMatchingFilterAreListed(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	exitCode, output, _ := runTestCommand("list", "-type", "music, videos", "-title", "2", "-format", "json")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	entries := []listedEntry{}
+	assert.Nil(t, json.Unmarshal([]byte(output), &entries))
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "music", entries[0].Type)
+	assert.Equal(t, "some music2", entries[0].Title)
+	assert.Equal(t, "videos", entries[1].Type)
+	assert.Equal(t, "some video2", entries[1].Title)
+	exitCode, _, errorOutput := runTestCommand("list", "-status", "Forgotten")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Equal(t, "Error: 'Forgotten' is not a valid status of an entry\n", errorOutput)
+}
+
+func TestThatAddedEntryIsSavedAndItsIdIsPrinted(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	exitCode, output, _ := runTestCommand("add", "-type", "comics", "-title", "new comic", "-total", "10", "-tags", "new")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	assert.Equal(t, "2\n", output)
+	comicsType, _ := loadTestEntries().EntryTypeWithName("comics")
+	entries := loadTestEntries().EntriesGroupedByType()[comicsType]
+	assert.Equal(t, data.Entry{Id: 2, Title: "new comic", Status: data.PlannedStatus, TotalAmountOfElementsToComplete: 10, Tags: "new"}, entries[2])
+	exitCode, _, errorOutput := runTestCommand("add", "-type", "comics")
+	assert.Equal(t, CommandMisused, exitCode)
+	assert.Contains(t, errorOutput, "Flag -title is required")
+	exitCode, _, errorOutput = runTestCommand("add", "-type", "books", "-title", "new book")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Equal(t, "Error: Cannot add entry 'new book' to entry type 'books' as no such type exists\n", errorOutput)
+}
+
+func TestThatProgressIsSetAndRecordedInHistory(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	exitCode, _, _ := runTestCommand("set-progress", "-type", "videos", "-id", "1", "-to", "2")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	videosType, _ := loadTestEntries().EntryTypeWithName("videos")
+	entry := loadTestEntries().EntriesGroupedByType()[videosType][1]
+	assert.Equal(t, 2, entry.ElementsCompleted)
+	assert.NotEmpty(t, entry.ConsumptionHistory)
+	exitCode, _, errorOutput := runTestCommand("set-progress", "-type", "videos", "-id", "5", "-to", "2")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Equal(t, "Error: There is no entry with id 5 in entry type 'videos'\n", errorOutput)
+}
+
+func TestThatScoresAreInScoringSystemOfEntryTypeAndEntriesCanBeListedByThem(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	entriesContainer := loadTestEntries()
+	err := entriesContainer.UpdateEntryType("comics", data.EntryType{Name: "comics", ScoringSystem: data.FiveStarScoring})
+	if err == nil {
+		err = entriesContainer.SaveData()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	exitCode, _, _ := runTestCommand("add", "-type", "comics", "-title", "new comic", "-score", "4.5")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	exitCode, output, _ := runTestCommand("list", "-type", "comics", "-sort", "score", "-format", "json")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	entries := []listedEntry{}
+	assert.Nil(t, json.Unmarshal([]byte(output), &entries))
+	assert.Equal(t, []int{2, 1, 0}, []int{entries[0].Id, entries[1].Id, entries[2].Id})
+	assert.Equal(t, []int{9, 6, 3}, []int{entries[0].Score, entries[1].Score, entries[2].Score})
+	_, output, _ = runTestCommand("list", "-type", "comics", "-sort", "score")
+	assert.Contains(t, output, "★★★★½")
+	exitCode, _, errorOutput := runTestCommand("add", "-type", "comics", "-title", "new comic", "-score", "7")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Equal(t, "Error: Score '7' has to be an amount of stars from 0.5 to 5 in halves, e.g. 3.5\n", errorOutput)
+	exitCode, _, errorOutput = runTestCommand("list", "-sort", "title")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Equal(t, "Error: Entries cannot be sorted by 'title'\n", errorOutput)
+}
+
+func TestThatEntriesAreNotSavedWhenThereAreChangesInJournal(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	err := data.NewJournal(configurator.config.JournalFilePath()).Append(data.JournalRecord{Operation: data.DeleteEntryTypeOperation, TypeName: "music"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	exitCode, _, errorOutput := runTestCommand("add", "-type", "comics", "-title", "new comic")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Contains(t, errorOutput, "there are unsaved changes of a previous session")
+	comicsType, _ := loadTestEntries().EntryTypeWithName("comics")
+	assert.Equal(t, 2, len(loadTestEntries().EntriesGroupedByType()[comicsType]))
+}
+
+func TestThatExportedCollectionCanBeImported(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	entriesContainer := loadTestEntries()
+	err := entriesContainer.CreateList("favourites")
+	if err == nil {
+		err = entriesContainer.AddToList("favourites", "music", 1)
+	}
+	if err == nil {
+		err = entriesContainer.AddRelation(data.Relation{Kind: data.AdaptationRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 1})
+	}
+	if err == nil {
+		err = entriesContainer.CreateSeries("franchise")
+	}
+	if err == nil {
+		err = entriesContainer.AddToSeries("franchise", "music", 0)
+	}
+	if err == nil {
+		err = entriesContainer.SaveData()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	exportPath := filepath.Join(testAppDataDirPath, "export.json")
+	exitCode, _, _ := runTestCommand("export", "-o", exportPath)
+	assert.Equal(t, CommandSucceeded, exitCode)
+	entriesContainer = loadTestEntries()
+	assert.Nil(t, entriesContainer.DeleteEntryType("music"))
+	assert.Nil(t, entriesContainer.DeleteList("favourites"))
+	assert.Nil(t, entriesContainer.DeleteSeries("franchise"))
+	assert.Nil(t, entriesContainer.SaveData())
+	exitCode, _, errorOutput := runTestCommand("import", "-i", exportPath)
+	assert.Equal(t, CommandSucceeded, exitCode, errorOutput)
+	entriesContainer = loadTestEntries()
+	musicType, _ := entriesContainer.EntryTypeWithName("music")
+	assert.Equal(t, []string{"some music1", "some music2"}, entriesTitles(entriesContainer.EntriesGroupedByType()[musicType]))
+	comicsType, _ := entriesContainer.EntryTypeWithName("comics")
+	assert.Equal(t, []string{"some comic1", "some comic2", "some comic1", "some comic2"}, entriesTitles(entriesContainer.EntriesGroupedByType()[comicsType]))
+	assert.Equal(t, []data.EntriesList{{Name: "favourites", Items: []data.ListItem{{TypeName: "music", EntryId: 1}}}}, entriesContainer.Lists())
+	assert.Equal(t, []data.Relation{{Kind: data.AdaptationRelation, TypeName: "comics", EntryId: 2, RelatedTypeName: "music", RelatedEntryId: 1}},
+		entriesContainer.Relations())
+	assert.Equal(t, []data.Series{{Name: "franchise", Members: []data.ListItem{{TypeName: "music", EntryId: 0}}}}, entriesContainer.AllSeries())
+	exitCode, output, _ := runTestCommand("list", "-related-as", "adaptation")
+	assert.Equal(t, CommandSucceeded, exitCode)
+	assert.Contains(t, output, "some music2")
+	assert.NotContains(t, output, "some music1")
+	exitCode, _, errorOutput = runTestCommand("list", "-related-as", "remake")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Contains(t, errorOutput, "'remake' is not a valid kind of relation")
+}
+
+func entriesTitles(entries []data.Entry) []string {
+	titles := []string{}
+	for _, entry := range entries {
+		titles = append(titles, entry.Title)
+	}
+	return titles
+}
+
+func TestThatUnknownCommandIsReported(t *testing.T) {
+	exitCode, _, errorOutput := runTestCommand("remove")
+	assert.Equal(t, CommandMisused, exitCode)
+	assert.Contains(t, errorOutput, "Unknown command 'remove'")
+	assert.Contains(t, errorOutput, "set-progress")
+}
+
+func TestThatServeCommandFailsWhenAddressCannotBeListenedOn(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	exitCode, _, errorOutput := runTestCommand("serve", "-address", "localhost:-1")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Contains(t, errorOutput, "Error: Failed to serve the API on localhost:-1")
+	exitCode, _, _ = runTestCommand("serve", "-port", "8420")
+	assert.Equal(t, CommandMisused, exitCode)
+}
+
+func TestThatCommandsAreNotForwardedToServerWithoutToken(t *testing.T) {
+	defer cleanupAfterTestRun()
+	configurator := NewTestAppConfigurator()
+	configurator.prepareConfiguratorForTestingWithExistingData()
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer listener.Close()
+	lock, err := data.AcquireInstanceLock(testAppDataDirPath, data.LockOwner{Pid: 123, Description: "the serve command",
+		Address: listener.Addr().String()})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer lock.Release()
+	exitCode, _, errorOutput := runTestCommand("types")
+	assert.Equal(t, CommandFailed, exitCode)
+	assert.Equal(t, "Error: The collection is used by the serve command, which doesn't accept commands as it has no token\n", errorOutput)
+}
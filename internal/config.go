@@ -16,24 +16,63 @@ import (
 const appName = "wirwl"
 const configFileName = appName + ".cfg"
 const logFileName = appName + ".log"
+const dbFileName = "data.db"
+const imagesDirName = "images"
+const backupsDirName = "backups"
+const journalFileName = "journal.jsonl"
+const customThemeFileName = "theme.toml"
 
 type Config struct {
 	AppDataDirPath string
 	ConfigDirPath  string
 	Keymap         map[input.Action]input.KeyCombination
+	//Layouts of entries tables mapped to the names of entry types they are used for
+	ColumnsLayouts map[string][]ColumnSettings
+	//Template of URL used to search for covers, see covers.HTTPSource for details
+	CoverSearchURL string
+	//Amount of seconds between automatic saves of unsaved changes, 0 disables saving them periodically
+	AutosavePeriod int
+	//Whether changes should be saved automatically as soon as they are made
+	AutosaveOnEdit bool
+	//Amount of changes that can be undone, 0 means that the default amount is used
+	UndoDepth int
+	//Filters of smart lists mapped to the names of the lists
+	SmartLists map[string]data.Filter
+	//Name of the theme, see themes.Named. The custom theme is defined in a file in the config directory.
+	Theme string
 }
 
-/*As TOML can't encode/decode maps that contain something else than strings, a helper struct is needed to convert
+//Describes a single visible column of an entries table. Columns are displayed in the order they are stored in a layout.
+//Width equal to 0 means that the column's width is adjusted automatically.
+type ColumnSettings struct {
+	Name  string
+	Width int
+}
+
+/*
+As TOML can't encode/decode maps that contain something else than strings, a helper struct is needed to convert
 before encoding/decoding.
 */
 type encodableDecodableConfig struct {
 	AppDataDirPath string
 	ConfigDirPath  string
 	Keymap         map[string]string
+	ColumnsLayouts map[string][]ColumnSettings
+	CoverSearchURL string
+	AutosavePeriod int
+	AutosaveOnEdit bool
+	UndoDepth      int
+	SmartLists     map[string]data.Filter
+	Theme          string
 }
 
 func NewConfig(configDirPath string) Config {
-	config := Config{ConfigDirPath: configDirPath, Keymap: map[input.Action]input.KeyCombination{}}
+	config := Config{
+		ConfigDirPath:  configDirPath,
+		Keymap:         map[input.Action]input.KeyCombination{},
+		ColumnsLayouts: map[string][]ColumnSettings{},
+		SmartLists:     map[string]data.Filter{},
+	}
 	return config
 }
 
@@ -59,10 +98,22 @@ func (config *Config) readConfigFromConfigFile() error {
 	return nil
 }
 
+//Actions that are not in the config file, e.g. because they have been added after it was saved, keep their default bindings
 func (config *Config) readDataFromDecodedConfig(decodedConfig encodableDecodableConfig) {
 	config.AppDataDirPath = decodedConfig.AppDataDirPath
 	config.ConfigDirPath = decodedConfig.ConfigDirPath
-	config.Keymap = convertStringKeymapToFormatUsableByConfig(decodedConfig.Keymap)
+	config.Keymap = make(map[input.Action]input.KeyCombination)
+	config.loadDefaultKeymap()
+	for action, keyCombination := range convertStringKeymapToFormatUsableByConfig(decodedConfig.Keymap) {
+		config.Keymap[action] = keyCombination
+	}
+	config.ColumnsLayouts = decodedConfig.ColumnsLayouts
+	config.CoverSearchURL = decodedConfig.CoverSearchURL
+	config.AutosavePeriod = decodedConfig.AutosavePeriod
+	config.AutosaveOnEdit = decodedConfig.AutosaveOnEdit
+	config.UndoDepth = decodedConfig.UndoDepth
+	config.SmartLists = decodedConfig.SmartLists
+	config.Theme = decodedConfig.Theme
 }
 
 func convertStringKeymapToFormatUsableByConfig(stringKeymap map[string]string) map[input.Action]input.KeyCombination {
@@ -126,11 +177,45 @@ func (config *Config) loadDefaultKeymap() {
 	config.Keymap[input.EditCurrentEntryTypeAction] = input.TwoKeyCombination(fyne.KeyT, fyne.KeyE)
 	config.Keymap[input.MoveDownAction] = input.SingleKeyCombination(fyne.KeyJ)
 	config.Keymap[input.MoveUpAction] = input.SingleKeyCombination(fyne.KeyK)
+	config.Keymap[input.MoveLeftAction] = input.SingleKeyCombination(fyne.KeyH)
+	config.Keymap[input.MoveRightAction] = input.SingleKeyCombination(fyne.KeyL)
 	config.Keymap[input.EnterInputModeAction] = input.SingleKeyCombination(fyne.KeyI)
 	config.Keymap[input.ExitInputModeAction] = input.SingleKeyCombination(fyne.KeyEscape)
 	config.Keymap[input.ExitTableAction] = input.SingleKeyCombination(fyne.KeySpace)
 	config.Keymap[input.ConfirmAction] = input.SingleKeyCombination(fyne.KeyReturn)
 	config.Keymap[input.CancelAction] = input.SingleKeyCombination(fyne.KeyEscape)
+	config.Keymap[input.EditColumnsLayoutAction] = input.TwoKeyCombination(fyne.KeyC, fyne.KeyE)
+	config.Keymap[input.IncrementProgressAction] = input.SingleKeyCombination(fyne.KeyEqual)
+	config.Keymap[input.DecrementProgressAction] = input.SingleKeyCombination(fyne.KeyMinus)
+	config.Keymap[input.ToggleDisplayModeAction] = input.TwoKeyCombination(fyne.KeyV, fyne.KeyC)
+	config.Keymap[input.ImportCoverAction] = input.TwoKeyCombination(fyne.KeyC, fyne.KeyI)
+	config.Keymap[input.CreateBackupAction] = input.TwoKeyCombination(fyne.KeyB, fyne.KeyC)
+	config.Keymap[input.DownloadCoverAction] = input.TwoKeyCombination(fyne.KeyC, fyne.KeyD)
+	config.Keymap[input.EnrichEntryAction] = input.TwoKeyCombination(fyne.KeyE, fyne.KeyN)
+	config.Keymap[input.UndoAction] = input.SingleKeyCombination(fyne.KeyU)
+	config.Keymap[input.RedoAction] = input.SingleKeyCombination(fyne.KeyR)
+	config.Keymap[input.ShowEntryDetailsAction] = input.TwoKeyCombination(fyne.KeyV, fyne.KeyD)
+	config.Keymap[input.StartRewatchAction] = input.TwoKeyCombination(fyne.KeyS, fyne.KeyR)
+	config.Keymap[input.ShowStatsAction] = input.TwoKeyCombination(fyne.KeyV, fyne.KeyS)
+	config.Keymap[input.CreateListAction] = input.TwoKeyCombination(fyne.KeyN, fyne.KeyL)
+	config.Keymap[input.DeleteListAction] = input.TwoKeyCombination(fyne.KeyD, fyne.KeyL)
+	config.Keymap[input.AddToListAction] = input.TwoKeyCombination(fyne.KeyA, fyne.KeyL)
+	config.Keymap[input.RemoveFromListAction] = input.TwoKeyCombination(fyne.KeyX, fyne.KeyL)
+	config.Keymap[input.MoveDownInListAction] = input.TwoKeyCombination(fyne.KeyM, fyne.KeyJ)
+	config.Keymap[input.MoveUpInListAction] = input.TwoKeyCombination(fyne.KeyM, fyne.KeyK)
+	config.Keymap[input.CreateSmartListAction] = input.TwoKeyCombination(fyne.KeyN, fyne.KeyS)
+	config.Keymap[input.SwitchThemeAction] = input.TwoKeyCombination(fyne.KeyV, fyne.KeyT)
+	config.Keymap[input.AddRelationAction] = input.TwoKeyCombination(fyne.KeyA, fyne.KeyR)
+	config.Keymap[input.RemoveRelationAction] = input.TwoKeyCombination(fyne.KeyD, fyne.KeyR)
+	config.Keymap[input.GoToRelatedEntryAction] = input.TwoKeyCombination(fyne.KeyG, fyne.KeyR)
+	config.Keymap[input.CreateSeriesAction] = input.TwoKeyCombination(fyne.KeyN, fyne.KeyF)
+	config.Keymap[input.DeleteSeriesAction] = input.TwoKeyCombination(fyne.KeyD, fyne.KeyF)
+	config.Keymap[input.AddToSeriesAction] = input.TwoKeyCombination(fyne.KeyA, fyne.KeyF)
+	config.Keymap[input.RemoveFromSeriesAction] = input.TwoKeyCombination(fyne.KeyX, fyne.KeyF)
+	config.Keymap[input.FindDuplicatesAction] = input.TwoKeyCombination(fyne.KeyF, fyne.KeyD)
+	config.Keymap[input.ToggleEntryMarkAction] = input.TwoKeyCombination(fyne.KeyM, fyne.KeyM)
+	config.Keymap[input.MoveEntriesAction] = input.TwoKeyCombination(fyne.KeyM, fyne.KeyT)
+	config.Keymap[input.CopyEntriesAction] = input.TwoKeyCombination(fyne.KeyC, fyne.KeyT)
 }
 
 func (config *Config) save() error {
@@ -138,7 +223,7 @@ func (config *Config) save() error {
 	if err != nil {
 		return errors.Wrap(err, "Failed to save the config because config directory in "+config.ConfigDirPath+" could not be created")
 	}
-	configFile, err := os.OpenFile(config.ConfigFilePath(), os.O_CREATE|os.O_WRONLY, 0700)
+	configFile, err := os.OpenFile(config.ConfigFilePath(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0700)
 	if err != nil {
 		return errors.Wrap(err, "Failed to save the config file because config file in "+config.ConfigFilePath()+" could not be opened")
 	}
@@ -163,13 +248,74 @@ func (config *Config) madeEncodable() encodableDecodableConfig {
 		AppDataDirPath: config.AppDataDirPath,
 		ConfigDirPath:  config.ConfigDirPath,
 		Keymap:         encodableKeymap,
+		ColumnsLayouts: config.ColumnsLayouts,
+		CoverSearchURL: config.CoverSearchURL,
+		AutosavePeriod: config.AutosavePeriod,
+		AutosaveOnEdit: config.AutosaveOnEdit,
+		UndoDepth:      config.UndoDepth,
+		SmartLists:     config.SmartLists,
+		Theme:          config.Theme,
+	}
+}
+
+//Returns the layout set for entry type with given name or the default layout if none has been set
+func (config *Config) ColumnsLayoutFor(entryTypeName string) []ColumnSettings {
+	layout, layoutExists := config.ColumnsLayouts[entryTypeName]
+	if layoutExists && len(layout) > 0 {
+		return layout
+	}
+	return defaultColumnsLayout()
+}
+
+func (config *Config) SetColumnsLayoutFor(entryTypeName string, layout []ColumnSettings) {
+	if config.ColumnsLayouts == nil {
+		config.ColumnsLayouts = map[string][]ColumnSettings{}
+	}
+	config.ColumnsLayouts[entryTypeName] = layout
+}
+
+func (config *Config) renameColumnsLayout(oldEntryTypeName string, newEntryTypeName string) {
+	layout, layoutExists := config.ColumnsLayouts[oldEntryTypeName]
+	if layoutExists && oldEntryTypeName != newEntryTypeName {
+		delete(config.ColumnsLayouts, oldEntryTypeName)
+		config.ColumnsLayouts[newEntryTypeName] = layout
 	}
 }
 
+//Smart lists that include entries of a renamed entry type keep including them
+func (config *Config) renameEntryTypeInSmartLists(oldEntryTypeName string, newEntryTypeName string) {
+	for listName, filter := range config.SmartLists {
+		typeNames := append([]string{}, filter.TypeNames...)
+		for i, typeName := range typeNames {
+			if typeName == oldEntryTypeName {
+				typeNames[i] = newEntryTypeName
+			}
+		}
+		filter.TypeNames = typeNames
+		config.SmartLists[listName] = filter
+	}
+}
+
+func (config *Config) deleteColumnsLayout(entryTypeName string) {
+	delete(config.ColumnsLayouts, entryTypeName)
+}
+
 func (config *Config) ConfigFilePath() string {
 	return filepath.Join(config.ConfigDirPath, configFileName)
 }
 
+func (config *Config) DbFilePath() string {
+	return filepath.Join(config.AppDataDirPath, dbFileName)
+}
+
+func (config *Config) JournalFilePath() string {
+	return filepath.Join(config.AppDataDirPath, journalFileName)
+}
+
+func (config *Config) CustomThemeFilePath() string {
+	return filepath.Join(config.ConfigDirPath, customThemeFileName)
+}
+
 func (config Config) String() string {
 	return fmt.Sprintf("%#v", config)
 }
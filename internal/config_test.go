@@ -94,13 +94,64 @@ func TestThatDefaultConfigHasCorrectKeymap(t *testing.T) {
 	assert.Equal(t, input.TwoKeyCombination(fyne.KeyT, fyne.KeyE), config.Keymap[input.EditCurrentEntryTypeAction])
 	assert.Equal(t, input.SingleKeyCombination(fyne.KeyJ), config.Keymap[input.MoveDownAction])
 	assert.Equal(t, input.SingleKeyCombination(fyne.KeyK), config.Keymap[input.MoveUpAction])
+	assert.Equal(t, input.SingleKeyCombination(fyne.KeyH), config.Keymap[input.MoveLeftAction])
+	assert.Equal(t, input.SingleKeyCombination(fyne.KeyL), config.Keymap[input.MoveRightAction])
 	assert.Equal(t, input.SingleKeyCombination(fyne.KeyI), config.Keymap[input.EnterInputModeAction])
 	assert.Equal(t, input.SingleKeyCombination(fyne.KeyEscape), config.Keymap[input.ExitInputModeAction])
 	assert.Equal(t, input.SingleKeyCombination(fyne.KeyReturn), config.Keymap[input.ConfirmAction])
 	assert.Equal(t, input.SingleKeyCombination(fyne.KeyEscape), config.Keymap[input.CancelAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyC, fyne.KeyE), config.Keymap[input.EditColumnsLayoutAction])
+	assert.Equal(t, input.SingleKeyCombination(fyne.KeyEqual), config.Keymap[input.IncrementProgressAction])
+	assert.Equal(t, input.SingleKeyCombination(fyne.KeyMinus), config.Keymap[input.DecrementProgressAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyV, fyne.KeyC), config.Keymap[input.ToggleDisplayModeAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyC, fyne.KeyI), config.Keymap[input.ImportCoverAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyB, fyne.KeyC), config.Keymap[input.CreateBackupAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyC, fyne.KeyD), config.Keymap[input.DownloadCoverAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyE, fyne.KeyN), config.Keymap[input.EnrichEntryAction])
+	assert.Equal(t, input.SingleKeyCombination(fyne.KeyU), config.Keymap[input.UndoAction])
+	assert.Equal(t, input.SingleKeyCombination(fyne.KeyR), config.Keymap[input.RedoAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyV, fyne.KeyD), config.Keymap[input.ShowEntryDetailsAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyS, fyne.KeyR), config.Keymap[input.StartRewatchAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyV, fyne.KeyS), config.Keymap[input.ShowStatsAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyN, fyne.KeyL), config.Keymap[input.CreateListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyD, fyne.KeyL), config.Keymap[input.DeleteListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyA, fyne.KeyL), config.Keymap[input.AddToListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyX, fyne.KeyL), config.Keymap[input.RemoveFromListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyM, fyne.KeyJ), config.Keymap[input.MoveDownInListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyM, fyne.KeyK), config.Keymap[input.MoveUpInListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyN, fyne.KeyS), config.Keymap[input.CreateSmartListAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyV, fyne.KeyT), config.Keymap[input.SwitchThemeAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyA, fyne.KeyR), config.Keymap[input.AddRelationAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyD, fyne.KeyR), config.Keymap[input.RemoveRelationAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyG, fyne.KeyR), config.Keymap[input.GoToRelatedEntryAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyN, fyne.KeyF), config.Keymap[input.CreateSeriesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyD, fyne.KeyF), config.Keymap[input.DeleteSeriesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyA, fyne.KeyF), config.Keymap[input.AddToSeriesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyX, fyne.KeyF), config.Keymap[input.RemoveFromSeriesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyF, fyne.KeyD), config.Keymap[input.FindDuplicatesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyM, fyne.KeyM), config.Keymap[input.ToggleEntryMarkAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyM, fyne.KeyT), config.Keymap[input.MoveEntriesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyC, fyne.KeyT), config.Keymap[input.CopyEntriesAction])
 
 }
 
+func TestThatKeymapFromConfigFileIsAppliedOnTopOfDefaultKeymap(t *testing.T) {
+	defer cleanupAfterTestRun()
+	config := NewConfig(testConfigDirPath)
+	config.Keymap[input.SaveChangesAction] = input.TwoKeyCombination(fyne.KeyW, fyne.KeyQ)
+	err := config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	loadedConfig := NewConfig(testConfigDirPath)
+	err = loadedConfig.load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyW, fyne.KeyQ), loadedConfig.Keymap[input.SaveChangesAction])
+	assert.Equal(t, input.TwoKeyCombination(fyne.KeyC, fyne.KeyT), loadedConfig.Keymap[input.CopyEntriesAction])
+}
+
 func TestThatErrorGetsReturnedIfConfigFileIsUnparsable(t *testing.T) {
 	err := data.CreateDirIfNotExist(testConfigDirPath)
 	if err != nil {
@@ -116,3 +167,120 @@ func TestThatErrorGetsReturnedIfConfigFileIsUnparsable(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to decode the config from the file in "+testConfigDirPath+"wirwl.cfg. File data: \n")
 }
+
+func TestThatDefaultColumnsLayoutIsReturnedForEntryTypeWithoutLayout(t *testing.T) {
+	config := NewConfig(testConfigDirPath)
+	config.SetColumnsLayoutFor("comics", []ColumnSettings{{"Title", 200}})
+	assert.Equal(t, defaultColumnsLayout(), config.ColumnsLayoutFor("music"))
+	assert.Equal(t, []ColumnSettings{{"Title", 200}}, config.ColumnsLayoutFor("comics"))
+}
+
+func TestThatColumnsLayoutsAreSavedAndLoaded(t *testing.T) {
+	defer cleanupAfterTestRun()
+	config := NewConfig(testConfigDirPath)
+	config.SetColumnsLayoutFor("comics", []ColumnSettings{{"Title", 200}, {"Score", 0}})
+	config.SetColumnsLayoutFor("some type", []ColumnSettings{{"Num", 0}})
+	err := config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	loadedConfig := NewConfig(testConfigDirPath)
+	err = loadedConfig.load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, config.ColumnsLayouts, loadedConfig.ColumnsLayouts)
+}
+
+func TestThatAutosaveAndUndoSettingsAreSavedAndLoaded(t *testing.T) {
+	defer cleanupAfterTestRun()
+	config := NewConfig(testConfigDirPath)
+	config.AutosavePeriod = 300
+	config.AutosaveOnEdit = true
+	config.UndoDepth = 20
+	err := config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	loadedConfig := NewConfig(testConfigDirPath)
+	err = loadedConfig.load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, 300, loadedConfig.AutosavePeriod)
+	assert.True(t, loadedConfig.AutosaveOnEdit)
+	assert.Equal(t, 20, loadedConfig.UndoDepth)
+}
+
+func TestThatThemeIsSavedAndLoaded(t *testing.T) {
+	defer cleanupAfterTestRun()
+	config := NewConfig(testConfigDirPath)
+	config.Theme = "dark"
+	err := config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	loadedConfig := NewConfig(testConfigDirPath)
+	err = loadedConfig.load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, "dark", loadedConfig.Theme)
+	assert.Equal(t, filepath.Join(testConfigDirPath, "theme.toml"), loadedConfig.CustomThemeFilePath())
+}
+
+func TestThatSmartListsAreSavedAndLoaded(t *testing.T) {
+	defer cleanupAfterTestRun()
+	config := NewConfig(testConfigDirPath)
+	filter := data.Filter{Statuses: []data.EntryStatus{data.PlannedStatus}, Tags: []string{"horror"}, TypeNames: []string{"videos"}, NotTouchedForDays: 90}
+	config.SmartLists["planned horror"] = filter
+	err := config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	loadedConfig := NewConfig(testConfigDirPath)
+	err = loadedConfig.load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, map[string]data.Filter{"planned horror": filter}, loadedConfig.SmartLists)
+	loadedConfig.renameEntryTypeInSmartLists("videos", "movies")
+	assert.Equal(t, []string{"movies"}, loadedConfig.SmartLists["planned horror"].TypeNames)
+	assert.Equal(t, []string{"videos"}, filter.TypeNames)
+}
+
+func TestThatSavingConfigWithLessDataOverwritesPreviousFileContents(t *testing.T) {
+	defer cleanupAfterTestRun()
+	config := NewConfig(testConfigDirPath)
+	config.SetColumnsLayoutFor("comics", []ColumnSettings{{"Title", 200}, {"Score", 0}})
+	err := config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.deleteColumnsLayout("comics")
+	err = config.save()
+	if err != nil {
+		log.Fatal(err)
+	}
+	loadedConfig := NewConfig(testConfigDirPath)
+	err = loadedConfig.load()
+	assert.Nil(t, err)
+	assert.Empty(t, loadedConfig.ColumnsLayouts)
+}
+
+func TestParsingOfColumnsLayoutSpecifications(t *testing.T) {
+	layout, err := columnsLayoutFromSpecifications(map[string]string{
+		"Title":  "2:300",
+		"Num":    " 1 ",
+		"Status": "2",
+		"Score":  "",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []ColumnSettings{{"Num", 0}, {"Status", 0}, {"Title", 300}}, layout)
+	_, err = columnsLayoutFromSpecifications(map[string]string{"Title": "1:0"})
+	assert.Equal(t, "Width of column 'Title' has to be a number greater than 0", err.Error())
+	_, err = columnsLayoutFromSpecifications(map[string]string{"Title": "1:2:3"})
+	assert.Equal(t, "Column 'Title' has incorrect value '1:2:3'. It should be either 'position' or 'position:width'", err.Error())
+	_, err = columnsLayoutFromSpecifications(map[string]string{})
+	assert.Equal(t, "At least one column has to be displayed", err.Error())
+}
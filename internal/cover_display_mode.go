@@ -0,0 +1,101 @@
+package wirwl
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/theme"
+	"wirwl/internal/data"
+	"wirwl/internal/images"
+	"wirwl/internal/input"
+	"wirwl/internal/widget"
+)
+
+/*
+Every entry type can be displayed either as a table or, in cover display mode, as a grid of covers with titles below them.
+Display mode can be toggled separately for every entry type and it stays the same until the application is closed.
+Entries which don't have a cover image are displayed with a placeholder icon.
+*/
+
+func (app *App) isInCoverDisplayMode(entryType data.EntryType) bool {
+	return app.typesInCoverDisplayMode[entryType.Name]
+}
+
+func (app *App) createEntriesCoverGrid(entryType data.EntryType, entries []data.Entry) {
+	items := []widget.CoverGridItem{}
+	for _, entry := range entries {
+		items = append(items, widget.CoverGridItem{Cover: app.coverImageFor(entryType, entry), Title: entry.Title})
+	}
+	grid := widget.NewCoverGrid(app.mainWindow.Canvas(), app.inputHandler, items)
+	grid.SetOnExitCallbackFunction(grid.ExitInputMode)
+	app.inputHandler.BindFunctionWithCountToAction(grid, input.IncrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(count) })
+	app.inputHandler.BindFunctionWithCountToAction(grid, input.DecrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(-count) })
+	app.inputHandler.BindFunctionToAction(grid, input.ToggleDisplayModeAction, func() { app.toggleCoverDisplayModeOfCurrentEntryType() })
+	app.inputHandler.BindFunctionToAction(grid, input.UndoAction, func() { app.undoLastChange() })
+	app.inputHandler.BindFunctionToAction(grid, input.RedoAction, func() { app.redoLastUndoneChange() })
+	app.inputHandler.BindFunctionToAction(grid, input.ShowEntryDetailsAction, func() { app.displayDetailsOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(grid, input.StartRewatchAction, func() { app.startRewatchingCurrentEntry() })
+	app.entriesCoverGrids[entryType] = grid
+}
+
+//Thumbnails have the same width as covers in the grid and images in the table so they don't have to be scaled much
+func (app *App) coverImageFor(entryType data.EntryType, entry data.Entry) *canvas.Image {
+	path, exists := app.imageStore.ThumbnailPath(entry.Cover, images.MediumThumbnail)
+	if exists {
+		return canvas.NewImageFromFile(path)
+	}
+	return canvas.NewImageFromResource(theme.FileImageIcon())
+}
+
+func (app *App) getCurrentEntryTypeCoverGrid() *widget.CoverGrid {
+	return app.entriesCoverGrids[app.getCurrentEntryType()]
+}
+
+//Returns the widget that displays entries of the current entry type in its current display mode
+func (app *App) getCurrentEntriesView() fyne.Focusable {
+	if table, isList := app.currentListTable(); isList {
+		return table
+	}
+	if app.isInCoverDisplayMode(app.getCurrentEntryType()) {
+		return app.getCurrentEntryTypeCoverGrid()
+	}
+	return app.getCurrentEntryTypeTable()
+}
+
+func (app *App) enterCurrentEntriesView() {
+	if table, isList := app.currentListTable(); isList {
+		table.EnterInputMode()
+		return
+	}
+	if app.isInCoverDisplayMode(app.getCurrentEntryType()) {
+		app.getCurrentEntryTypeCoverGrid().EnterInputMode()
+	} else {
+		app.getCurrentEntryTypeTable().EnterInputMode()
+	}
+}
+
+func (app *App) isCurrentEntriesViewFocused() bool {
+	focused := app.mainWindow.Canvas().Focused()
+	return focused != nil && focused == app.getCurrentEntriesView()
+}
+
+//Number of the entry that is selected in the current entries view, i.e. its row in the table or its item in the grid
+func (app *App) currentEntryNum() int {
+	if _, isList := app.currentListTable(); isList {
+		return app.currentListEntryNum()
+	}
+	if app.isInCoverDisplayMode(app.getCurrentEntryType()) {
+		return app.getCurrentEntryTypeCoverGrid().CurrentItemNum()
+	}
+	return app.getCurrentEntryTypeTable().CurrentRowNum()
+}
+
+//The entry that was selected stays selected after toggling and so does the focus
+func (app *App) toggleCoverDisplayModeOfCurrentEntryType() {
+	if app.failIfListIsSelected() {
+		return
+	}
+	entryType := app.getCurrentEntryType()
+	state := app.entriesViewStateOf(entryType)
+	app.typesInCoverDisplayMode[entryType.Name] = !app.isInCoverDisplayMode(entryType)
+	app.recreateEntriesViews(entryType, state)
+}
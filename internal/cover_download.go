@@ -0,0 +1,107 @@
+package wirwl
+
+import (
+	"bytes"
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/theme"
+	"github.com/pkg/errors"
+	"image"
+	"wirwl/internal/covers"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Covers can be downloaded from a cover source configured in the config. Candidates found for the entry selected in the
+current entry type's table or cover grid are displayed in a picker dialog and the picked one is downloaded in the background
+while its progress is displayed. Downloaded cover is then imported into the image store like a cover imported from a file,
+but only once nothing else uses the entries, as the entry may have been changed in the meantime.
+*/
+
+func (app *App) tryFindingCoversForCurrentEntry() {
+	if app.config.CoverSearchURL == "" {
+		app.msgDialog.Display(widget.WarningPopUp, "Cover search URL is not set in the config!")
+		return
+	}
+	entryType := app.getCurrentEntryType()
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to download a cover image for!")
+		return
+	}
+	app.msgDialog.Display(widget.InfoPopUp, "Searching for covers...")
+	app.runInBackground(func() {
+		candidates, previews, err := app.findCoverCandidates(covers.QueryFor(entryType, entry))
+		app.runInForeground(func() {
+			app.msgDialog.Hide()
+			if err != nil {
+				log.Error(err)
+				app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+				return
+			}
+			if len(candidates) == 0 {
+				app.msgDialog.Display(widget.InfoPopUp, "No covers have been found for '"+entry.Title+"'")
+				return
+			}
+			app.coverPickerDialog.OnPick = func(itemNum int) {
+				app.downloadCover(entryType, entry, candidates[itemNum])
+			}
+			app.coverPickerDialog.Display("Covers of '"+entry.Title+"'", previews)
+		})
+	})
+}
+
+func (app *App) findCoverCandidates(query covers.Query) ([]covers.Candidate, []widget.CoverGridItem, error) {
+	candidates, err := app.coverSource.FindCandidates(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	previews := make([]widget.CoverGridItem, len(candidates))
+	for i, candidate := range candidates {
+		previews[i] = widget.CoverGridItem{Cover: app.coverPreview(candidate), Title: candidate.Title}
+	}
+	return candidates, previews, nil
+}
+
+//Candidates whose preview can't be downloaded are still displayed as their full image may be available
+func (app *App) coverPreview(candidate covers.Candidate) fyne.CanvasObject {
+	previewData, err := app.coverSource.Download(candidate.PreviewURL(), nil)
+	if err != nil {
+		log.Error(errors.Wrap(err, "Failed to download preview of a cover"))
+		return canvas.NewImageFromResource(theme.FileImageIcon())
+	}
+	previewImage, _, err := image.Decode(bytes.NewReader(previewData))
+	if err != nil {
+		log.Error(errors.Wrap(err, "Failed to decode preview of a cover downloaded from "+candidate.PreviewURL()))
+		return canvas.NewImageFromResource(theme.FileImageIcon())
+	}
+	return canvas.NewImageFromImage(previewImage)
+}
+
+func (app *App) downloadCover(entryType data.EntryType, entry data.Entry, candidate covers.Candidate) {
+	app.progressDialog.Display("Downloading cover of '" + entry.Title + "'")
+	app.runInBackground(func() {
+		imageData, err := app.coverSource.Download(candidate.ImageURL, func(downloadedBytes int64, totalBytes int64) {
+			if totalBytes > 0 {
+				app.progressDialog.SetProgress(float64(downloadedBytes) / float64(totalBytes))
+			}
+		})
+		app.runInForeground(func() {
+			app.progressDialog.Close()
+			var hash string
+			if err == nil {
+				hash, err = app.imageStore.ImportData(imageData)
+			}
+			if err == nil {
+				err = app.entriesContainer.SetCover(entryType.Name, entry.Id, hash)
+			}
+			if err != nil {
+				err = errors.Wrap(err, "Failed to download cover of '"+entry.Title+"'")
+				log.Error(err)
+				app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+			}
+		})
+	})
+}
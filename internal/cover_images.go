@@ -0,0 +1,74 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Cover images are kept in an image store in application's data directory and entries refer to them by their hashes.
+An image can be imported from a file into the entry selected in the current entry type's table or cover grid using
+a dialog in which a path to the file is typed in. Setting a cover is a change of the entry, so it can be undone and
+it gets saved along with other changes. Images not used by any saved entry are removed when the application starts.
+*/
+
+func (app *App) loadImages() {
+	err := app.imageStore.Load()
+	if err != nil {
+		msg := "Failed to load cover images. Covers will not be displayed."
+		log.Error(errors.Wrap(err, msg))
+		app.loadingErrors[imagesLoadError] = msg
+		return
+	}
+	app.removeOrphanedImages()
+}
+
+//Unsaved changes in the journal, or in another instance if the collection has been opened read-only, can refer to
+//images that are not used by any saved entry, so the images are kept until the changes are saved
+func (app *App) removeOrphanedImages() {
+	if _, entriesLoadingErrorExists := app.loadingErrors[entriesLoadError]; entriesLoadingErrorExists || app.readOnly {
+		return
+	}
+	records, err := app.journal.Records()
+	if err != nil || len(records) > 0 {
+		return
+	}
+	err = app.imageStore.RemoveOrphans(app.entriesContainer.EntriesGroupedByType())
+	if err != nil {
+		log.Error(errors.Wrap(err, "An error occurred when removing cover images of entries that no longer exist"))
+	}
+}
+
+func (app *App) createImportCoverDialog() {
+	formItemFactory := widget.NewFormDialogFormItemFactory(app.mainWindow.Canvas(), app.inputHandler)
+	app.importCoverDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Importing cover image", formItemFactory.FormItemWithInputField("Path"))
+	app.importCoverDialog.OnEnterPressed = app.onEnterPressedInImportCoverDialog
+}
+
+func (app *App) displayDialogForImportingCover() {
+	if _, exists := app.currentEntry(); !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to import a cover image for!")
+		return
+	}
+	app.importCoverDialog.CleanItemValues()
+	app.importCoverDialog.Display()
+}
+
+func (app *App) onEnterPressedInImportCoverDialog() {
+	entryType := app.getCurrentEntryType()
+	entry, _ := app.currentEntry()
+	path := strings.TrimSpace(app.importCoverDialog.ItemValue("Path"))
+	hash, err := app.imageStore.Import(path)
+	if err == nil {
+		err = app.entriesContainer.SetCover(entryType.Name, entry.Id, hash)
+	}
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.importCoverDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
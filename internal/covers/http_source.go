@@ -0,0 +1,73 @@
+package covers
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"wirwl/internal/catalog"
+)
+
+/*
+HTTPSource finds candidates by sending a GET request to a URL created from a template in which placeholders
+{title} and {query} are replaced with query's title and entry type's image query respectively,
+e.g. 'https://example.com/search?q={title}+{query}'. Both values are escaped so they can be safely used in a URL.
+The response has to be a JSON array of candidates:
+
+	[{"title": "...", "imageUrl": "...", "thumbnailUrl": "..."}]
+
+where only imageUrl is required. Relative URLs are resolved against the URL of the request.
+*/
+type HTTPSource struct {
+	urlTemplate   string
+	client        *catalog.Client
+	MaxCandidates int
+}
+
+const TitlePlaceholder = catalog.TitlePlaceholder
+const QueryPlaceholder = "{query}"
+
+//Covers are rarely bigger than a few megabytes so anything bigger is most likely not an image
+const maxDownloadSize = 20 * 1024 * 1024
+
+func NewHTTPSource(urlTemplate string) *HTTPSource {
+	return &HTTPSource{
+		urlTemplate:   urlTemplate,
+		client:        catalog.NewClient(),
+		MaxCandidates: 8,
+	}
+}
+
+func (source *HTTPSource) SearchURL(query Query) string {
+	return catalog.SearchURL(source.urlTemplate, map[string]string{TitlePlaceholder: query.Title, QueryPlaceholder: query.TypeImageQuery})
+}
+
+func (source *HTTPSource) FindCandidates(query Query) ([]Candidate, error) {
+	if source.urlTemplate == "" {
+		return nil, errors.New("Cannot search for covers as cover search URL template is not set")
+	}
+	searchURL := source.SearchURL(query)
+	responseData, err := source.Download(searchURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to search for covers of '"+query.Title+"'")
+	}
+	candidates := []Candidate{}
+	err = json.Unmarshal(responseData, &candidates)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read covers found for '"+query.Title+"' as the response is not in the expected format")
+	}
+	validCandidates := []Candidate{}
+	for _, candidate := range candidates {
+		if candidate.ImageURL == "" {
+			continue
+		}
+		catalog.ResolveURLs(searchURL, &candidate.ImageURL, &candidate.ThumbnailURL)
+		validCandidates = append(validCandidates, candidate)
+		if len(validCandidates) == source.MaxCandidates {
+			break
+		}
+	}
+	return validCandidates, nil
+}
+
+func (source *HTTPSource) Download(url string, onProgress func(downloadedBytes int64, totalBytes int64)) ([]byte, error) {
+	return source.client.Get(url, maxDownloadSize, "a cover", onProgress)
+}
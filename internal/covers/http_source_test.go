@@ -0,0 +1,109 @@
+package covers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"wirwl/internal/catalog"
+	"wirwl/internal/data"
+)
+
+const testCandidatesResponse = `[
+	{"title": "First", "imageUrl": "/images/first.png", "thumbnailUrl": "/thumbnails/first.png"},
+	{"title": "Without image"},
+	{"title": "Second", "imageUrl": "http://example.com/second.png"}
+]`
+
+func createTestServer(receivedQueries *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", func(writer http.ResponseWriter, request *http.Request) {
+		*receivedQueries = append(*receivedQueries, request.URL.Query().Get("q"))
+		_, _ = writer.Write([]byte(testCandidatesResponse))
+	})
+	mux.HandleFunc("/invalid", func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte("<html>not json</html>"))
+	})
+	mux.HandleFunc("/image", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Length", strconv.Itoa(3*catalog.DownloadChunkSize))
+		_, _ = writer.Write([]byte(strings.Repeat("a", 3*catalog.DownloadChunkSize)))
+	})
+	mux.HandleFunc("/too_big", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Length", strconv.Itoa(maxDownloadSize+1))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestThatPlaceholdersInURLTemplateAreReplacedWithEscapedValues(t *testing.T) {
+	source := NewHTTPSource("http://example.com/search?q={title}+{query}&title={title}")
+	searchURL := source.SearchURL(Query{Title: "Some title & more", TypeImageQuery: "movie poster"})
+	assert.Equal(t, "http://example.com/search?q=Some+title+%26+more+movie+poster&title=Some+title+%26+more", searchURL)
+}
+
+func TestThatQueryUsesEntryImageQueryInsteadOfTitleIfItIsSet(t *testing.T) {
+	entryType := data.EntryType{Name: "videos", ImageQuery: "movie poster"}
+	assert.Equal(t, Query{Title: "Title", TypeImageQuery: "movie poster"}, QueryFor(entryType, data.Entry{Title: "Title"}))
+	assert.Equal(t, Query{Title: "Other", TypeImageQuery: "movie poster"}, QueryFor(entryType, data.Entry{Title: "Title", ImageQuery: "Other"}))
+}
+
+func TestThatCandidatesAreFoundWithResolvedURLsAndWithoutOnesMissingImage(t *testing.T) {
+	receivedQueries := []string{}
+	server := createTestServer(&receivedQueries)
+	defer server.Close()
+	source := NewHTTPSource(server.URL + "/search?q={title}+{query}")
+	candidates, err := source.FindCandidates(Query{Title: "Title", TypeImageQuery: "poster"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Title poster"}, receivedQueries)
+	assert.Equal(t, []Candidate{
+		{Title: "First", ImageURL: server.URL + "/images/first.png", ThumbnailURL: server.URL + "/thumbnails/first.png"},
+		{Title: "Second", ImageURL: "http://example.com/second.png"},
+	}, candidates)
+	assert.Equal(t, server.URL+"/thumbnails/first.png", candidates[0].PreviewURL())
+	assert.Equal(t, "http://example.com/second.png", candidates[1].PreviewURL())
+}
+
+func TestThatAmountOfCandidatesIsLimited(t *testing.T) {
+	receivedQueries := []string{}
+	server := createTestServer(&receivedQueries)
+	defer server.Close()
+	source := NewHTTPSource(server.URL + "/search?q={title}")
+	source.MaxCandidates = 1
+	candidates, _ := source.FindCandidates(Query{Title: "Title"})
+	assert.Equal(t, 1, len(candidates))
+}
+
+func TestThatErrorsAreReturnedWhenCandidatesCannotBeFound(t *testing.T) {
+	receivedQueries := []string{}
+	server := createTestServer(&receivedQueries)
+	defer server.Close()
+	_, err := NewHTTPSource("").FindCandidates(Query{Title: "Title"})
+	assert.Equal(t, "Cannot search for covers as cover search URL template is not set", err.Error())
+	_, err = NewHTTPSource(server.URL + "/invalid?q={title}").FindCandidates(Query{Title: "Title"})
+	assert.Contains(t, err.Error(), "Failed to read covers found for 'Title' as the response is not in the expected format")
+	_, err = NewHTTPSource(server.URL + "/not_existing?q={title}").FindCandidates(Query{Title: "Title"})
+	assert.Contains(t, err.Error(), "failed with status 404")
+}
+
+func TestThatDownloadReportsProgress(t *testing.T) {
+	receivedQueries := []string{}
+	server := createTestServer(&receivedQueries)
+	defer server.Close()
+	reportedProgress := []int64{}
+	downloadedData, err := NewHTTPSource("").Download(server.URL+"/image", func(downloadedBytes int64, totalBytes int64) {
+		assert.Equal(t, int64(3*catalog.DownloadChunkSize), totalBytes)
+		reportedProgress = append(reportedProgress, downloadedBytes)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3*catalog.DownloadChunkSize, len(downloadedData))
+	assert.Equal(t, []int64{catalog.DownloadChunkSize, 2 * catalog.DownloadChunkSize, 3 * catalog.DownloadChunkSize}, reportedProgress)
+}
+
+func TestThatTooBigFilesAreNotDownloaded(t *testing.T) {
+	receivedQueries := []string{}
+	server := createTestServer(&receivedQueries)
+	defer server.Close()
+	_, err := NewHTTPSource("").Download(server.URL+"/too_big", nil)
+	assert.Contains(t, err.Error(), "is too big to be a cover")
+}
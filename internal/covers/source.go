@@ -0,0 +1,44 @@
+package covers
+
+import (
+	"wirwl/internal/data"
+)
+
+/*
+Covers of entries are found using a source which, for a given query, returns candidates i.e. images that can be used
+as a cover. A candidate chosen by a user is then downloaded and stored like any other cover image.
+*/
+type Source interface {
+	FindCandidates(query Query) ([]Candidate, error)
+	//Progress function is called every time a part of the data gets downloaded. Total amount of bytes is -1 if it's unknown.
+	Download(url string, onProgress func(downloadedBytes int64, totalBytes int64)) ([]byte, error)
+}
+
+type Candidate struct {
+	Title        string `json:"title"`
+	ImageURL     string `json:"imageUrl"`
+	ThumbnailURL string `json:"thumbnailUrl"`
+}
+
+//Thumbnail is preferred for displaying candidates as it's smaller but not every source provides it
+func (candidate Candidate) PreviewURL() string {
+	if candidate.ThumbnailURL != "" {
+		return candidate.ThumbnailURL
+	}
+	return candidate.ImageURL
+}
+
+type Query struct {
+	//Entry's image query if it has one, otherwise entry's title
+	Title string
+	//Entry type's image query which is usually used to narrow the results down e.g. to movie posters
+	TypeImageQuery string
+}
+
+func QueryFor(entryType data.EntryType, entry data.Entry) Query {
+	title := entry.Title
+	if entry.ImageQuery != "" {
+		title = entry.ImageQuery
+	}
+	return Query{Title: title, TypeImageQuery: entryType.ImageQuery}
+}
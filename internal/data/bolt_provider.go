@@ -1,6 +1,7 @@
 package data
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"github.com/boltdb/bolt"
 	"github.com/pkg/errors"
@@ -10,43 +11,86 @@ import (
 
 const entriesTypesTableName = "entries_types"
 const entriesTableSuffix = "_entries"
+const listsTableName = "entries_lists"
+const relationsTableName = "entries_relations"
+const seriesTableName = "entries_series"
+
+//Time for which opening the database waits for another process to close it
+const dbOpeningTimeout = 10 * time.Second
 
 type BoltProvider struct {
 	dbPath string
 	db     *bolt.DB
+	//Database opened read-only can be read even if another process is writing to it, but nothing can be saved in it
+	readOnly bool
 }
 
 func NewBoltProvider(dbPath string) Provider {
 	return &BoltProvider{dbPath: dbPath}
 }
 
-func (provider *BoltProvider) SaveEntries(entries map[EntryType][]Entry) error {
-	entriesTypes := provider.getEntriesTypesFromEntries(entries)
-	err := provider.saveEntriesTypesToDb(entriesTypes)
-	if err != nil {
-		return err
+func NewReadOnlyBoltProvider(dbPath string) Provider {
+	return &BoltProvider{dbPath: dbPath, readOnly: true}
+}
+
+func (provider *BoltProvider) failIfReadOnly() error {
+	if provider.readOnly {
+		return errors.New("Changes cannot be saved as the database " + provider.dbPath + " has been opened read-only")
 	}
-	for entryType, entries := range entries {
-		err = provider.saveEntriesToDb(entryType.Name+entriesTableSuffix, entries)
+	return nil
+}
+
+func (provider *BoltProvider) SaveEntries(entries map[EntryType][]Entry) error {
+	return provider.update(func(transaction *bolt.Tx) error {
+		return putEntries(transaction, entries)
+	})
+}
+
+//Everything is saved in a single transaction, so the database is left as it was if saving any part fails. Otherwise
+//entries could be saved without their lists, relations or series, which would then refer to entries that don't exist.
+func (provider *BoltProvider) SaveAll(entries map[EntryType][]Entry, lists []EntriesList, relations []Relation, allSeries []Series) error {
+	return provider.update(func(transaction *bolt.Tx) error {
+		err := putEntries(transaction, entries)
 		if err != nil {
 			return err
 		}
-	}
-	return nil
+		err = putLists(transaction, lists)
+		if err != nil {
+			return err
+		}
+		err = putRelations(transaction, relations)
+		if err != nil {
+			return err
+		}
+		return putSeries(transaction, allSeries)
+	})
 }
 
-func (provider *BoltProvider) getEntriesTypesFromEntries(entries map[EntryType][]Entry) []EntryType {
-	var entriesTypes []EntryType
-	for entryType, _ := range entries {
-		entriesTypes = append(entriesTypes, entryType)
+//Makes the changes in a single transaction of the database opened only for the time of making them
+func (provider *BoltProvider) update(change func(transaction *bolt.Tx) error) error {
+	err := provider.failIfReadOnly()
+	if err != nil {
+		return err
+	}
+	err = provider.openDb()
+	if err != nil {
+		return err
 	}
-	return entriesTypes
+	err = provider.db.Update(change)
+	closingErr := provider.closeDb()
+	if err != nil {
+		return err
+	}
+	return closingErr
 }
 
 func (provider *BoltProvider) openDb() error {
-	db, err := bolt.Open(provider.dbPath, 0600, &bolt.Options{Timeout: 10 * time.Second})
+	db, err := bolt.Open(provider.dbPath, 0600, &bolt.Options{Timeout: dbOpeningTimeout, ReadOnly: provider.readOnly})
 	provider.db = db
-	if err != nil {
+	if err == bolt.ErrTimeout {
+		return errors.New("The database " + provider.dbPath + " has not been closed by another process for " +
+			dbOpeningTimeout.String() + ". It's most likely used by another instance of the application.")
+	} else if err != nil {
 		return errors.Wrap(err, "An error occurred when opening the database")
 	}
 	return nil
@@ -60,34 +104,24 @@ func (provider *BoltProvider) closeDb() error {
 	return nil
 }
 
-func (provider *BoltProvider) saveEntriesToDb(table string, entries []Entry) error {
-	err := provider.openDb()
-	if err != nil {
-		return err
-	}
-	defer func() {
-		err = provider.closeDb()
-	}()
-	err = provider.saveEntriesInTable(table, entries)
-	if err != nil {
-		return err
-	}
-	return err
-}
-
-func (provider *BoltProvider) saveEntriesInTable(table string, entries []Entry) error {
-	err := provider.deleteTableIfExists(table)
+//Tables of entry types and of entries of each type are replaced, so entries removed since the last save are removed
+func putEntries(transaction *bolt.Tx, entries map[EntryType][]Entry) error {
+	err := recreateTable(transaction, entriesTypesTableName)
 	if err != nil {
 		return err
 	}
-	if len(entries) == 0 {
-		err = provider.createNewTable(table)
+	for entryType, entriesOfType := range entries {
+		err = putEntryType(transaction, entryType)
 		if err != nil {
 			return err
 		}
-	} else {
-		for _, entry := range entries {
-			err := provider.saveEntryToTable(table, entry)
+		table := entryType.Name + entriesTableSuffix
+		err = recreateTable(transaction, table)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entriesOfType {
+			err = putEntry(transaction, table, entry)
 			if err != nil {
 				return err
 			}
@@ -96,47 +130,33 @@ func (provider *BoltProvider) saveEntriesInTable(table string, entries []Entry)
 	return nil
 }
 
-func (provider *BoltProvider) createNewTable(name string) error {
-	return provider.db.Update(func(transaction *bolt.Tx) error {
-		_, err := transaction.CreateBucketIfNotExists([]byte(name))
+//Table is deleted if it exists and created empty
+func recreateTable(transaction *bolt.Tx, table string) error {
+	if transaction.Bucket([]byte(table)) != nil {
+		err := transaction.DeleteBucket([]byte(table))
 		if err != nil {
-			return errors.Wrap(err, "An error occurred when creating a new table with name "+name)
+			return errors.Wrap(err, "An error occurred when deleting an existing table with name "+table)
 		}
-		return err
-	})
+	}
+	_, err := transaction.CreateBucket([]byte(table))
+	if err != nil {
+		return errors.Wrap(err, "An error occurred when creating a new table with name "+table)
+	}
+	return nil
 }
 
-func (provider *BoltProvider) saveEntryToTable(table string, entry Entry) error {
+func putEntry(transaction *bolt.Tx, table string, entry Entry) error {
 	entryAsJSON, err := json.Marshal(entry)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred when marshaling entry during entry saving to table with name "+table+". Entry to save was: "+entry.String())
 	}
-	err = provider.db.Update(func(transaction *bolt.Tx) error {
-		bucket, err := transaction.CreateBucketIfNotExists([]byte(table))
-		if err != nil {
-			return errors.Wrap(err, "An error occurred when creating bucket for table with name "+table+" during entry saving. Entry to save was: "+entry.String())
-		}
-		return bucket.Put([]byte(strconv.Itoa(entry.Id)), entryAsJSON)
-	})
+	err = transaction.Bucket([]byte(table)).Put([]byte(strconv.Itoa(entry.Id)), entryAsJSON)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred when making update on the database during entry saving for table with name "+table+". Entry to save was:"+entry.String())
 	}
 	return nil
 }
 
-func (provider *BoltProvider) deleteTableIfExists(table string) error {
-	return provider.db.Update(func(transaction *bolt.Tx) error {
-		bucket := transaction.Bucket([]byte(table))
-		if bucket != nil {
-			err := transaction.DeleteBucket([]byte(table))
-			if err != nil {
-				return errors.Wrap(err, "An error occurred when deleting an existing table with name "+table)
-			}
-		}
-		return nil
-	})
-}
-
 func (provider *BoltProvider) LoadEntries() (map[EntryType][]Entry, error) {
 	entriesTypes, err := provider.loadEntriesTypesFromDb()
 	if err != nil {
@@ -190,33 +210,12 @@ func getEntriesDataFromTable(transaction *bolt.Tx, table string) ([]Entry, error
 	return entries, err
 }
 
-func (provider *BoltProvider) saveEntriesTypesToDb(entriesTypes []EntryType) error {
-	err := provider.openDb()
-	if err != nil {
-		return err
-	}
-	err = provider.deleteTableIfExists(entriesTypesTableName)
-	for _, entryType := range entriesTypes {
-		err := provider.saveEntryTypeToTable(entryType)
-		if err != nil {
-			return err
-		}
-	}
-	return provider.db.Close()
-}
-
-func (provider *BoltProvider) saveEntryTypeToTable(entryType EntryType) error {
+func putEntryType(transaction *bolt.Tx, entryType EntryType) error {
 	typeAsJSON, err := json.Marshal(entryType)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred when marshaling entry type during entry type saving")
 	}
-	err = provider.db.Update(func(transaction *bolt.Tx) error {
-		bucket, err := transaction.CreateBucketIfNotExists([]byte(entriesTypesTableName))
-		if err != nil {
-			return errors.Wrap(err, "An error occurred when creating bucket during entry type saving")
-		}
-		return bucket.Put([]byte(entryType.Name), typeAsJSON)
-	})
+	err = transaction.Bucket([]byte(entriesTypesTableName)).Put([]byte(entryType.Name), typeAsJSON)
 	if err != nil {
 		return errors.Wrap(err, "An error occurred when making update on the database during entry type saving")
 	}
@@ -260,3 +259,178 @@ func getEntriesTypesFromTable(transaction *bolt.Tx) ([]EntryType, error) {
 	})
 	return types, err
 }
+
+func (provider *BoltProvider) SaveLists(lists []EntriesList) error {
+	return provider.update(func(transaction *bolt.Tx) error {
+		return putLists(transaction, lists)
+	})
+}
+
+//Lists are stored in a single table as, unlike entries, they only refer to what they contain so they are small
+func putLists(transaction *bolt.Tx, lists []EntriesList) error {
+	err := recreateTable(transaction, listsTableName)
+	if err != nil {
+		return err
+	}
+	for _, list := range lists {
+		listAsJSON, err := json.Marshal(list)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred when marshaling list with name "+list.Name+" during list saving")
+		}
+		err = transaction.Bucket([]byte(listsTableName)).Put([]byte(list.Name), listAsJSON)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred when making update on the database during saving of list with name "+list.Name)
+		}
+	}
+	return nil
+}
+
+func (provider *BoltProvider) LoadLists() ([]EntriesList, error) {
+	err := provider.openDb()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = provider.closeDb()
+	}()
+	lists := []EntriesList{}
+	err = provider.db.View(func(transaction *bolt.Tx) error {
+		bucket := transaction.Bucket([]byte(listsTableName))
+		if bucket == nil {
+			//Databases created before lists were introduced don't have the table
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			var list EntriesList
+			err := json.Unmarshal(value, &list)
+			if err != nil {
+				return errors.Wrap(err, "An error occurred when unmarshalling list with name "+string(key))
+			}
+			lists = append(lists, list)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lists, err
+}
+
+func (provider *BoltProvider) SaveRelations(relations []Relation) error {
+	return provider.update(func(transaction *bolt.Tx) error {
+		return putRelations(transaction, relations)
+	})
+}
+
+//Relations are stored in a single table like lists, each under the key of its position, so they keep their order
+func putRelations(transaction *bolt.Tx, relations []Relation) error {
+	err := recreateTable(transaction, relationsTableName)
+	if err != nil {
+		return err
+	}
+	for i, relation := range relations {
+		relationAsJSON, err := json.Marshal(relation)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred when marshaling a relation during relations saving")
+		}
+		err = transaction.Bucket([]byte(relationsTableName)).Put(relationKey(i), relationAsJSON)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred when making update on the database during saving of relations")
+		}
+	}
+	return nil
+}
+
+//Keys are compared as bytes so they are big-endian numbers to be sorted by positions
+func relationKey(position int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(position))
+	return key
+}
+
+func (provider *BoltProvider) LoadRelations() ([]Relation, error) {
+	err := provider.openDb()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = provider.closeDb()
+	}()
+	relations := []Relation{}
+	err = provider.db.View(func(transaction *bolt.Tx) error {
+		bucket := transaction.Bucket([]byte(relationsTableName))
+		if bucket == nil {
+			//Databases created before relations were introduced don't have the table
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			var relation Relation
+			err := json.Unmarshal(value, &relation)
+			if err != nil {
+				return errors.Wrap(err, "An error occurred when unmarshalling a relation")
+			}
+			relations = append(relations, relation)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return relations, err
+}
+
+func (provider *BoltProvider) SaveSeries(allSeries []Series) error {
+	return provider.update(func(transaction *bolt.Tx) error {
+		return putSeries(transaction, allSeries)
+	})
+}
+
+//Series are stored in a single table like lists, each under its name
+func putSeries(transaction *bolt.Tx, allSeries []Series) error {
+	err := recreateTable(transaction, seriesTableName)
+	if err != nil {
+		return err
+	}
+	for _, series := range allSeries {
+		seriesAsJSON, err := json.Marshal(series)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred when marshaling series with name "+series.Name+" during series saving")
+		}
+		err = transaction.Bucket([]byte(seriesTableName)).Put([]byte(series.Name), seriesAsJSON)
+		if err != nil {
+			return errors.Wrap(err, "An error occurred when making update on the database during saving of series with name "+series.Name)
+		}
+	}
+	return nil
+}
+
+func (provider *BoltProvider) LoadSeries() ([]Series, error) {
+	err := provider.openDb()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = provider.closeDb()
+	}()
+	allSeries := []Series{}
+	err = provider.db.View(func(transaction *bolt.Tx) error {
+		bucket := transaction.Bucket([]byte(seriesTableName))
+		if bucket == nil {
+			//Databases created before series were introduced don't have the table
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			var series Series
+			err := json.Unmarshal(value, &series)
+			if err != nil {
+				return errors.Wrap(err, "An error occurred when unmarshalling series with name "+string(key))
+			}
+			allSeries = append(allSeries, series)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allSeries, err
+}
@@ -61,3 +61,39 @@ func TestThatWhenSavingEntriesPreviousDataInDbIsRemoved(t *testing.T) {
 	loadedEntries, err := dataProvider.LoadEntries()
 	assert.Empty(t, loadedEntries)
 }
+
+func TestThatReadOnlyDbCanBeLoadedButNotSaved(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	err := NewBoltProvider(testDbPath).SaveEntries(GetTestEntries())
+	if err != nil {
+		log.Fatal(err)
+	}
+	dataProvider := NewReadOnlyBoltProvider(testDbPath)
+	loadedEntries, err := dataProvider.LoadEntries()
+	assert.Nil(t, err)
+	assert.Equal(t, GetTestEntries(), loadedEntries)
+	err = dataProvider.SaveEntries(map[EntryType][]Entry{})
+	assert.EqualError(t, err, "Changes cannot be saved as the database "+testDbPath+" has been opened read-only")
+	err = dataProvider.SaveLists([]EntriesList{})
+	assert.NotNil(t, err)
+	loadedEntries, _ = NewBoltProvider(testDbPath).LoadEntries()
+	assert.Equal(t, GetTestEntries(), loadedEntries)
+}
+
+func TestThatNothingIsSavedWhenSavingAnyPartOfDataFails(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	dataProvider := NewBoltProvider(testDbPath)
+	lists := []EntriesList{{Name: "favourites", Items: []ListItem{{"comics", 0}}}}
+	relations := []Relation{{SequelRelation, "comics", 0, "comics", 1}}
+	assert.Nil(t, dataProvider.SaveAll(GetTestEntries(), lists, relations, []Series{}))
+	err := dataProvider.SaveAll(map[EntryType][]Entry{}, []EntriesList{}, []Relation{}, []Series{{Name: ""}})
+	assert.NotNil(t, err)
+	loadedEntries, _ := dataProvider.LoadEntries()
+	assert.Equal(t, GetTestEntries(), loadedEntries)
+	loadedLists, _ := dataProvider.LoadLists()
+	assert.Equal(t, lists, loadedLists)
+	loadedRelations, _ := dataProvider.LoadRelations()
+	assert.Equal(t, relations, loadedRelations)
+}
@@ -0,0 +1,91 @@
+package data
+
+/*
+Listeners subscribed to changes of an entries container are notified about every change with an event describing
+what has changed, including the values from before and after the change, so they can react only to what has changed.
+*/
+
+type ChangeKind string
+
+const (
+	EntryTypeAddedChange   ChangeKind = "ENTRY_TYPE_ADDED"
+	EntryTypeUpdatedChange ChangeKind = "ENTRY_TYPE_UPDATED"
+	EntryTypeRenamedChange ChangeKind = "ENTRY_TYPE_RENAMED"
+	EntryTypeDeletedChange ChangeKind = "ENTRY_TYPE_DELETED"
+	EntryAddedChange       ChangeKind = "ENTRY_ADDED"
+	EntryUpdatedChange     ChangeKind = "ENTRY_UPDATED"
+	EntryDeletedChange     ChangeKind = "ENTRY_DELETED"
+	EntryMovedChange       ChangeKind = "ENTRY_MOVED"
+	ListCreatedChange      ChangeKind = "LIST_CREATED"
+	ListUpdatedChange      ChangeKind = "LIST_UPDATED"
+	ListDeletedChange      ChangeKind = "LIST_DELETED"
+	RelationAddedChange    ChangeKind = "RELATION_ADDED"
+	RelationRemovedChange  ChangeKind = "RELATION_REMOVED"
+	SeriesCreatedChange    ChangeKind = "SERIES_CREATED"
+	SeriesUpdatedChange    ChangeKind = "SERIES_UPDATED"
+	SeriesDeletedChange    ChangeKind = "SERIES_DELETED"
+)
+
+//Values that don't exist before or after the change are nil, e.g. TypeBefore of an added type.
+//Changes of entries have both types set to the type the entry belongs to, unless the entry gets moved to another type.
+//Changes of lists, relations and series have only the lists, relations or series set. Relations removed along with
+//their entries are a part of the entries' change, so there is no separate event about them.
+type ChangeEvent struct {
+	Kind           ChangeKind
+	TypeBefore     *EntryType
+	TypeAfter      *EntryType
+	EntryBefore    *Entry
+	EntryAfter     *Entry
+	ListBefore     *EntriesList
+	ListAfter      *EntriesList
+	RelationBefore *Relation
+	RelationAfter  *Relation
+	SeriesBefore   *Series
+	SeriesAfter    *Series
+}
+
+//Identifies a subscription to changes so it can be cancelled
+type SubscriptionId int
+
+type changeListener struct {
+	id       SubscriptionId
+	callback func(ChangeEvent)
+}
+
+func entryTypeChangeEvent(typeBefore EntryType, typeAfter EntryType) ChangeEvent {
+	kind := EntryTypeUpdatedChange
+	if typeBefore.Name != typeAfter.Name {
+		kind = EntryTypeRenamedChange
+	}
+	return ChangeEvent{Kind: kind, TypeBefore: &typeBefore, TypeAfter: &typeAfter}
+}
+
+func entryChangeEvent(kind ChangeKind, entryType EntryType, entryBefore *Entry, entryAfter *Entry) ChangeEvent {
+	return ChangeEvent{Kind: kind, TypeBefore: &entryType, TypeAfter: &entryType, EntryBefore: entryBefore, EntryAfter: entryAfter}
+}
+
+//Returns the id of the subscription which can be used to unsubscribe. Listeners are notified in the order they subscribed.
+func (container *EntriesContainer) SubscribeToChanges(callback func(ChangeEvent)) SubscriptionId {
+	container.lastSubscriptionId++
+	container.changeListeners = append(container.changeListeners, changeListener{container.lastSubscriptionId, callback})
+	return container.lastSubscriptionId
+}
+
+//Nothing happens if there is no subscription with the given id, e.g. when it has already been cancelled
+func (container *EntriesContainer) UnsubscribeFromChanges(id SubscriptionId) {
+	for i, listener := range container.changeListeners {
+		if listener.id == id {
+			container.changeListeners = append(container.changeListeners[:i:i], container.changeListeners[i+1:]...)
+			return
+		}
+	}
+}
+
+//Every change is followed by a notification so it's also where the container gets marked as having unsaved changes.
+//Listeners can unsubscribe while being notified, so the ones notified are those subscribed when the change was made.
+func (container *EntriesContainer) notifyListenersAboutChange(event ChangeEvent) {
+	container.unsavedChanges = true
+	for _, listener := range container.changeListeners {
+		listener.callback(event)
+	}
+}
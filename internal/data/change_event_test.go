@@ -0,0 +1,63 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatListenersAreNotifiedAboutChangesWithValuesFromBeforeAndAfterThem(t *testing.T) {
+	container := createLoadedTestContainer()
+	events := []ChangeEvent{}
+	container.SubscribeToChanges(func(event ChangeEvent) { events = append(events, event) })
+	booksType := EntryType{Name: "books"}
+	renamedBooksType := EntryType{Name: "novels"}
+	updatedNovelsType := EntryType{Name: "novels", ImageQuery: "novel"}
+	assert.Nil(t, container.AddEntryType(booksType))
+	assert.Nil(t, container.UpdateEntryType("books", renamedBooksType))
+	assert.Nil(t, container.UpdateEntryType("novels", updatedNovelsType))
+	assert.Nil(t, container.DeleteEntryType("novels"))
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, 1))
+	expectedEvents := []ChangeEvent{
+		{Kind: EntryTypeAddedChange, TypeAfter: &booksType},
+		{Kind: EntryTypeRenamedChange, TypeBefore: &booksType, TypeAfter: &renamedBooksType},
+		{Kind: EntryTypeUpdatedChange, TypeBefore: &renamedBooksType, TypeAfter: &updatedNovelsType},
+		{Kind: EntryTypeDeletedChange, TypeBefore: &updatedNovelsType},
+	}
+	assert.Equal(t, expectedEvents, events[:4])
+	progressEvent := events[4]
+	assert.Equal(t, EntryUpdatedChange, progressEvent.Kind)
+	assert.Equal(t, comicsEntryType, *progressEvent.TypeBefore)
+	assert.Equal(t, comicsEntryType, *progressEvent.TypeAfter)
+	assert.Equal(t, 1, progressEvent.EntryBefore.ElementsCompleted)
+	assert.Equal(t, 2, progressEvent.EntryAfter.ElementsCompleted)
+	assert.Equal(t, progressEvent.EntryBefore.Id, progressEvent.EntryAfter.Id)
+}
+
+func TestThatUnsubscribedListenerIsNoLongerNotified(t *testing.T) {
+	container := createLoadedTestContainer()
+	notifications1, notifications2 := 0, 0
+	id1 := container.SubscribeToChanges(func(ChangeEvent) { notifications1++ })
+	id2 := container.SubscribeToChanges(func(ChangeEvent) { notifications2++ })
+	assert.NotEqual(t, id1, id2)
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	container.UnsubscribeFromChanges(id1)
+	container.UnsubscribeFromChanges(id1)
+	assert.Nil(t, container.DeleteEntryType("books"))
+	assert.Equal(t, 1, notifications1)
+	assert.Equal(t, 2, notifications2)
+}
+
+func TestThatListenerCanUnsubscribeWhileBeingNotified(t *testing.T) {
+	container := createLoadedTestContainer()
+	notifications1, notifications2 := 0, 0
+	var id SubscriptionId
+	id = container.SubscribeToChanges(func(ChangeEvent) {
+		notifications1++
+		container.UnsubscribeFromChanges(id)
+	})
+	container.SubscribeToChanges(func(ChangeEvent) { notifications2++ })
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	assert.Nil(t, container.DeleteEntryType("books"))
+	assert.Equal(t, 1, notifications1)
+	assert.Equal(t, 2, notifications2)
+}
@@ -0,0 +1,89 @@
+package data
+
+import (
+	"strconv"
+	"time"
+)
+
+/*
+Every entry has a history of its consumption, as a single pair of start and finish dates can't tell when its elements
+have been completed or that it has been consumed again. Events are appended to the history automatically whenever
+the progress or status of the entry changes and when it gets rewatched, i.e. consumed again after being completed.
+*/
+
+type ConsumptionEventKind string
+
+const (
+	ProgressChangedEvent ConsumptionEventKind = "Progress changed"
+	StatusChangedEvent   ConsumptionEventKind = "Status changed"
+	RewatchStartedEvent  ConsumptionEventKind = "Rewatch started"
+	RewatchFinishedEvent ConsumptionEventKind = "Rewatch finished"
+)
+
+//Layout of dates of consumption events, which unlike dates of entries include time, e.g. 31/12/2020 23:59
+const EventDateLayout = "02/01/2006 15:04"
+
+//From and To are the values from before and after the event, e.g. amounts of completed elements, and are empty for rewatches
+type ConsumptionEvent struct {
+	Kind ConsumptionEventKind
+	Date time.Time
+	From string `json:",omitempty"`
+	To   string `json:",omitempty"`
+}
+
+func (event ConsumptionEvent) String() string {
+	return event.Date.Local().Format(EventDateLayout) + " " + event.Description()
+}
+
+//Describes the event without its date
+func (event ConsumptionEvent) Description() string {
+	if event.From == "" && event.To == "" {
+		return string(event.Kind)
+	}
+	return string(event.Kind) + " from '" + event.From + "' to '" + event.To + "'"
+}
+
+//Entry is being rewatched from the moment a rewatch is started until it's finished by completing the entry again
+func (entry Entry) IsBeingRewatched() bool {
+	for i := len(entry.ConsumptionHistory) - 1; i >= 0; i-- {
+		switch entry.ConsumptionHistory[i].Kind {
+		case RewatchStartedEvent:
+			return true
+		case RewatchFinishedEvent:
+			return false
+		}
+	}
+	return false
+}
+
+//Returns a copy of the entry with its progress reset and marked as in progress, so it can be consumed again.
+//Start and finish dates stay the same as they are the dates of the first time the entry was consumed.
+func (entry Entry) WithRewatchStarted(entryType EntryType, date time.Time) Entry {
+	entry = entry.withEventsOfChangesTo(entryType, entry.withProgressAndStatus(0, entryType.StatusIn(InProgressStatus)), date)
+	entry.ConsumptionHistory = append(entry.ConsumptionHistory, ConsumptionEvent{Kind: RewatchStartedEvent, Date: date})
+	return entry
+}
+
+func (entry Entry) withProgressAndStatus(elementsCompleted int, status EntryStatus) Entry {
+	entry.ElementsCompleted = elementsCompleted
+	entry.Status = status
+	return entry
+}
+
+//Returns the changed entry with events describing how its progress and status differ from the entry appended to
+//the history, finishing the rewatch if the entry has been completed while being rewatched
+func (entry Entry) withEventsOfChangesTo(entryType EntryType, changedEntry Entry, date time.Time) Entry {
+	//History is copied when appended to so the history of the original entry stays the same
+	history := entry.ConsumptionHistory[:len(entry.ConsumptionHistory):len(entry.ConsumptionHistory)]
+	if entry.ElementsCompleted != changedEntry.ElementsCompleted {
+		history = append(history, ConsumptionEvent{ProgressChangedEvent, date, strconv.Itoa(entry.ElementsCompleted), strconv.Itoa(changedEntry.ElementsCompleted)})
+	}
+	if entry.Status != changedEntry.Status {
+		history = append(history, ConsumptionEvent{StatusChangedEvent, date, string(entry.Status), string(changedEntry.Status)})
+		if entryType.CategoryOf(changedEntry.Status) == CompletedStatus && entry.IsBeingRewatched() {
+			history = append(history, ConsumptionEvent{Kind: RewatchFinishedEvent, Date: date})
+		}
+	}
+	changedEntry.ConsumptionHistory = history
+	return changedEntry
+}
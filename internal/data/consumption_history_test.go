@@ -0,0 +1,79 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var rewatchDate = time.Date(2020, time.April, 1, 20, 30, 0, 0, time.UTC)
+
+func TestThatChangingProgressIsRecordedInHistory(t *testing.T) {
+	entry := Entry{Status: InProgressStatus, ElementsCompleted: 4, TotalAmountOfElementsToComplete: 5}
+	changedEntry := entry.WithProgressChangedBy(EntryType{}, 1, progressChangeDate)
+	assert.Equal(t, []ConsumptionEvent{
+		{ProgressChangedEvent, progressChangeDate, "4", "5"},
+		{StatusChangedEvent, progressChangeDate, "In progress", "Completed"},
+	}, changedEntry.ConsumptionHistory)
+	assert.Empty(t, entry.ConsumptionHistory)
+	assert.Equal(t, 2, len(changedEntry.WithProgressChangedBy(EntryType{}, 10, progressChangeDate).ConsumptionHistory))
+}
+
+func TestThatRewatchIsRecordedFromItsStartUntilEntryIsCompletedAgain(t *testing.T) {
+	entry := Entry{Status: CompletedStatus, ElementsCompleted: 2, TotalAmountOfElementsToComplete: 2, StartDate: "01/01/2020", FinishDate: "02/01/2020"}
+	rewatchedEntry := entry.WithRewatchStarted(EntryType{}, rewatchDate)
+	assert.True(t, rewatchedEntry.IsBeingRewatched())
+	assert.Equal(t, 0, rewatchedEntry.ElementsCompleted)
+	assert.Equal(t, InProgressStatus, rewatchedEntry.Status)
+	assert.Equal(t, RewatchStartedEvent, rewatchedEntry.ConsumptionHistory[2].Kind)
+	rewatchedEntry = rewatchedEntry.WithProgressChangedBy(EntryType{}, 1, rewatchDate).WithProgressChangedBy(EntryType{}, 1, rewatchDate)
+	assert.False(t, rewatchedEntry.IsBeingRewatched())
+	assert.Equal(t, RewatchFinishedEvent, rewatchedEntry.ConsumptionHistory[len(rewatchedEntry.ConsumptionHistory)-1].Kind)
+	assert.Equal(t, "01/01/2020", rewatchedEntry.StartDate)
+	assert.Equal(t, "02/01/2020", rewatchedEntry.FinishDate)
+}
+
+func TestThatConsumptionEventsAreDescribedWithTheirDates(t *testing.T) {
+	event := ConsumptionEvent{ProgressChangedEvent, rewatchDate, "1", "2"}
+	expectedDate := rewatchDate.Local().Format(EventDateLayout)
+	assert.Equal(t, expectedDate+" Progress changed from '1' to '2'", event.String())
+	assert.Equal(t, expectedDate+" Rewatch started", ConsumptionEvent{Kind: RewatchStartedEvent, Date: rewatchDate}.String())
+}
+
+func TestThatOnlyCompletedEntryCanBeRewatched(t *testing.T) {
+	container := createLoadedTestContainer()
+	err := container.StartRewatch("comics", 0)
+	assert.Equal(t, "Cannot start rewatching entry 'some comic1' as it has not been completed", err.Error())
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, 1))
+	assert.Nil(t, container.StartRewatch("comics", 0))
+	assert.True(t, container.entries[comicsEntryType][0].IsBeingRewatched())
+	_, err = container.Undo()
+	assert.Nil(t, err)
+	assert.False(t, container.entries[comicsEntryType][0].IsBeingRewatched())
+}
+
+func TestThatEditingProgressOrStatusOfEntryIsRecordedInHistory(t *testing.T) {
+	container := createLoadedTestContainer()
+	entry := container.entries[comicsEntryType][1]
+	entry.Status = DroppedStatus
+	entry.Title = "changed title"
+	assert.Nil(t, container.UpdateEntry("comics", entry))
+	history := container.entries[comicsEntryType][1].ConsumptionHistory
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, StatusChangedEvent, history[0].Kind)
+	assert.Equal(t, string(DroppedStatus), history[0].To)
+}
+
+func TestThatConsumptionHistoryIsSavedAndLoaded(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	entries := GetTestEntries()
+	entries[comicsEntryType][0] = entries[comicsEntryType][0].WithProgressChangedBy(EntryType{}, 1, progressChangeDate).WithRewatchStarted(EntryType{}, rewatchDate)
+	dataProvider := NewBoltProvider(testDbPath)
+	err := dataProvider.SaveEntries(entries)
+	assert.Nil(t, err)
+	loadedEntries, err := dataProvider.LoadEntries()
+	assert.Nil(t, err)
+	assert.Equal(t, entries[comicsEntryType][0].ConsumptionHistory, loadedEntries[comicsEntryType][0].ConsumptionHistory)
+	assert.True(t, loadedEntries[comicsEntryType][0].IsBeingRewatched())
+}
@@ -0,0 +1,341 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/*
+Entries imported or added more than once are found as pairs of entries that are likely to be the same work, as their
+titles are similar or their links are the same. Entries don't keep ids of external catalogs other than their links,
+which enrichment fills in from the catalogs, so the same link also means the same id in a catalog. Such entries can be
+merged into one of them, which keeps its own values and takes the missing ones, tags and history from the other one.
+The merged entry is deleted, but unlike a deleted entry it is replaced by the kept entry in its lists, series and relations.
+*/
+type DuplicateReason string
+
+const (
+	SimilarTitlesReason DuplicateReason = "similar titles"
+	SameLinkReason      DuplicateReason = "same link"
+)
+
+//Titles are similar when at most this part of their normalized forms has to be changed to make them equal
+const maxTitlesDifference = 0.15
+
+type DuplicatePair struct {
+	TypeName      string
+	Entry         Entry
+	OtherTypeName string
+	OtherEntry    Entry
+	Reasons       []DuplicateReason
+}
+
+//Returns pairs of entries of any entry types that are likely to be duplicates, ordered by names of the types and
+//positions of the entries in them
+func (container *EntriesContainer) DuplicateCandidates() []DuplicatePair {
+	type typedEntry struct {
+		typeName string
+		entry    Entry
+	}
+	typesNames := []string{}
+	for entryType := range container.entries {
+		typesNames = append(typesNames, entryType.Name)
+	}
+	sort.Strings(typesNames)
+	allEntries := []typedEntry{}
+	for _, typeName := range typesNames {
+		entryType, _ := container.EntryTypeWithName(typeName)
+		for _, entry := range container.entries[entryType] {
+			allEntries = append(allEntries, typedEntry{typeName, entry})
+		}
+	}
+	pairs := []DuplicatePair{}
+	for i, first := range allEntries {
+		for _, second := range allEntries[i+1:] {
+			reasons := duplicateReasons(first.entry, second.entry)
+			if len(reasons) > 0 {
+				pairs = append(pairs, DuplicatePair{first.typeName, first.entry, second.typeName, second.entry, reasons})
+			}
+		}
+	}
+	return pairs
+}
+
+func duplicateReasons(entry Entry, otherEntry Entry) []DuplicateReason {
+	reasons := []DuplicateReason{}
+	if areTitlesSimilar(entry.Title, otherEntry.Title) {
+		reasons = append(reasons, SimilarTitlesReason)
+	}
+	if link := normalizedLink(entry.Link); link != "" && link == normalizedLink(otherEntry.Link) {
+		reasons = append(reasons, SameLinkReason)
+	}
+	return reasons
+}
+
+//Titles with different numbers are never similar, as they are usually different parts of the same work, e.g. volumes
+func areTitlesSimilar(title string, otherTitle string) bool {
+	normalized, otherNormalized := []rune(normalizedTitle(title)), []rune(normalizedTitle(otherTitle))
+	if len(normalized) == 0 || len(otherNormalized) == 0 || numbersIn(normalized) != numbersIn(otherNormalized) {
+		return false
+	}
+	longerLength := len(normalized)
+	if len(otherNormalized) > longerLength {
+		longerLength = len(otherNormalized)
+	}
+	lengthsDifference := len(normalized) - len(otherNormalized)
+	if lengthsDifference < 0 {
+		lengthsDifference = -lengthsDifference
+	}
+	if float64(lengthsDifference)/float64(longerLength) > maxTitlesDifference {
+		return false
+	}
+	return float64(editDistance(normalized, otherNormalized))/float64(longerLength) <= maxTitlesDifference
+}
+
+//Title is compared ignoring case, punctuation and whitespace between its words, e.g. 'Spirited away!' is 'spirited away'
+func normalizedTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func numbersIn(text []rune) string {
+	return strings.Join(strings.FieldsFunc(string(text), func(r rune) bool { return !unicode.IsDigit(r) }), " ")
+}
+
+//Amount of runes that have to be inserted, deleted or replaced to change the text into the other text
+func editDistance(text []rune, otherText []rune) int {
+	previousRow := make([]int, len(otherText)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+	for i := 1; i <= len(text); i++ {
+		row := make([]int, len(otherText)+1)
+		row[0] = i
+		for j := 1; j <= len(otherText); j++ {
+			replacementCost := 1
+			if text[i-1] == otherText[j-1] {
+				replacementCost = 0
+			}
+			row[j] = minOf(previousRow[j]+1, row[j-1]+1, previousRow[j-1]+replacementCost)
+		}
+		previousRow = row
+	}
+	return previousRow[len(otherText)]
+}
+
+func minOf(first int, others ...int) int {
+	min := first
+	for _, value := range others {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
+
+//Links are compared ignoring their scheme, 'www.' and trailing slashes and fragments, e.g. 'https://www.a.com/b/' is 'a.com/b'
+func normalizedLink(link string) string {
+	link = strings.ToLower(strings.TrimSpace(link))
+	if fragmentIndex := strings.Index(link, "#"); fragmentIndex >= 0 {
+		link = link[:fragmentIndex]
+	}
+	for _, prefix := range []string{"https://", "http://", "www."} {
+		link = strings.TrimPrefix(link, prefix)
+	}
+	return strings.TrimRight(link, "/")
+}
+
+//Kept entry keeps all of its values except the empty ones, which are taken from the other entry. It also takes the
+//other entry's tags and consumption history, its progress if it's greater and its dates if they are earlier for the
+//start or later for the finish. Score is converted to the scoring system of the kept entry's type, see Entry.migratedTo.
+func (entry Entry) mergedWith(otherEntry Entry, entryType EntryType, otherEntryType EntryType) Entry {
+	if otherEntry.ElementsCompleted > entry.ElementsCompleted {
+		entry.ElementsCompleted = otherEntry.ElementsCompleted
+	}
+	if entry.TotalAmountOfElementsToComplete == 0 {
+		entry.TotalAmountOfElementsToComplete = otherEntry.TotalAmountOfElementsToComplete
+	}
+	if entry.Score == 0 {
+		entry.Score = entryType.Scoring().convertScoreFrom(otherEntryType.Scoring(), otherEntry.Score)
+	}
+	entry.StartDate = chosenDate(entry.StartDate, otherEntry.StartDate, func(date time.Time, otherDate time.Time) bool { return otherDate.Before(date) })
+	entry.FinishDate = chosenDate(entry.FinishDate, otherEntry.FinishDate, func(date time.Time, otherDate time.Time) bool { return otherDate.After(date) })
+	for _, field := range []struct{ value, otherValue *string }{
+		{&entry.Link, &otherEntry.Link},
+		{&entry.Description, &otherEntry.Description},
+		{&entry.Comment, &otherEntry.Comment},
+		{&entry.ImageQuery, &otherEntry.ImageQuery},
+		{&entry.Cover, &otherEntry.Cover},
+	} {
+		if *field.value == "" {
+			*field.value = *field.otherValue
+		}
+	}
+	entry.Tags = strings.Join(mergedTags(entry.TagsList(), otherEntry.TagsList()), ", ")
+	entry.ConsumptionHistory = append(append([]ConsumptionEvent{}, entry.ConsumptionHistory...), otherEntry.ConsumptionHistory...)
+	sort.SliceStable(entry.ConsumptionHistory, func(i, j int) bool {
+		return entry.ConsumptionHistory[i].Date.Before(entry.ConsumptionHistory[j].Date)
+	})
+	return entry
+}
+
+//Other date is chosen if the date is empty, or if both dates are valid and the other one is preferred
+func chosenDate(date string, otherDate string, isOtherDatePreferred func(date time.Time, otherDate time.Time) bool) string {
+	if date == "" {
+		return otherDate
+	}
+	parsedDate, err := time.Parse(DateLayout, date)
+	parsedOtherDate, otherErr := time.Parse(DateLayout, otherDate)
+	if err == nil && otherErr == nil && isOtherDatePreferred(parsedDate, parsedOtherDate) {
+		return otherDate
+	}
+	return date
+}
+
+func mergedTags(tags []string, otherTags []string) []string {
+	merged := append([]string{}, tags...)
+	for _, otherTag := range otherTags {
+		isNew := true
+		for _, tag := range merged {
+			if strings.EqualFold(tag, otherTag) {
+				isNew = false
+			}
+		}
+		if isNew {
+			merged = append(merged, otherTag)
+		}
+	}
+	return merged
+}
+
+//Merged entry gets deleted after its values are merged into the kept entry, see Entry.mergedWith. Its lists, series and
+//relations are moved to the kept entry, except for the ones the kept entry already has.
+func (container *EntriesContainer) MergeEntries(typeName string, entryId int, mergedTypeName string, mergedEntryId int) error {
+	entry, _ := container.entryWithId(typeName, entryId)
+	entry.Id = entryId
+	mergedEntry, exists := container.entryWithId(mergedTypeName, mergedEntryId)
+	if exists {
+		entryType, _ := container.EntryTypeWithName(typeName)
+		mergedType, _ := container.EntryTypeWithName(mergedTypeName)
+		entry = entry.mergedWith(mergedEntry, entryType, mergedType)
+	}
+	record := JournalRecord{Operation: MergeEntriesOperation, TypeName: typeName, Entry: &entry, MergedTypeName: mergedTypeName, MergedEntryId: mergedEntryId}
+	return container.execute(record, "merging "+container.describeEntry(mergedTypeName, mergedEntryId)+" into "+container.describeEntry(typeName, entryId))
+}
+
+func (container *EntriesContainer) mergeEntries(record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(record.TypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot merge entries into entry type '" + record.TypeName + "' as no such type exists")
+	}
+	entryNum, exists := entryNumIn(container.entries[entryType], record.Entry.Id)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot merge entries into entry with id " + strconv.Itoa(record.Entry.Id) + " in entry type '" + record.TypeName + "' as no such entry exists")
+	}
+	if record.TypeName == record.MergedTypeName && record.Entry.Id == record.MergedEntryId {
+		return JournalRecord{}, errors.New("Entry '" + container.entries[entryType][entryNum].Title + "' cannot be merged into itself")
+	}
+	mergedType, _ := container.EntryTypeWithName(record.MergedTypeName)
+	mergedEntryNum, exists := entryNumIn(container.entries[mergedType], record.MergedEntryId)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot merge entry with id " + strconv.Itoa(record.MergedEntryId) + " of entry type '" + record.MergedTypeName + "' as no such entry exists")
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	mergedEntries := container.entries[mergedType]
+	mergedEntry := mergedEntries[mergedEntryNum]
+	container.entries[mergedType] = append(append([]Entry{}, mergedEntries[:mergedEntryNum]...), mergedEntries[mergedEntryNum+1:]...)
+	relations, lists, series := container.moveMergedEntry(ListItem{record.MergedTypeName, record.MergedEntryId}, ListItem{record.TypeName, record.Entry.Id})
+	container.notifyListenersAboutChange(entryChangeEvent(EntryDeletedChange, mergedType, &mergedEntry, nil))
+	//Kept entry is looked up again, as it moves when the merged entry of the same type was before it
+	entryNum, _ = entryNumIn(container.entries[entryType], record.Entry.Id)
+	entry, changedEntry := container.entries[entryType][entryNum], *record.Entry
+	container.entries[entryType][entryNum] = changedEntry
+	container.notifyListenersAboutChange(entryChangeEvent(EntryUpdatedChange, entryType, &entry, &changedEntry))
+	return JournalRecord{Operation: UnmergeEntriesOperation, TypeName: record.TypeName, Entry: &entry, MergedTypeName: record.MergedTypeName,
+		Entries: []Entry{mergedEntry}, Position: mergedEntryNum, Relations: relations, Lists: lists, Series: series}, nil
+}
+
+//Kept entry takes the place of the merged entry in its lists, unless it's already in them, and in its series, unless
+//it's already a member of a series. Relations are moved the same way, except for the ones between the two entries.
+//Returns all relations, the changed lists and the changed series as they were before, to restore them on unmerging.
+func (container *EntriesContainer) moveMergedEntry(mergedEntry ListItem, keptEntry ListItem) ([]Relation, []EntriesList, *Series) {
+	relations := append([]Relation{}, container.relations...)
+	lists := []EntriesList{}
+	for _, list := range container.lists {
+		if _, exists := list.PositionOf(mergedEntry.TypeName, mergedEntry.EntryId); exists {
+			lists = append(lists, list.copy())
+		}
+	}
+	movedEntries := map[ListItem]ListItem{mergedEntry: keptEntry}
+	container.moveEntriesInLists(movedEntries)
+	container.moveEntriesInRelations(movedEntries)
+	series, isMember := container.SeriesOf(mergedEntry.TypeName, mergedEntry.EntryId)
+	if !isMember {
+		return relations, lists, nil
+	}
+	if _, isKeptEntryMember := container.SeriesOf(keptEntry.TypeName, keptEntry.EntryId); isKeptEntryMember {
+		container.removeSeriesMembersOfEntries(func(typeName string, entryId int) bool {
+			return typeName == mergedEntry.TypeName && entryId == mergedEntry.EntryId
+		})
+	} else {
+		container.moveEntriesInSeries(movedEntries)
+	}
+	return relations, lists, &series
+}
+
+//Reverts merging by restoring the kept entry to what it was before and the merged entry to where it was, with its
+//relations, lists and series
+func (container *EntriesContainer) unmergeEntries(record JournalRecord) (JournalRecord, error) {
+	entryType, _ := container.EntryTypeWithName(record.TypeName)
+	entryNum, exists := entryNumIn(container.entries[entryType], record.Entry.Id)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot unmerge entries of entry with id " + strconv.Itoa(record.Entry.Id) + " in entry type '" + record.TypeName + "' as no such entry exists")
+	}
+	mergedType, err := container.EntryTypeWithName(record.MergedTypeName)
+	if err != nil || len(record.Entries) != 1 {
+		return JournalRecord{}, errors.New("Cannot unmerge entry from entry type '" + record.MergedTypeName + "' as no such type exists")
+	}
+	mergedEntry := record.Entries[0]
+	if _, exists := entryNumIn(container.entries[mergedType], mergedEntry.Id); exists {
+		return JournalRecord{}, errors.New("Cannot unmerge entry with id " + strconv.Itoa(mergedEntry.Id) + " to entry type '" + record.MergedTypeName + "' as its id is already used")
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	entry, changedEntry := container.entries[entryType][entryNum], *record.Entry
+	container.entries[entryType][entryNum] = changedEntry
+	container.notifyListenersAboutChange(entryChangeEvent(EntryUpdatedChange, entryType, &entry, &changedEntry))
+	mergedEntries := container.entries[mergedType]
+	position := record.Position
+	if position < 0 || position > len(mergedEntries) {
+		position = len(mergedEntries)
+	}
+	container.entries[mergedType] = append(append(append([]Entry{}, mergedEntries[:position]...), mergedEntry), mergedEntries[position:]...)
+	container.relations = append([]Relation{}, record.Relations...)
+	container.restoreLists(record.Lists)
+	if record.Series != nil {
+		container.restoreSeries(*record.Series)
+	}
+	container.notifyListenersAboutChange(entryChangeEvent(EntryAddedChange, mergedType, nil, &mergedEntry))
+	return JournalRecord{Operation: MergeEntriesOperation, TypeName: record.TypeName, Entry: &entry, MergedTypeName: record.MergedTypeName,
+		MergedEntryId: mergedEntry.Id}, nil
+}
+
+func entryNumIn(entries []Entry, entryId int) (int, bool) {
+	for i, entry := range entries {
+		if entry.Id == entryId {
+			return i, true
+		}
+	}
+	return 0, false
+}
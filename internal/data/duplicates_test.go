@@ -0,0 +1,147 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestThatEntriesWithSimilarTitlesOrSameLinksAreFoundAsDuplicates(t *testing.T) {
+	container := createLoadedTestContainer()
+	pairs := container.DuplicateCandidates()
+	assert.Equal(t, 6, len(pairs))
+	assert.Equal(t, "comics", pairs[0].TypeName)
+	assert.Equal(t, "some comic1", pairs[0].Entry.Title)
+	assert.Equal(t, "music", pairs[0].OtherTypeName)
+	assert.Equal(t, "some music1", pairs[0].OtherEntry.Title)
+	assert.Equal(t, []DuplicateReason{SameLinkReason}, pairs[0].Reasons)
+	_, err := container.AddEntry("comics", Entry{Title: "Some  Comic-2!", Status: PlannedStatus, Link: "https://www.comics.com/2/"})
+	assert.Nil(t, err)
+	_, err = container.AddEntry("videos", Entry{Title: "Anime", Status: PlannedStatus, Link: "http://comics.com/2#reviews"})
+	assert.Nil(t, err)
+	pairs = container.DuplicateCandidates()
+	assert.Equal(t, 8, len(pairs))
+	assert.Equal(t, "some comic2", pairs[2].Entry.Title)
+	assert.Equal(t, "Some  Comic-2!", pairs[2].OtherEntry.Title)
+	assert.Equal(t, []DuplicateReason{SimilarTitlesReason}, pairs[2].Reasons)
+	assert.Equal(t, "Anime", pairs[5].OtherEntry.Title)
+	assert.Equal(t, []DuplicateReason{SameLinkReason}, pairs[5].Reasons)
+	assert.Empty(t, NewEntriesContainer(NewSampleTestDataProvider("")).DuplicateCandidates())
+}
+
+func TestThatTitlesAreSimilarOnlyWhenTheyDifferSlightlyAndHaveTheSameNumbers(t *testing.T) {
+	assert.True(t, areTitlesSimilar("The Lord of the Rings", "the lord of the rings."))
+	assert.True(t, areTitlesSimilar("Spirited Away", "Spirted Away"))
+	assert.False(t, areTitlesSimilar("Dune", "Dune Messiah"))
+	assert.False(t, areTitlesSimilar("Berserk vol. 1", "Berserk vol. 11"))
+	assert.False(t, areTitlesSimilar("", "!"))
+}
+
+func TestThatMergedEntryTakesMissingValuesTagsAndHistoryOfTheOtherEntry(t *testing.T) {
+	firstDate, secondDate := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := Entry{Id: 3, Title: "Dune", Status: PlannedStatus, ElementsCompleted: 1, StartDate: "05/05/2020", FinishDate: "",
+		Tags: "sci-fi, classic", ConsumptionHistory: []ConsumptionEvent{{Kind: RewatchStartedEvent, Date: secondDate}}}
+	otherEntry := Entry{Id: 7, Title: "dune", Status: CompletedStatus, ElementsCompleted: 3, TotalAmountOfElementsToComplete: 3,
+		Score: 8, StartDate: "01/01/2020", FinishDate: "02/02/2020", Link: "some link", Comment: "some comment", Tags: "Classic, favourite",
+		Cover: "some cover", ConsumptionHistory: []ConsumptionEvent{{Kind: StatusChangedEvent, Date: firstDate}}}
+	merged := entry.mergedWith(otherEntry, comicsEntryType, comicsEntryType)
+	assert.Equal(t, Entry{Id: 3, Title: "Dune", Status: PlannedStatus, ElementsCompleted: 3, TotalAmountOfElementsToComplete: 3,
+		Score: 8, StartDate: "01/01/2020", FinishDate: "02/02/2020", Link: "some link", Comment: "some comment",
+		Tags: "sci-fi, classic, favourite", Cover: "some cover", ConsumptionHistory: []ConsumptionEvent{{Kind: StatusChangedEvent, Date: firstDate},
+			{Kind: RewatchStartedEvent, Date: secondDate}}}, merged)
+	thumbsType := EntryType{Name: "games", ScoringSystem: ThumbsScoring}
+	assert.Equal(t, 2, entry.mergedWith(otherEntry, thumbsType, comicsEntryType).Score)
+	entry.Score = 1
+	assert.Equal(t, 1, entry.mergedWith(otherEntry, thumbsType, comicsEntryType).Score)
+}
+
+func TestThatMergedEntryIsDeletedAndMergingCanBeUndone(t *testing.T) {
+	container := createContainerWithTestRelations()
+	assert.Nil(t, container.MergeEntries("videos", 1, "comics", 0))
+	assert.Equal(t, []Entry{GetExampleComicEntries()[1]}, container.entries[comicsEntryType])
+	merged, _ := container.entryWithId("videos", 1)
+	assert.Equal(t, "some video2", merged.Title)
+	assert.Equal(t, "some tags", merged.Tags)
+	assert.Equal(t, []Relation{{SequelRelation, "videos", 1, "comics", 1}}, container.RelationsOf("videos", 1))
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "merging entry 'some comic1' into entry 'some video2'", change.Description)
+	assert.Equal(t, GetExampleComicEntries(), container.entries[comicsEntryType])
+	assert.Equal(t, GetExampleVideoEntries(), container.entries[videoEntryType])
+	assert.Equal(t, 2, len(container.RelationsOf("comics", 0)))
+	_, err = container.Redo()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(container.entries[comicsEntryType]))
+}
+
+func TestThatListsAndSeriesOfMergedEntryAreMovedToKeptEntryUntilMergingIsUndone(t *testing.T) {
+	container := createLoadedTestContainer()
+	_ = container.CreateList("favourites")
+	_ = container.AddToList("favourites", "comics", 0)
+	_ = container.AddToList("favourites", "music", 0)
+	_ = container.AddToList("favourites", "videos", 0)
+	_ = container.CreateList("later")
+	_ = container.AddToList("later", "music", 0)
+	_ = container.CreateSeries("franchise")
+	_ = container.AddToSeries("franchise", "comics", 0)
+	_ = container.AddToSeries("franchise", "music", 0)
+	_ = container.CreateSeries("other franchise")
+	_ = container.AddToSeries("other franchise", "videos", 1)
+	assert.Nil(t, container.MergeEntries("videos", 0, "music", 0))
+	favourites, _ := container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"videos", 0}}, favourites.Items)
+	later, _ := container.ListWithName("later")
+	assert.Equal(t, []ListItem{{"videos", 0}}, later.Items)
+	franchise, _ := container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"videos", 0}}, franchise.Members)
+	assert.Nil(t, container.MergeEntries("videos", 1, "comics", 0))
+	franchise, _ = container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"videos", 0}}, franchise.Members)
+	favourites, _ = container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"videos", 1}, {"videos", 0}}, favourites.Items)
+	_, _ = container.Undo()
+	_, err := container.Undo()
+	assert.Nil(t, err)
+	favourites, _ = container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"music", 0}, {"videos", 0}}, favourites.Items)
+	later, _ = container.ListWithName("later")
+	assert.Equal(t, []ListItem{{"music", 0}}, later.Items)
+	franchise, _ = container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"music", 0}}, franchise.Members)
+	otherFranchise, _ := container.SeriesWithName("other franchise")
+	assert.Equal(t, []ListItem{{"videos", 1}}, otherFranchise.Members)
+}
+
+func TestThatEntryIsMergedIntoEntryOfTheSameTypeThatFollowsIt(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.MergeEntries("comics", 1, "comics", 0))
+	assert.Equal(t, 1, len(container.entries[comicsEntryType]))
+	assert.Equal(t, "some comic2", container.entries[comicsEntryType][0].Title)
+	_, _ = container.Undo()
+	assert.Equal(t, GetExampleComicEntries(), container.entries[comicsEntryType])
+}
+
+func TestThatInvalidMergesAreNotMade(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.EqualError(t, container.MergeEntries("comics", 0, "comics", 0), "Entry 'some comic1' cannot be merged into itself")
+	assert.EqualError(t, container.MergeEntries("comics", 5, "music", 0), "Cannot merge entries into entry with id 5 in entry type 'comics' as no such entry exists")
+	assert.EqualError(t, container.MergeEntries("comics", 0, "music", 5), "Cannot merge entry with id 5 of entry type 'music' as no such entry exists")
+	assert.EqualError(t, container.MergeEntries("books", 0, "music", 0), "Cannot merge entries into entry type 'books' as no such type exists")
+	assert.Equal(t, GetTestEntries(), container.entries)
+}
+
+func TestThatMergesAreReplayedFromJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := createLoadedTestContainer()
+	container.SetJournal(journal)
+	_ = container.MergeEntries("music", 0, "videos", 0)
+	_, _ = container.Undo()
+	_ = container.MergeEntries("comics", 1, "music", 1)
+	replayingContainer := createLoadedTestContainer()
+	replayingContainer.SetJournal(journal)
+	amount, err := replayingContainer.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, amount)
+	assert.Equal(t, container.entries, replayingContainer.entries)
+}
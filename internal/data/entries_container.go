@@ -1,37 +1,210 @@
 package data
 
-import "github.com/pkg/errors"
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"time"
+)
 
 type EntriesContainer struct {
-	dataProvider                     Provider
-	entries                          map[EntryType][]Entry
-	changeListenersCallbackFunctions []func()
+	dataProvider       Provider
+	entries            map[EntryType][]Entry
+	lists              []EntriesList
+	relations          []Relation
+	series             []Series
+	changeListeners    []changeListener
+	lastSubscriptionId SubscriptionId
+	//Set on every change and reset when the entries get loaded or saved
+	unsavedChanges bool
+	//Changes are recorded in the journal before they are made, unless it's nil
+	journal *Journal
+	history history
 }
 
 func NewEntriesContainer(dataProvider Provider) *EntriesContainer {
-	return &EntriesContainer{entries: map[EntryType][]Entry{}, dataProvider: dataProvider}
+	return &EntriesContainer{entries: map[EntryType][]Entry{}, dataProvider: dataProvider, history: newHistory(DefaultUndoDepth)}
 }
 
 func (container *EntriesContainer) LoadData() error {
 	entries, err := container.dataProvider.LoadEntries()
 	container.entries = entries
+	container.unsavedChanges = false
+	container.history.clear()
+	if err != nil {
+		return err
+	}
+	lists, err := container.dataProvider.LoadLists()
+	container.lists = append([]EntriesList{}, lists...)
+	sort.Slice(container.lists, func(i, j int) bool { return container.lists[i].Name < container.lists[j].Name })
+	if err != nil {
+		return err
+	}
+	relations, err := container.dataProvider.LoadRelations()
+	container.relations = append([]Relation{}, relations...)
+	if err != nil {
+		return err
+	}
+	series, err := container.dataProvider.LoadSeries()
+	container.series = append([]Series{}, series...)
+	sort.Slice(container.series, func(i, j int) bool { return container.series[i].Name < container.series[j].Name })
 	return err
 }
 
 func (container *EntriesContainer) SaveData() error {
-	err := container.dataProvider.SaveEntries(container.entries)
-	return err
+	err := container.dataProvider.SaveAll(container.entries, container.lists, container.relations, container.series)
+	if err != nil {
+		return err
+	}
+	container.unsavedChanges = false
+	if container.journal != nil {
+		return errors.Wrap(container.journal.Clear(), "Changes have been saved but the journal of them could not be cleared")
+	}
+	return nil
+}
+
+//Every change made after setting the journal gets recorded in it. Records that are already in the journal are kept.
+func (container *EntriesContainer) SetJournal(journal *Journal) {
+	container.journal = journal
+}
+
+func (container *EntriesContainer) recordInJournal(record JournalRecord) error {
+	if container.journal == nil {
+		return nil
+	}
+	return errors.Wrap(container.journal.Append(record), "Change has not been made as it could not be recorded in the journal")
+}
+
+//Makes changes recorded in the journal, e.g. after the application crashed before they were saved, and returns the
+//amount of made changes. Replaying stops at the first change that cannot be made. Replayed changes cannot be undone.
+func (container *EntriesContainer) ReplayJournal() (int, error) {
+	journal := container.journal
+	if journal == nil {
+		return 0, errors.New("Cannot replay changes as there is no journal")
+	}
+	records, err := journal.Records()
+	if err != nil {
+		return 0, err
+	}
+	//Replayed changes are already in the journal so they must not be recorded again
+	container.journal = nil
+	defer func() { container.journal = journal }()
+	for i, record := range records {
+		_, err = container.apply(record)
+		if err != nil {
+			return i, errors.Wrap(err, "Failed to replay change number "+strconv.Itoa(i+1)+" recorded in the journal")
+		}
+	}
+	return len(records), nil
+}
+
+func (container *EntriesContainer) HasUnsavedChanges() bool {
+	return container.unsavedChanges
+}
+
+//Makes a change requested by a user, which unlike changes made when undoing, redoing or replaying, starts a new history of changes
+func (container *EntriesContainer) execute(record JournalRecord, description string) error {
+	revertingRecord, err := container.apply(record)
+	if err != nil {
+		return err
+	}
+	container.history.changeMade(historyItem{description: description, record: revertingRecord})
+	return nil
+}
+
+//Every change of entries is described by a record, so it can be recorded in the journal before it's made.
+//Returns the record of the change that reverts the made change.
+func (container *EntriesContainer) apply(record JournalRecord) (JournalRecord, error) {
+	switch record.Operation {
+	case AddEntryTypeOperation:
+		return container.addEntryType(*record.EntryType, []Entry{}, record)
+	case RestoreEntryTypeOperation:
+		return container.addEntryType(*record.EntryType, record.Entries, record)
+	case DeleteEntryTypeOperation:
+		return container.deleteEntryType(record)
+	case UpdateEntryTypeOperation:
+		return container.updateEntryType(record)
+	case AddEntryOperation:
+		return container.addEntry(record)
+	case DeleteEntryOperation:
+		return container.deleteEntry(record)
+	case UpdateEntryOperation:
+		return container.updateEntry(record.TypeName, record.Entry.Id, "update entry", func(EntryType, Entry) Entry { return *record.Entry }, record)
+	case ChangeEntryProgressOperation:
+		return container.updateEntry(record.TypeName, record.EntryId, "change progress of entry", func(entryType EntryType, entry Entry) Entry {
+			return entry.WithProgressChangedBy(entryType, record.Amount, record.Date)
+		}, record)
+	case StartRewatchOperation:
+		return container.updateEntry(record.TypeName, record.EntryId, "start rewatch of entry", func(entryType EntryType, entry Entry) Entry {
+			return entry.WithRewatchStarted(entryType, record.Date)
+		}, record)
+	case SetCoverOperation:
+		return container.updateEntry(record.TypeName, record.EntryId, "set cover of entry", func(entryType EntryType, entry Entry) Entry {
+			entry.Cover = record.Cover
+			return entry
+		}, record)
+	case CreateListOperation:
+		return container.createList(record)
+	case DeleteListOperation:
+		return container.deleteList(record)
+	case AddToListOperation:
+		return container.addToList(record)
+	case RemoveFromListOperation:
+		return container.removeFromList(record)
+	case MoveInListOperation:
+		return container.moveInList(record)
+	case AddRelationOperation:
+		return container.addRelation(record)
+	case RemoveRelationOperation:
+		return container.removeRelation(record)
+	case CreateSeriesOperation:
+		return container.createSeries(record)
+	case DeleteSeriesOperation:
+		return container.deleteSeries(record)
+	case AddToSeriesOperation:
+		return container.addToSeries(record)
+	case RemoveFromSeriesOperation:
+		return container.removeFromSeries(record)
+	case MergeEntriesOperation:
+		return container.mergeEntries(record)
+	case UnmergeEntriesOperation:
+		return container.unmergeEntries(record)
+	case MoveEntriesOperation:
+		return container.moveEntries(record)
+	case AddEntriesOperation:
+		return container.addEntries(record)
+	case DeleteEntriesOperation:
+		return container.deleteEntries(record)
+	}
+	return JournalRecord{}, errors.New("Unknown journal operation '" + string(record.Operation) + "'")
 }
 
 func (container *EntriesContainer) AddEntryType(entryTypeToAdd EntryType) error {
+	record := JournalRecord{Operation: AddEntryTypeOperation, EntryType: &entryTypeToAdd}
+	return container.execute(record, "adding entry type '"+entryTypeToAdd.Name+"'")
+}
+
+func (container *EntriesContainer) addEntryType(entryTypeToAdd EntryType, entries []Entry, record JournalRecord) (JournalRecord, error) {
 	if entryTypeToAdd.Name == "" {
-		return errors.New("Cannot add entry type with an empty name")
+		return JournalRecord{}, errors.New("Cannot add entry type with an empty name")
 	} else if container.typeWithNameExists(entryTypeToAdd.Name) {
-		return errors.New("Entry type with name '" + entryTypeToAdd.Name + "' already exists")
+		return JournalRecord{}, errors.New("Entry type with name '" + entryTypeToAdd.Name + "' already exists")
+	} else if err := reservedTypeNameError(entryTypeToAdd.Name); err != nil {
+		return JournalRecord{}, err
+	} else if _, err := ParseStatuses(entryTypeToAdd.Statuses); err != nil {
+		return JournalRecord{}, errors.Wrap(err, "Cannot add entry type '"+entryTypeToAdd.Name+"' with incorrect statuses")
+	} else if !IsValidScoringSystem(entryTypeToAdd.ScoringSystem) {
+		return JournalRecord{}, unknownScoringSystemError(entryTypeToAdd.ScoringSystem)
 	}
-	container.entries[entryTypeToAdd] = []Entry{}
-	container.notifyListenersAboutChange()
-	return nil
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	container.entries[entryTypeToAdd] = append([]Entry{}, entries...)
+	container.restoreRelations(record.Relations)
+	container.restoreSeriesMembers(record.SeriesMembers)
+	container.notifyListenersAboutChange(ChangeEvent{Kind: EntryTypeAddedChange, TypeAfter: &entryTypeToAdd})
+	return JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: entryTypeToAdd.Name}, nil
 }
 
 func (container *EntriesContainer) typeWithNameExists(nameToCheck string) bool {
@@ -43,46 +216,267 @@ func (container *EntriesContainer) typeWithNameExists(nameToCheck string) bool {
 	return false
 }
 
-func (container *EntriesContainer) notifyListenersAboutChange() {
-	for _, callback := range container.changeListenersCallbackFunctions {
-		callback()
-	}
+func (container *EntriesContainer) DeleteEntryType(typeName string) error {
+	record := JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: typeName}
+	return container.execute(record, "deleting entry type '"+typeName+"'")
 }
 
-func (container *EntriesContainer) DeleteEntryType(typeName string) error {
-	if container.typeWithNameExists(typeName) {
-		container.deleteEntryTypeWithName(typeName)
-		container.notifyListenersAboutChange()
-		return nil
+//Deleted type is restored along with all of its entries, their relations and series memberships when the deletion
+//gets reverted
+func (container *EntriesContainer) deleteEntryType(record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(record.TypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot delete an entry type with name '" + record.TypeName + "' as there is no such type")
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
 	}
-	return errors.New("Cannot delete an entry type with name '" + typeName + "' as there is no such type")
+	entries := container.entries[entryType]
+	delete(container.entries, entryType)
+	isDeletedEntry := func(typeName string, entryId int) bool { return typeName == entryType.Name }
+	relations := container.removeRelationsOfEntries(isDeletedEntry)
+	seriesMembers := container.removeSeriesMembersOfEntries(isDeletedEntry)
+	container.notifyListenersAboutChange(ChangeEvent{Kind: EntryTypeDeletedChange, TypeBefore: &entryType})
+	return JournalRecord{Operation: RestoreEntryTypeOperation, EntryType: &entryType, Entries: entries, Relations: relations,
+		SeriesMembers: seriesMembers}, nil
 }
 
-func (container *EntriesContainer) deleteEntryTypeWithName(typeName string) {
-	for entryType, _ := range container.entries {
-		if entryType.Name == typeName {
+func (container *EntriesContainer) UpdateEntryType(nameOfTypeToUpdate string, typeToReplaceWith EntryType) error {
+	record := JournalRecord{Operation: UpdateEntryTypeOperation, TypeName: nameOfTypeToUpdate, EntryType: &typeToReplaceWith}
+	return container.execute(record, "editing entry type '"+nameOfTypeToUpdate+"'")
+}
+
+//Statuses and scores of entries are migrated to the updated type, see Entry.migratedTo, unless the record
+//has the entries the type should have after the update, which is the case when an update gets reverted, so entries
+//get back exactly the statuses they had
+func (container *EntriesContainer) updateEntryType(record JournalRecord) (JournalRecord, error) {
+	nameOfTypeToUpdate, typeToReplaceWith := record.TypeName, *record.EntryType
+	if typeToReplaceWith.Name == "" {
+		return JournalRecord{}, errors.New("Cannot update entry type with name '" + nameOfTypeToUpdate + "' to type with an empty name")
+	} else if err := reservedTypeNameError(typeToReplaceWith.Name); err != nil && typeToReplaceWith.Name != nameOfTypeToUpdate {
+		return JournalRecord{}, err
+	} else if _, err := ParseStatuses(typeToReplaceWith.Statuses); err != nil {
+		return JournalRecord{}, errors.Wrap(err, "Cannot update entry type '"+nameOfTypeToUpdate+"' to type with incorrect statuses")
+	} else if !IsValidScoringSystem(typeToReplaceWith.ScoringSystem) {
+		return JournalRecord{}, unknownScoringSystemError(typeToReplaceWith.ScoringSystem)
+	}
+	for entryType, entries := range container.entries {
+		if entryType.Name == nameOfTypeToUpdate {
+			err := container.recordInJournal(record)
+			if err != nil {
+				return JournalRecord{}, err
+			}
+			migratedEntries := record.Entries
+			if migratedEntries == nil {
+				migratedEntries = []Entry{}
+				for _, entry := range entries {
+					migratedEntries = append(migratedEntries, entry.migratedTo(entryType, typeToReplaceWith))
+				}
+			}
 			delete(container.entries, entryType)
+			container.entries[typeToReplaceWith] = migratedEntries
+			container.notifyListenersAboutChange(entryTypeChangeEvent(entryType, typeToReplaceWith))
+			if entryType.Name != typeToReplaceWith.Name {
+				container.renameEntryTypeInLists(entryType.Name, typeToReplaceWith.Name)
+				container.renameEntryTypeInRelations(entryType.Name, typeToReplaceWith.Name)
+				container.renameEntryTypeInSeries(entryType.Name, typeToReplaceWith.Name)
+			}
+			return JournalRecord{Operation: UpdateEntryTypeOperation, TypeName: typeToReplaceWith.Name, EntryType: &entryType, Entries: entries}, nil
 		}
 	}
+	return JournalRecord{}, errors.New("Cannot update entry type '" + nameOfTypeToUpdate + "' as no such type exists")
 }
 
-func (container *EntriesContainer) UpdateEntryType(nameOfTypeToUpdate string, typeToReplaceWith EntryType) error {
-	if typeToReplaceWith.Name == "" {
-		return errors.New("Cannot update entry type with name '" + nameOfTypeToUpdate + "' to type with an empty name")
+//Entry is added at the end of the entry type with an id not used by any other entry of the type, which is returned.
+//Entry has to have one of the statuses of the type and a score valid in its scoring system.
+func (container *EntriesContainer) AddEntry(typeName string, entryToAdd Entry) (int, error) {
+	entryType, err := container.EntryTypeWithName(typeName)
+	if err != nil {
+		return 0, errors.New("Cannot add entry '" + entryToAdd.Title + "' to entry type '" + typeName + "' as no such type exists")
+	}
+	if !entryType.HasStatus(entryToAdd.Status) {
+		return 0, statusNotOfTypeError(entryToAdd, entryType)
+	} else if !entryType.Scoring().IsValidScore(entryToAdd.Score) {
+		return 0, invalidScoreError(entryToAdd, entryType)
 	}
-	return container.tryUpdatingEntryType(nameOfTypeToUpdate, typeToReplaceWith)
+	entryToAdd.Id = nextEntryIdIn(container.entries[entryType])
+	record := JournalRecord{Operation: AddEntryOperation, TypeName: typeName, Entry: &entryToAdd, Position: len(container.entries[entryType])}
+	err = container.execute(record, "adding entry '"+entryToAdd.Title+"'")
+	if err != nil {
+		return 0, err
+	}
+	return entryToAdd.Id, nil
 }
 
-func (container *EntriesContainer) tryUpdatingEntryType(nameOfTypeToUpdate string, typeToReplaceWith EntryType) error {
-	for entryType, entries := range container.entries {
-		if entryType.Name == nameOfTypeToUpdate {
-			delete(container.entries, entryType)
-			container.entries[typeToReplaceWith] = entries
-			container.notifyListenersAboutChange()
-			return nil
+func nextEntryIdIn(entries []Entry) int {
+	nextId := 0
+	for _, entry := range entries {
+		if entry.Id >= nextId {
+			nextId = entry.Id + 1
 		}
 	}
-	return errors.New("Cannot update entry type '" + nameOfTypeToUpdate + "' as no such type exists")
+	return nextId
+}
+
+func (container *EntriesContainer) DeleteEntry(typeName string, entryId int) error {
+	record := JournalRecord{Operation: DeleteEntryOperation, TypeName: typeName, EntryId: entryId}
+	return container.execute(record, "deleting "+container.describeEntry(typeName, entryId))
+}
+
+//Entry is inserted at the record's position, so an entry restored after its deletion gets back to where it was,
+//along with its relations and series membership
+func (container *EntriesContainer) addEntry(record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(record.TypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot add entry to entry type '" + record.TypeName + "' as no such type exists")
+	}
+	if _, exists := container.entryWithId(record.TypeName, record.Entry.Id); exists {
+		return JournalRecord{}, errors.New("Cannot add entry with id " + strconv.Itoa(record.Entry.Id) + " to entry type '" + record.TypeName + "' as its id is already used")
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	entries := container.entries[entryType]
+	position := record.Position
+	if position < 0 || position > len(entries) {
+		position = len(entries)
+	}
+	addedEntry := *record.Entry
+	container.entries[entryType] = append(append(append([]Entry{}, entries[:position]...), addedEntry), entries[position:]...)
+	container.restoreRelations(record.Relations)
+	container.restoreSeriesMembers(record.SeriesMembers)
+	container.notifyListenersAboutChange(entryChangeEvent(EntryAddedChange, entryType, nil, &addedEntry))
+	return JournalRecord{Operation: DeleteEntryOperation, TypeName: record.TypeName, EntryId: addedEntry.Id}, nil
+}
+
+func (container *EntriesContainer) deleteEntry(record JournalRecord) (JournalRecord, error) {
+	entryType, _ := container.EntryTypeWithName(record.TypeName)
+	for i, entry := range container.entries[entryType] {
+		if entry.Id == record.EntryId {
+			err := container.recordInJournal(record)
+			if err != nil {
+				return JournalRecord{}, err
+			}
+			entries := container.entries[entryType]
+			container.entries[entryType] = append(append([]Entry{}, entries[:i]...), entries[i+1:]...)
+			isDeletedEntry := func(typeName string, entryId int) bool {
+				return typeName == record.TypeName && entryId == record.EntryId
+			}
+			relations := container.removeRelationsOfEntries(isDeletedEntry)
+			seriesMembers := container.removeSeriesMembersOfEntries(isDeletedEntry)
+			container.notifyListenersAboutChange(entryChangeEvent(EntryDeletedChange, entryType, &entry, nil))
+			return JournalRecord{Operation: AddEntryOperation, TypeName: record.TypeName, Entry: &entry, Position: i, Relations: relations,
+				SeriesMembers: seriesMembers}, nil
+		}
+	}
+	return JournalRecord{}, errors.New("Cannot delete entry with id " + strconv.Itoa(record.EntryId) + " in entry type '" + record.TypeName + "' as no such entry exists")
+}
+
+//Replaces the entry that has the same id as the given entry. Changes of its progress and status are recorded in its
+//consumption history, which along with its cover are the only parts of the given entry that are ignored, see SetCover.
+//Changed status has to be one of the statuses of the type and changed score has to be valid in its scoring system.
+func (container *EntriesContainer) UpdateEntry(typeName string, entryToUpdateWith Entry) error {
+	entryToUpdate, exists := container.entryWithId(typeName, entryToUpdateWith.Id)
+	if exists {
+		entryType, _ := container.EntryTypeWithName(typeName)
+		if entryToUpdateWith.Status != entryToUpdate.Status && !entryType.HasStatus(entryToUpdateWith.Status) {
+			return statusNotOfTypeError(entryToUpdateWith, entryType)
+		} else if entryToUpdateWith.Score != entryToUpdate.Score && !entryType.Scoring().IsValidScore(entryToUpdateWith.Score) {
+			return invalidScoreError(entryToUpdateWith, entryType)
+		}
+		entryToUpdateWith = entryToUpdate.withEventsOfChangesTo(entryType, entryToUpdateWith, currentChangeDate())
+		entryToUpdateWith.Cover = entryToUpdate.Cover
+	}
+	record := JournalRecord{Operation: UpdateEntryOperation, TypeName: typeName, Entry: &entryToUpdateWith}
+	return container.execute(record, "editing "+container.describeEntry(typeName, entryToUpdateWith.Id))
+}
+
+func statusNotOfTypeError(entry Entry, entryType EntryType) error {
+	return errors.New("Entry '" + entry.Title + "' cannot have status '" + string(entry.Status) + "' as it's not a status of entry type '" +
+		entryType.Name + "'")
+}
+
+func invalidScoreError(entry Entry, entryType EntryType) error {
+	return errors.New("Entry '" + entry.Title + "' cannot have score " + strconv.Itoa(entry.Score) + " as entry type '" +
+		entryType.Name + "' scores from 1 to " + strconv.Itoa(entryType.Scoring().MaxScore()))
+}
+
+func unknownScoringSystemError(system ScoringSystem) error {
+	return errors.New("There is no scoring system '" + string(system) + "', it has to be one of: " + scoringSystemsAsText())
+}
+
+//Changes amount of completed elements of the entry with the given id by the given amount, see Entry.WithProgressChangedBy.
+//Date of the change is recorded so the change made again when replaying the journal has the same date.
+func (container *EntriesContainer) ChangeEntryProgress(typeName string, entryId int, amount int) error {
+	record := JournalRecord{Operation: ChangeEntryProgressOperation, TypeName: typeName, EntryId: entryId, Amount: amount, Date: currentChangeDate()}
+	return container.execute(record, "changing progress of "+container.describeEntry(typeName, entryId))
+}
+
+//Cover is the hash of an image in the image store, or an empty hash if the entry should have no cover. Cover is set
+//separately from other values of the entry, so editing the entry without knowing its cover, e.g. through the API,
+//doesn't remove the cover.
+func (container *EntriesContainer) SetCover(typeName string, entryId int, cover string) error {
+	record := JournalRecord{Operation: SetCoverOperation, TypeName: typeName, EntryId: entryId, Cover: cover}
+	return container.execute(record, "changing cover of "+container.describeEntry(typeName, entryId))
+}
+
+//Only an entry with a status in the completed category can be rewatched, see Entry.WithRewatchStarted
+func (container *EntriesContainer) StartRewatch(typeName string, entryId int) error {
+	entry, exists := container.entryWithId(typeName, entryId)
+	entryType, _ := container.EntryTypeWithName(typeName)
+	if exists && entryType.CategoryOf(entry.Status) != CompletedStatus {
+		return errors.New("Cannot start rewatching entry '" + entry.Title + "' as it has not been completed")
+	}
+	record := JournalRecord{Operation: StartRewatchOperation, TypeName: typeName, EntryId: entryId, Date: currentChangeDate()}
+	return container.execute(record, "starting rewatch of "+container.describeEntry(typeName, entryId))
+}
+
+//Dates are in UTC and have no monotonic clock reading so changes replayed from the journal are exactly the same as
+//the ones originally made, and are precise to a second as that's precise enough for a history of consumption
+func currentChangeDate() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+//Changes made to entries don't always have a simple opposite, e.g. changing progress can change entry's status,
+//so they are reverted by updating the entry to what it was before the change
+func (container *EntriesContainer) updateEntry(typeName string, entryId int, changeName string, change func(EntryType, Entry) Entry, record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(typeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot " + changeName + " in entry type '" + typeName + "' as no such type exists")
+	}
+	for i, entry := range container.entries[entryType] {
+		if entry.Id == entryId {
+			err = container.recordInJournal(record)
+			if err != nil {
+				return JournalRecord{}, err
+			}
+			changedEntry := change(entryType, entry)
+			container.entries[entryType][i] = changedEntry
+			container.notifyListenersAboutChange(entryChangeEvent(EntryUpdatedChange, entryType, &entry, &changedEntry))
+			return JournalRecord{Operation: UpdateEntryOperation, TypeName: typeName, Entry: &entry}, nil
+		}
+	}
+	return JournalRecord{}, errors.New("Cannot " + changeName + " with id " + strconv.Itoa(entryId) + " in entry type '" + typeName + "' as no such entry exists")
+}
+
+func (container *EntriesContainer) describeEntry(typeName string, entryId int) string {
+	entry, exists := container.entryWithId(typeName, entryId)
+	if exists {
+		return "entry '" + entry.Title + "'"
+	}
+	return "entry with id " + strconv.Itoa(entryId)
+}
+
+func (container *EntriesContainer) entryWithId(typeName string, entryId int) (Entry, bool) {
+	entryType, _ := container.EntryTypeWithName(typeName)
+	for _, entry := range container.entries[entryType] {
+		if entry.Id == entryId {
+			return entry, true
+		}
+	}
+	return Entry{}, false
 }
 
 func (container *EntriesContainer) EntryTypeWithName(typeName string) (EntryType, error) {
@@ -102,10 +496,6 @@ func (container *EntriesContainer) EntriesGroupedByType() map[EntryType][]Entry
 	return entriesToReturn
 }
 
-func (container *EntriesContainer) SubscribeToChanges(callbackFunction func()) {
-	container.changeListenersCallbackFunctions = append(container.changeListenersCallbackFunctions, callbackFunction)
-}
-
 func (container *EntriesContainer) AmountOfTypes() int {
 	return len(container.entries)
 }
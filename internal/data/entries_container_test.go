@@ -2,6 +2,7 @@ package data
 
 import (
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
 	"wirwl/internal/log"
 )
@@ -79,6 +80,19 @@ func TestThatErrorIsReturnedWhenTryingToAddEntryTypeWithEmptyName(t *testing.T)
 	assert.Contains(t, err.Error(), "Cannot add entry type with an empty name")
 }
 
+func TestThatEntryTypesCannotHaveNamesUnderWhichSeriesAndListsAreDisplayed(t *testing.T) {
+	container := createLoadedTestContainer()
+	err := container.AddEntryType(EntryType{Name: "Series"})
+	assert.EqualError(t, err, "Entry type cannot be named 'Series' as series are displayed under that name")
+	renamedType := comicsEntryType
+	renamedType.Name = "List: comics"
+	err = container.UpdateEntryType("comics", renamedType)
+	assert.EqualError(t, err, "Name of entry type 'List: comics' cannot start with 'List: ' as lists are displayed under such names")
+	err = container.AddEntryType(EntryType{Name: "Smart list: comics"})
+	assert.EqualError(t, err, "Name of entry type 'Smart list: comics' cannot start with 'Smart list: ' as lists are displayed under such names")
+	assert.Equal(t, GetTestEntries(), container.entries)
+}
+
 func TestThatWhenEntryTypeExistsItIsRemoved(t *testing.T) {
 	container := NewEntriesContainer(NewSampleTestDataProvider(""))
 	typeToAdd := EntryType{
@@ -176,10 +190,10 @@ func TestThatEntriesGroupedByTypeAreProperlyReturned(t *testing.T) {
 func TestThatChangeCallbackFunctionIsCalledOnEveryChangeForEveryListener(t *testing.T) {
 	function1Called := false
 	function2Called := false
-	function1 := func() {
+	function1 := func(ChangeEvent) {
 		function1Called = true
 	}
-	function2 := func() {
+	function2 := func(ChangeEvent) {
 		function2Called = true
 	}
 	container := NewEntriesContainer(NewSampleTestDataProvider(""))
@@ -262,3 +276,218 @@ func TestThatContainerReturnsCorrectAmountOfEntryTypes(t *testing.T) {
 	}
 	assert.Equal(t, 3, container.AmountOfTypes())
 }
+
+func TestThatItIsPossibleToUpdateAnEntry(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	changeCallbackCalled := false
+	container.SubscribeToChanges(func(ChangeEvent) { changeCallbackCalled = true })
+	entryToUpdateWith := GetExampleVideoEntries()[1]
+	entryToUpdateWith.Title = "updated title"
+	entryToUpdateWith.ElementsCompleted = 5
+	err = container.UpdateEntry(videoEntryType.Name, entryToUpdateWith)
+	assert.Nil(t, err)
+	updatedEntry := container.entries[videoEntryType][1]
+	assert.Equal(t, 1, len(updatedEntry.ConsumptionHistory))
+	updatedEntry.ConsumptionHistory = nil
+	assert.Equal(t, entryToUpdateWith, updatedEntry)
+	assert.Equal(t, GetExampleVideoEntries()[0], container.entries[videoEntryType][0])
+	assert.True(t, changeCallbackCalled)
+}
+
+func TestThatErrorIsReturnedWhenTryingToUpdateEntryThatDoesNotExist(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = container.UpdateEntry("non existing type", Entry{Id: 0})
+	assert.Equal(t, "Cannot update entry in entry type 'non existing type' as no such type exists", err.Error())
+	err = container.UpdateEntry(videoEntryType.Name, Entry{Id: 100})
+	assert.Equal(t, "Cannot update entry with id 100 in entry type 'videos' as no such entry exists", err.Error())
+}
+
+func TestThatItIsPossibleToChangeProgressOfAnEntry(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	changeCallbackCalled := false
+	container.SubscribeToChanges(func(ChangeEvent) { changeCallbackCalled = true })
+	err = container.ChangeEntryProgress(comicsEntryType.Name, 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, container.entries[comicsEntryType][0].ElementsCompleted)
+	assert.Equal(t, CompletedStatus, container.entries[comicsEntryType][0].Status)
+	assert.Equal(t, GetExampleComicEntries()[1], container.entries[comicsEntryType][1])
+	assert.True(t, changeCallbackCalled)
+}
+
+func TestThatErrorIsReturnedWhenTryingToChangeProgressOfEntryThatDoesNotExist(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = container.ChangeEntryProgress("non existing type", 0, 1)
+	assert.Equal(t, "Cannot change progress of entry in entry type 'non existing type' as no such type exists", err.Error())
+	err = container.ChangeEntryProgress(videoEntryType.Name, 100, 1)
+	assert.Equal(t, "Cannot change progress of entry with id 100 in entry type 'videos' as no such entry exists", err.Error())
+}
+
+func TestThatCoverOfEntryIsKeptWhenEntryIsUpdatedAndCanBeChangedOnlyByUndoableChange(t *testing.T) {
+	container := createLoadedTestContainer()
+	err := container.SetCover(comicsEntryType.Name, 1, "some hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "some hash", container.entries[comicsEntryType][1].Cover)
+	updatedEntry := GetExampleComicEntries()[1]
+	updatedEntry.Title = "new title"
+	err = container.UpdateEntry(comicsEntryType.Name, updatedEntry)
+	assert.Nil(t, err)
+	assert.Equal(t, "some hash", container.entries[comicsEntryType][1].Cover)
+	_, _ = container.Undo()
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "changing cover of entry 'some comic2'", change.Description)
+	assert.Equal(t, GetExampleComicEntries(), container.entries[comicsEntryType])
+	err = container.SetCover(videoEntryType.Name, 100, "some hash")
+	assert.Equal(t, "Cannot set cover of entry with id 100 in entry type 'videos' as no such entry exists", err.Error())
+}
+
+func TestThatEntryIsAddedWithUnusedIdAndItsAdditionCanBeUndone(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var event ChangeEvent
+	container.SubscribeToChanges(func(changeEvent ChangeEvent) { event = changeEvent })
+	id, err := container.AddEntry(comicsEntryType.Name, Entry{Id: 0, Title: "new comic", Status: PlannedStatus})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, id)
+	assert.Equal(t, Entry{Id: 2, Title: "new comic", Status: PlannedStatus}, container.entries[comicsEntryType][2])
+	assert.Equal(t, EntryAddedChange, event.Kind)
+	assert.Nil(t, event.EntryBefore)
+	_, err = container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, GetExampleComicEntries(), container.entries[comicsEntryType])
+	assert.Equal(t, EntryDeletedChange, event.Kind)
+	_, err = container.Redo()
+	assert.Nil(t, err)
+	assert.Equal(t, "new comic", container.entries[comicsEntryType][2].Title)
+	_, err = container.AddEntry("non existing type", Entry{Title: "new comic"})
+	assert.Equal(t, "Cannot add entry 'new comic' to entry type 'non existing type' as no such type exists", err.Error())
+}
+
+func TestThatContainerHasUnsavedChangesOnlyAfterChangeUntilItIsSaved(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	container := NewEntriesContainer(NewSampleTestDataProvider(testDbPath))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	assert.False(t, container.HasUnsavedChanges())
+	err = container.ChangeEntryProgress(comicsEntryType.Name, 0, 1)
+	assert.Nil(t, err)
+	assert.True(t, container.HasUnsavedChanges())
+	err = container.SaveData()
+	assert.Nil(t, err)
+	assert.False(t, container.HasUnsavedChanges())
+}
+
+func TestThatContainerHasUnsavedChangesWhenSavingFails(t *testing.T) {
+	container := NewEntriesContainer(NewAlwaysFailingProvider())
+	err := container.AddEntryType(EntryType{Name: "type"})
+	assert.Nil(t, err)
+	err = container.SaveData()
+	assert.NotNil(t, err)
+	assert.True(t, container.HasUnsavedChanges())
+}
+
+func TestThatFailedChangeDoesNotMarkContainerAsHavingUnsavedChanges(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	err = container.AddEntryType(EntryType{Name: ""})
+	assert.NotNil(t, err)
+	assert.False(t, container.HasUnsavedChanges())
+}
+
+func TestThatChangesAreRecordedInJournalAndCanBeReplayed(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	container.SetJournal(journal)
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	assert.Nil(t, container.UpdateEntryType("music", EntryType{Name: "albums"}))
+	assert.Nil(t, container.DeleteEntryType("videos"))
+	updatedEntry := GetExampleComicEntries()[1]
+	updatedEntry.Title = "updated title"
+	assert.Nil(t, container.UpdateEntry("comics", updatedEntry))
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, 1))
+	assert.NotNil(t, container.DeleteEntryType("non existing type"))
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(records))
+	containerAfterCrash := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err = containerAfterCrash.LoadData()
+	assert.Nil(t, err)
+	containerAfterCrash.SetJournal(journal)
+	replayedAmount, err := containerAfterCrash.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, replayedAmount)
+	assert.Equal(t, container.EntriesGroupedByType(), containerAfterCrash.EntriesGroupedByType())
+	assert.True(t, containerAfterCrash.HasUnsavedChanges())
+	records, err = journal.Records()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(records))
+}
+
+func TestThatReplayingStopsAtChangeThatCannotBeMade(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	assert.Nil(t, journal.Append(JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: "videos"}))
+	assert.Nil(t, journal.Append(JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: "videos"}))
+	assert.Nil(t, journal.Append(JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: "music"}))
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	container.SetJournal(journal)
+	replayedAmount, err := container.ReplayJournal()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "change number 2")
+	assert.Equal(t, 1, replayedAmount)
+	assert.Equal(t, 2, container.AmountOfTypes())
+}
+
+func TestThatSavingClearsJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	testDbPath, dbCleanup := getTempDbPath()
+	defer dbCleanup()
+	container := NewEntriesContainer(NewSampleTestDataProvider(testDbPath))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	container.SetJournal(journal)
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	err = container.SaveData()
+	assert.Nil(t, err)
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestThatChangeIsNotMadeWhenItCannotBeRecordedInJournal(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	container.SetJournal(NewJournal(filepath.Join("non", "existing", "dir", "journal.jsonl")))
+	err = container.AddEntryType(EntryType{Name: "books"})
+	assert.NotNil(t, err)
+	assert.False(t, container.typeWithNameExists("books"))
+	assert.False(t, container.HasUnsavedChanges())
+}
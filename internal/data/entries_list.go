@@ -0,0 +1,252 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+)
+
+/*
+Lists are user-defined groupings of entries, e.g. "Watch with friends", which unlike entry types can contain entries of
+any type in a chosen order. A list only refers to its entries, so the entries can be changed without changing the list.
+Items referring to entries that don't exist, e.g. after their type has been deleted, are kept so the deletion can be
+undone, and items follow their entry type when it gets renamed.
+*/
+type EntriesList struct {
+	Name  string
+	Items []ListItem
+}
+
+type ListItem struct {
+	TypeName string
+	EntryId  int
+}
+
+//Returns copies of all lists sorted by their names
+func (container *EntriesContainer) Lists() []EntriesList {
+	lists := []EntriesList{}
+	for _, list := range container.lists {
+		lists = append(lists, list.copy())
+	}
+	return lists
+}
+
+func (container *EntriesContainer) ListWithName(name string) (EntriesList, error) {
+	num, exists := container.listNum(name)
+	if !exists {
+		return EntriesList{}, errors.New("Cannot retrieve list with name '" + name + "' as such list doesn't exist")
+	}
+	return container.lists[num].copy(), nil
+}
+
+func (container *EntriesContainer) listNum(name string) (int, bool) {
+	for i, list := range container.lists {
+		if list.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (list EntriesList) copy() EntriesList {
+	list.Items = append([]ListItem{}, list.Items...)
+	return list
+}
+
+//Returns the position of the item referring to the entry, if the list contains it
+func (list EntriesList) PositionOf(typeName string, entryId int) (int, bool) {
+	for i, item := range list.Items {
+		if item.TypeName == typeName && item.EntryId == entryId {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (container *EntriesContainer) CreateList(name string) error {
+	record := JournalRecord{Operation: CreateListOperation, List: &EntriesList{Name: name}}
+	return container.execute(record, "creating list '"+name+"'")
+}
+
+func (container *EntriesContainer) DeleteList(name string) error {
+	record := JournalRecord{Operation: DeleteListOperation, ListName: name}
+	return container.execute(record, "deleting list '"+name+"'")
+}
+
+//Entry is added at the end of the list
+func (container *EntriesContainer) AddToList(listName string, typeName string, entryId int) error {
+	record := JournalRecord{Operation: AddToListOperation, ListName: listName, TypeName: typeName, EntryId: entryId}
+	if num, exists := container.listNum(listName); exists {
+		record.Position = len(container.lists[num].Items)
+	}
+	return container.execute(record, "adding "+container.describeEntry(typeName, entryId)+" to list '"+listName+"'")
+}
+
+func (container *EntriesContainer) RemoveFromList(listName string, position int) error {
+	record := JournalRecord{Operation: RemoveFromListOperation, ListName: listName, Position: position}
+	return container.execute(record, "removing "+container.describeListItem(listName, position)+" from list '"+listName+"'")
+}
+
+//Moves the item at given position by given amount of positions, towards the end of the list if the amount is positive
+func (container *EntriesContainer) MoveInList(listName string, position int, amount int) error {
+	record := JournalRecord{Operation: MoveInListOperation, ListName: listName, Position: position, Amount: amount}
+	return container.execute(record, "moving "+container.describeListItem(listName, position)+" in list '"+listName+"'")
+}
+
+func (container *EntriesContainer) describeListItem(listName string, position int) string {
+	num, exists := container.listNum(listName)
+	if !exists || position < 0 || position >= len(container.lists[num].Items) {
+		return "item at position " + strconv.Itoa(position)
+	}
+	item := container.lists[num].Items[position]
+	return container.describeEntry(item.TypeName, item.EntryId)
+}
+
+//Created list is empty unless it's restored after being deleted
+func (container *EntriesContainer) createList(record JournalRecord) (JournalRecord, error) {
+	list := record.List.copy()
+	if list.Name == "" {
+		return JournalRecord{}, errors.New("Cannot create list with an empty name")
+	} else if _, exists := container.listNum(list.Name); exists {
+		return JournalRecord{}, errors.New("List with name '" + list.Name + "' already exists")
+	}
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	container.lists = append(container.lists, list)
+	sort.Slice(container.lists, func(i, j int) bool { return container.lists[i].Name < container.lists[j].Name })
+	container.notifyListenersAboutChange(ChangeEvent{Kind: ListCreatedChange, ListAfter: &list})
+	return JournalRecord{Operation: DeleteListOperation, ListName: list.Name}, nil
+}
+
+//Deleted list is restored with all of its items when the deletion gets reverted
+func (container *EntriesContainer) deleteList(record JournalRecord) (JournalRecord, error) {
+	num, exists := container.listNum(record.ListName)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot delete list with name '" + record.ListName + "' as there is no such list")
+	}
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	list := container.lists[num]
+	container.lists = append(container.lists[:num:num], container.lists[num+1:]...)
+	container.notifyListenersAboutChange(ChangeEvent{Kind: ListDeletedChange, ListBefore: &list})
+	return JournalRecord{Operation: CreateListOperation, List: &list}, nil
+}
+
+//Only existing entries can be added and every entry can be in a list only once
+func (container *EntriesContainer) addToList(record JournalRecord) (JournalRecord, error) {
+	return container.updateList(record.ListName, "add entry to", record, func(list EntriesList) (EntriesList, JournalRecord, error) {
+		entry, exists := container.entryWithId(record.TypeName, record.EntryId)
+		if !exists {
+			return list, JournalRecord{}, errors.New("Cannot add entry with id " + strconv.Itoa(record.EntryId) + " of entry type '" + record.TypeName + "' to list '" + list.Name + "' as no such entry exists")
+		} else if _, inList := list.PositionOf(record.TypeName, record.EntryId); inList {
+			return list, JournalRecord{}, errors.New("Entry '" + entry.Title + "' is already in list '" + list.Name + "'")
+		} else if record.Position < 0 || record.Position > len(list.Items) {
+			return list, JournalRecord{}, errors.New("Cannot add entry '" + entry.Title + "' to list '" + list.Name + "' at position " + strconv.Itoa(record.Position))
+		}
+		item := ListItem{TypeName: record.TypeName, EntryId: record.EntryId}
+		list.Items = append(list.Items[:record.Position], append([]ListItem{item}, list.Items[record.Position:]...)...)
+		return list, JournalRecord{Operation: RemoveFromListOperation, ListName: list.Name, Position: record.Position}, nil
+	})
+}
+
+func (container *EntriesContainer) removeFromList(record JournalRecord) (JournalRecord, error) {
+	return container.updateList(record.ListName, "remove entry from", record, func(list EntriesList) (EntriesList, JournalRecord, error) {
+		if record.Position < 0 || record.Position >= len(list.Items) {
+			return list, JournalRecord{}, errors.New("Cannot remove item at position " + strconv.Itoa(record.Position) + " from list '" + list.Name + "' as there is no such item")
+		}
+		item := list.Items[record.Position]
+		list.Items = append(list.Items[:record.Position], list.Items[record.Position+1:]...)
+		return list, JournalRecord{Operation: AddToListOperation, ListName: list.Name, TypeName: item.TypeName, EntryId: item.EntryId, Position: record.Position}, nil
+	})
+}
+
+func (container *EntriesContainer) moveInList(record JournalRecord) (JournalRecord, error) {
+	return container.updateList(record.ListName, "move entry in", record, func(list EntriesList) (EntriesList, JournalRecord, error) {
+		newPosition := record.Position + record.Amount
+		if record.Position < 0 || record.Position >= len(list.Items) || newPosition < 0 || newPosition >= len(list.Items) {
+			return list, JournalRecord{}, errors.New("Cannot move item at position " + strconv.Itoa(record.Position) + " of list '" + list.Name + "' to position " + strconv.Itoa(newPosition))
+		}
+		item := list.Items[record.Position]
+		list.Items = append(list.Items[:record.Position], list.Items[record.Position+1:]...)
+		list.Items = append(list.Items[:newPosition], append([]ListItem{item}, list.Items[newPosition:]...)...)
+		return list, JournalRecord{Operation: MoveInListOperation, ListName: list.Name, Position: newPosition, Amount: -record.Amount}, nil
+	})
+}
+
+//Change is made on a copy of the list, so the list stays the same if the change fails
+func (container *EntriesContainer) updateList(listName string, changeName string, record JournalRecord, change func(EntriesList) (EntriesList, JournalRecord, error)) (JournalRecord, error) {
+	num, exists := container.listNum(listName)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot " + changeName + " list '" + listName + "' as no such list exists")
+	}
+	listBefore := container.lists[num]
+	listAfter, revertingRecord, err := change(listBefore.copy())
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	container.lists[num] = listAfter
+	container.notifyListenersAboutChange(ChangeEvent{Kind: ListUpdatedChange, ListBefore: &listBefore, ListAfter: &listAfter})
+	return revertingRecord, nil
+}
+
+//Moving items is a part of moving their entries to another entry type so it's not recorded in the journal on its own.
+//Item that would refer to an entry the list already contains, which happens when an entry is merged into another one,
+//is removed instead.
+func (container *EntriesContainer) moveEntriesInLists(movedItems map[ListItem]ListItem) {
+	for num, listBefore := range container.lists {
+		listAfter := EntriesList{Name: listBefore.Name, Items: []ListItem{}}
+		moved := false
+		for _, item := range listBefore.Items {
+			if movedItem, exists := movedItems[item]; exists {
+				item = movedItem
+				moved = true
+			}
+			if _, exists := listAfter.PositionOf(item.TypeName, item.EntryId); !exists {
+				listAfter.Items = append(listAfter.Items, item)
+			}
+		}
+		if moved {
+			container.lists[num] = listAfter
+			container.notifyListenersAboutChange(ChangeEvent{Kind: ListUpdatedChange, ListBefore: &listBefore, ListAfter: &listAfter})
+		}
+	}
+}
+
+//Restored lists replace the lists with their names, as they are only restored along with the entries they contained
+func (container *EntriesContainer) restoreLists(lists []EntriesList) {
+	for _, list := range lists {
+		num, exists := container.listNum(list.Name)
+		if !exists {
+			continue
+		}
+		listBefore, listAfter := container.lists[num], list.copy()
+		container.lists[num] = listAfter
+		container.notifyListenersAboutChange(ChangeEvent{Kind: ListUpdatedChange, ListBefore: &listBefore, ListAfter: &listAfter})
+	}
+}
+
+//Renaming items is a part of renaming the entry type so it's not recorded in the journal on its own
+func (container *EntriesContainer) renameEntryTypeInLists(oldName string, newName string) {
+	for num, listBefore := range container.lists {
+		listAfter := listBefore.copy()
+		renamed := false
+		for i, item := range listAfter.Items {
+			if item.TypeName == oldName {
+				listAfter.Items[i].TypeName = newName
+				renamed = true
+			}
+		}
+		if renamed {
+			container.lists[num] = listAfter
+			container.notifyListenersAboutChange(ChangeEvent{Kind: ListUpdatedChange, ListBefore: &listBefore, ListAfter: &listAfter})
+		}
+	}
+}
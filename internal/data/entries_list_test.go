@@ -0,0 +1,162 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func createContainerWithTestList() *EntriesContainer {
+	container := createLoadedTestContainer()
+	_ = container.CreateList("favourites")
+	_ = container.AddToList("favourites", "comics", 0)
+	_ = container.AddToList("favourites", "music", 1)
+	_ = container.AddToList("favourites", "videos", 0)
+	return container
+}
+
+func TestThatEntriesOfAnyTypeCanBeAddedToList(t *testing.T) {
+	container := createContainerWithTestList()
+	list, err := container.ListWithName("favourites")
+	assert.Nil(t, err)
+	assert.Equal(t, []ListItem{{"comics", 0}, {"music", 1}, {"videos", 0}}, list.Items)
+	position, exists := list.PositionOf("music", 1)
+	assert.True(t, exists)
+	assert.Equal(t, 1, position)
+	_, exists = list.PositionOf("music", 0)
+	assert.False(t, exists)
+}
+
+func TestThatListsAreSortedByNames(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.CreateList("watch with friends"))
+	assert.Nil(t, container.CreateList("top 10"))
+	lists := container.Lists()
+	assert.Equal(t, 2, len(lists))
+	assert.Equal(t, "top 10", lists[0].Name)
+	assert.Equal(t, "watch with friends", lists[1].Name)
+}
+
+func TestThatInvalidChangesOfListsAreNotMade(t *testing.T) {
+	container := createContainerWithTestList()
+	assert.Equal(t, "Cannot create list with an empty name", container.CreateList("").Error())
+	assert.Equal(t, "List with name 'favourites' already exists", container.CreateList("favourites").Error())
+	assert.Equal(t, "Entry 'some comic1' is already in list 'favourites'", container.AddToList("favourites", "comics", 0).Error())
+	assert.Equal(t, "Cannot add entry with id 7 of entry type 'comics' to list 'favourites' as no such entry exists", container.AddToList("favourites", "comics", 7).Error())
+	assert.Equal(t, "Cannot add entry to list 'other' as no such list exists", container.AddToList("other", "comics", 0).Error())
+	assert.Equal(t, "Cannot remove item at position 3 from list 'favourites' as there is no such item", container.RemoveFromList("favourites", 3).Error())
+	assert.Equal(t, "Cannot move item at position 0 of list 'favourites' to position -1", container.MoveInList("favourites", 0, -1).Error())
+	assert.Equal(t, "Cannot delete list with name 'other' as there is no such list", container.DeleteList("other").Error())
+	list, _ := container.ListWithName("favourites")
+	assert.Equal(t, 3, len(list.Items))
+}
+
+func TestThatEntriesCanBeRemovedFromListAndReordered(t *testing.T) {
+	container := createContainerWithTestList()
+	assert.Nil(t, container.MoveInList("favourites", 0, 2))
+	list, _ := container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"music", 1}, {"videos", 0}, {"comics", 0}}, list.Items)
+	assert.Nil(t, container.RemoveFromList("favourites", 1))
+	list, _ = container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"music", 1}, {"comics", 0}}, list.Items)
+}
+
+func TestThatChangesOfListsCanBeUndone(t *testing.T) {
+	container := createContainerWithTestList()
+	assert.Nil(t, container.MoveInList("favourites", 2, -2))
+	assert.Nil(t, container.RemoveFromList("favourites", 1))
+	assert.Nil(t, container.DeleteList("favourites"))
+	assert.Empty(t, container.Lists())
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "deleting list 'favourites'", change.Description)
+	_, _ = container.Undo()
+	_, _ = container.Undo()
+	list, _ := container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"music", 1}, {"videos", 0}}, list.Items)
+	change, _ = container.Redo()
+	assert.Equal(t, "moving entry 'some video1' in list 'favourites'", change.Description)
+	list, _ = container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"videos", 0}, {"comics", 0}, {"music", 1}}, list.Items)
+}
+
+func TestThatItemsOfListsFollowRenamedEntryType(t *testing.T) {
+	container := createContainerWithTestList()
+	var events []ChangeEvent
+	container.SubscribeToChanges(func(event ChangeEvent) { events = append(events, event) })
+	assert.Nil(t, container.UpdateEntryType("comics", EntryType{Name: "manga"}))
+	list, _ := container.ListWithName("favourites")
+	assert.Equal(t, ListItem{"manga", 0}, list.Items[0])
+	assert.Equal(t, ListUpdatedChange, events[1].Kind)
+	assert.Equal(t, "comics", events[1].ListBefore.Items[0].TypeName)
+	_, _ = container.Undo()
+	list, _ = container.ListWithName("favourites")
+	assert.Equal(t, ListItem{"comics", 0}, list.Items[0])
+}
+
+func TestThatItemsOfDeletedEntryTypeAreKeptInList(t *testing.T) {
+	container := createContainerWithTestList()
+	assert.Nil(t, container.DeleteEntryType("comics"))
+	list, _ := container.ListWithName("favourites")
+	assert.Equal(t, 3, len(list.Items))
+}
+
+func TestThatReturnedListsCannotBeUsedToChangeListsInContainer(t *testing.T) {
+	container := createContainerWithTestList()
+	container.Lists()[0].Items[0].EntryId = 5
+	list, _ := container.ListWithName("favourites")
+	list.Items[1].EntryId = 5
+	list, _ = container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"music", 1}, {"videos", 0}}, list.Items)
+}
+
+func TestThatChangesOfListsAreReplayedFromJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := createLoadedTestContainer()
+	container.SetJournal(journal)
+	_ = container.CreateList("favourites")
+	_ = container.AddToList("favourites", "comics", 0)
+	_ = container.AddToList("favourites", "music", 1)
+	_ = container.MoveInList("favourites", 1, -1)
+	replayingContainer := createLoadedTestContainer()
+	replayingContainer.SetJournal(journal)
+	amount, err := replayingContainer.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, amount)
+	assert.Equal(t, container.Lists(), replayingContainer.Lists())
+}
+
+func TestThatListsAreSavedAndLoaded(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	dataProvider := NewBoltProvider(testDbPath)
+	lists, err := dataProvider.LoadLists()
+	assert.Nil(t, err)
+	assert.Empty(t, lists)
+	savedLists := []EntriesList{{Name: "empty", Items: []ListItem{}}, {Name: "favourites", Items: []ListItem{{"comics", 0}, {"music", 1}}}}
+	assert.Nil(t, dataProvider.SaveLists(savedLists))
+	lists, err = dataProvider.LoadLists()
+	assert.Nil(t, err)
+	assert.Equal(t, savedLists, lists)
+	assert.Nil(t, dataProvider.SaveLists(savedLists[1:]))
+	lists, err = dataProvider.LoadLists()
+	assert.Nil(t, err)
+	assert.Equal(t, savedLists[1:], lists)
+}
+
+func TestThatListsAreLoadedAndSavedWithEntries(t *testing.T) {
+	provider := NewAbstractProvider()
+	provider.LoadListsFunc = func() ([]EntriesList, error) {
+		return []EntriesList{{Name: "watched"}, {Name: "favourites"}}, nil
+	}
+	var savedLists []EntriesList
+	provider.SaveListsFunc = func(lists []EntriesList) error {
+		savedLists = lists
+		return nil
+	}
+	container := NewEntriesContainer(provider)
+	assert.Nil(t, container.LoadData())
+	assert.Equal(t, "favourites", container.Lists()[0].Name)
+	assert.Nil(t, container.SaveData())
+	assert.Equal(t, 2, len(savedLists))
+}
@@ -1,6 +1,11 @@
 package data
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
 
 type EntryStatus string
 
@@ -12,6 +17,27 @@ const (
 	PlannedStatus    EntryStatus = "Planned"
 )
 
+//Layout of dates stored in entries, e.g. 31/12/2020
+const DateLayout = "02/01/2006"
+
+func EntryStatuses() []EntryStatus {
+	return []EntryStatus{InProgressStatus, CompletedStatus, OnHoldStatus, DroppedStatus, PlannedStatus}
+}
+
+func IsValidStatus(status EntryStatus) bool {
+	for _, validStatus := range EntryStatuses() {
+		if status == validStatus {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidDate(date string) bool {
+	_, err := time.Parse(DateLayout, date)
+	return err == nil
+}
+
 type Entry struct {
 	Id                              int
 	Status                          EntryStatus
@@ -26,18 +52,120 @@ type Entry struct {
 	Comment                         string
 	Tags                            string
 	ImageQuery                      string
+	ConsumptionHistory              []ConsumptionEvent
+	//Hash of the cover image in the image store, empty if the entry has no cover
+	Cover string `json:",omitempty"`
 }
 
 func (entry Entry) String() string {
 	return fmt.Sprintf("%#v", entry)
 }
 
+//Tags are separated with commas, whitespace around them is not a part of them
+func (entry Entry) TagsList() []string {
+	tags := []string{}
+	for _, tag := range strings.Split(entry.Tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+//Returns the date of the most recent thing that happened to the entry, which is either an event in its consumption
+//history or its start or finish, if there was anything
+func (entry Entry) LastActivityDate() (time.Time, bool) {
+	lastDate, exists := time.Time{}, false
+	dates := []time.Time{}
+	for _, event := range entry.ConsumptionHistory {
+		dates = append(dates, event.Date)
+	}
+	for _, date := range []string{entry.StartDate, entry.FinishDate} {
+		parsedDate, err := time.ParseInLocation(DateLayout, date, time.Local)
+		if err == nil {
+			dates = append(dates, parsedDate)
+		}
+	}
+	for _, date := range dates {
+		if !exists || date.After(lastDate) {
+			lastDate, exists = date, true
+		}
+	}
+	return lastDate, exists
+}
+
 type EntryType struct {
 	Name                  string
 	CompletionElementName string
 	ImageQuery            string
+	//Template of URL used to search for metadata of entries, see metadata.HTTPProvider for details
+	MetadataURL string
+	//Statuses that entries of the type can have, see TypeStatus
+	Statuses      string
+	ScoringSystem ScoringSystem
 }
 
 func (entryType EntryType) String() string {
 	return fmt.Sprintf("%#v", entryType)
 }
+
+//Series and lists are displayed along with entry types, e.g. in tabs, under the name or names with the prefixes,
+//so names of entry types can't be the same
+const SeriesDisplayName = "Series"
+const ListDisplayNamePrefix = "List: "
+const SmartListDisplayNamePrefix = "Smart list: "
+
+func reservedTypeNameError(typeName string) error {
+	if typeName == SeriesDisplayName {
+		return errors.New("Entry type cannot be named '" + typeName + "' as series are displayed under that name")
+	}
+	for _, prefix := range []string{ListDisplayNamePrefix, SmartListDisplayNamePrefix} {
+		if strings.HasPrefix(typeName, prefix) {
+			return errors.New("Name of entry type '" + typeName + "' cannot start with '" + prefix + "' as lists are displayed under such names")
+		}
+	}
+	return nil
+}
+
+//Returns a copy of the entry with amount of completed elements changed by the given amount (negative amount decreases it).
+//Amount of completed elements never goes below 0 or, if total amount is known, above it.
+//Status and dates follow the progress i.e. starting an entry marks it as in progress and sets its start date if it's not set,
+//completing all of the elements marks it as completed and sets its finish date if it's not set
+//and going back from a completed entry marks it as in progress again and clears its finish date.
+//Statuses given to the entry are the ones of its entry type in those categories, see EntryType.StatusIn.
+//The changes are recorded in the consumption history of the entry.
+func (entry Entry) WithProgressChangedBy(entryType EntryType, amount int, date time.Time) Entry {
+	originalEntry := entry
+	completed := entry.ElementsCompleted + amount
+	if completed < 0 {
+		completed = 0
+	}
+	total := entry.TotalAmountOfElementsToComplete
+	if total > 0 && completed > total {
+		completed = total
+	}
+	if completed == entry.ElementsCompleted {
+		return entry
+	}
+	formattedDate := date.Format(DateLayout)
+	if entry.ElementsCompleted == 0 && completed > 0 {
+		entry.Status = entryType.StatusIn(InProgressStatus)
+		if entry.StartDate == "" {
+			entry.StartDate = formattedDate
+		}
+	}
+	if total > 0 && completed == total {
+		if entryType.CategoryOf(entry.Status) != CompletedStatus {
+			entry.Status = entryType.StatusIn(CompletedStatus)
+		}
+		if entry.FinishDate == "" {
+			entry.FinishDate = formattedDate
+		}
+	} else if entryType.CategoryOf(entry.Status) == CompletedStatus {
+		entry.Status = entryType.StatusIn(InProgressStatus)
+		entry.FinishDate = ""
+	}
+	entry.ElementsCompleted = completed
+	return originalEntry.withEventsOfChangesTo(entryType, entry, date)
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDateValidation(t *testing.T) {
+	assert.True(t, IsValidDate("31/12/2020"))
+	assert.True(t, IsValidDate("01/01/1990"))
+	assert.False(t, IsValidDate("12/31/2020"))
+	assert.False(t, IsValidDate("1/1/1990/"))
+	assert.False(t, IsValidDate(""))
+}
+
+func TestStatusValidation(t *testing.T) {
+	assert.True(t, IsValidStatus(PlannedStatus))
+	assert.True(t, IsValidStatus("On hold"))
+	assert.False(t, IsValidStatus("on hold"))
+	assert.False(t, IsValidStatus(""))
+}
+
+var progressChangeDate = time.Date(2020, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+func TestThatChangingProgressIsLimitedByZeroAndTotalAmount(t *testing.T) {
+	entry := Entry{ElementsCompleted: 2, TotalAmountOfElementsToComplete: 5, Status: InProgressStatus}
+	assert.Equal(t, 0, entry.WithProgressChangedBy(EntryType{}, -10, progressChangeDate).ElementsCompleted)
+	assert.Equal(t, 5, entry.WithProgressChangedBy(EntryType{}, 10, progressChangeDate).ElementsCompleted)
+	assert.Equal(t, 3, entry.WithProgressChangedBy(EntryType{}, 1, progressChangeDate).ElementsCompleted)
+	entry.TotalAmountOfElementsToComplete = 0
+	assert.Equal(t, 12, entry.WithProgressChangedBy(EntryType{}, 10, progressChangeDate).ElementsCompleted)
+}
+
+func TestThatStartingEntryMarksItAsInProgressAndSetsStartDate(t *testing.T) {
+	entry := Entry{Status: PlannedStatus, TotalAmountOfElementsToComplete: 5}
+	changedEntry := entry.WithProgressChangedBy(EntryType{}, 1, progressChangeDate)
+	assert.Equal(t, InProgressStatus, changedEntry.Status)
+	assert.Equal(t, "14/03/2020", changedEntry.StartDate)
+	entry.StartDate = "01/01/2020"
+	assert.Equal(t, "01/01/2020", entry.WithProgressChangedBy(EntryType{}, 1, progressChangeDate).StartDate)
+}
+
+func TestThatCompletingAllElementsMarksEntryAsCompletedAndSetsFinishDate(t *testing.T) {
+	entry := Entry{Status: InProgressStatus, ElementsCompleted: 4, TotalAmountOfElementsToComplete: 5, StartDate: "01/01/2020"}
+	changedEntry := entry.WithProgressChangedBy(EntryType{}, 1, progressChangeDate)
+	assert.Equal(t, CompletedStatus, changedEntry.Status)
+	assert.Equal(t, "14/03/2020", changedEntry.FinishDate)
+	assert.Equal(t, "01/01/2020", changedEntry.StartDate)
+}
+
+func TestThatGoingBackFromCompletedEntryMarksItAsInProgressAndClearsFinishDate(t *testing.T) {
+	entry := Entry{Status: CompletedStatus, ElementsCompleted: 5, TotalAmountOfElementsToComplete: 5, FinishDate: "01/02/2020"}
+	changedEntry := entry.WithProgressChangedBy(EntryType{}, -1, progressChangeDate)
+	assert.Equal(t, InProgressStatus, changedEntry.Status)
+	assert.Empty(t, changedEntry.FinishDate)
+}
+
+func TestThatEntryDoesNotChangeWhenProgressCannotChange(t *testing.T) {
+	entry := Entry{Status: CompletedStatus, ElementsCompleted: 5, TotalAmountOfElementsToComplete: 5, FinishDate: "01/02/2020"}
+	assert.Equal(t, entry, entry.WithProgressChangedBy(EntryType{}, 3, progressChangeDate))
+	entry = Entry{Status: PlannedStatus}
+	assert.Equal(t, entry, entry.WithProgressChangedBy(EntryType{}, -1, progressChangeDate))
+}
+
+func TestThatTagsOfEntryAreSeparatedWithCommas(t *testing.T) {
+	assert.Equal(t, []string{"horror", "old movies"}, Entry{Tags: " horror,, old movies ,"}.TagsList())
+	assert.Empty(t, Entry{}.TagsList())
+}
+
+func TestThatLastActivityOfEntryIsTheMostRecentOfItsDatesAndEvents(t *testing.T) {
+	_, exists := Entry{}.LastActivityDate()
+	assert.False(t, exists)
+	entry := Entry{StartDate: "01/01/2020", FinishDate: "05/01/2020"}
+	date, exists := entry.LastActivityDate()
+	assert.True(t, exists)
+	assert.Equal(t, time.Date(2020, time.January, 5, 0, 0, 0, 0, time.Local), date)
+	eventDate := time.Date(2020, time.February, 1, 10, 0, 0, 0, time.UTC)
+	entry.ConsumptionHistory = []ConsumptionEvent{{Kind: RewatchStartedEvent, Date: eventDate}}
+	date, _ = entry.LastActivityDate()
+	assert.Equal(t, eventDate, date)
+}
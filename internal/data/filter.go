@@ -0,0 +1,151 @@
+package data
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+Filter describes which entries should be chosen, e.g. entries that are planned and tagged 'horror', so it can be saved
+and matched against entries whenever they change. Every criterion that is set has to be met, criteria that are not set,
+i.e. have zero values, are met by every entry.
+*/
+type Filter struct {
+	//Entry has to have one of the statuses or a status that belongs to one of them, see TypeStatus
+	Statuses []EntryStatus
+	//Entry has to have all of the tags, which are compared ignoring case
+	Tags []string
+	//Entry has to belong to one of the entry types
+	TypeNames []string
+	//Title of the entry has to contain the text, ignoring case
+	TitleContains string
+	//Score normalized to the scale from 1 to 10 has to be at least that high, see ScoringSystem.NormalizedScore
+	MinScore int
+	//Nothing could have happened to the entry for at least that many days, see Entry.LastActivityDate
+	NotTouchedForDays int
+	//Entry has to be of one of the kinds in relation to some other entry, e.g. an adaptation of it, see Relation.
+	//Relations are kept in the entries container, so it's only checked by EntriesContainer.EntriesMatching.
+	RelatedAs []RelationKind
+}
+
+//Matches the entry of the given entry type by all criteria except RelatedAs. Time that has passed since the last
+//activity is counted up to the given time.
+func (filter Filter) Matches(entryType EntryType, entry Entry, now time.Time) bool {
+	return filter.matchesStatus(entryType, entry) &&
+		filter.matchesTags(entry) &&
+		filter.matchesTypeName(entryType) &&
+		strings.Contains(strings.ToLower(entry.Title), strings.ToLower(filter.TitleContains)) &&
+		entryType.NormalizedScoreOf(entry) >= float64(filter.MinScore) &&
+		filter.matchesInactivity(entry, now)
+}
+
+func (filter Filter) matchesStatus(entryType EntryType, entry Entry) bool {
+	if len(filter.Statuses) == 0 {
+		return true
+	}
+	for _, status := range filter.Statuses {
+		if entry.Status == status || entryType.CategoryOf(entry.Status) == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (filter Filter) matchesTags(entry Entry) bool {
+	entryTags := make(map[string]bool)
+	for _, tag := range entry.TagsList() {
+		entryTags[strings.ToLower(tag)] = true
+	}
+	for _, tag := range filter.Tags {
+		if !entryTags[strings.ToLower(tag)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (filter Filter) matchesTypeName(entryType EntryType) bool {
+	if len(filter.TypeNames) == 0 {
+		return true
+	}
+	for _, typeName := range filter.TypeNames {
+		if entryType.Name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+//Entry is of the inverse kind of its relations in relation to their related entries, e.g. the source of its adaptation
+func (filter Filter) matchesRelations(relations []Relation) bool {
+	if len(filter.RelatedAs) == 0 {
+		return true
+	}
+	for _, kind := range filter.RelatedAs {
+		for _, relation := range relations {
+			if relation.Kind.Inverse() == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//Entry with no activity at all has not been touched for any amount of days
+func (filter Filter) matchesInactivity(entry Entry, now time.Time) bool {
+	if filter.NotTouchedForDays <= 0 {
+		return true
+	}
+	lastActivityDate, exists := entry.LastActivityDate()
+	return !exists || now.Sub(lastActivityDate) >= time.Duration(filter.NotTouchedForDays)*24*time.Hour
+}
+
+//Status is valid in a filter if it's one of the built-in statuses or a status of any of the entry types
+func (container *EntriesContainer) IsValidStatus(status EntryStatus) bool {
+	if IsValidStatus(status) {
+		return true
+	}
+	for entryType := range container.entries {
+		if entryType.HasStatus(status) {
+			return true
+		}
+	}
+	return false
+}
+
+//Criteria with many values, e.g. statuses, are typed in as values separated with commas, e.g. 'Planned, On hold'.
+//Values are trimmed and empty values are skipped.
+func SplitCommaSeparatedValues(text string) []string {
+	values := []string{}
+	for _, value := range strings.Split(text, ",") {
+		if strings.TrimSpace(value) != "" {
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+	return values
+}
+
+//Entry of an entry type that matched a filter
+type FilteredEntry struct {
+	Type  EntryType
+	Entry Entry
+}
+
+//Returns the matching entries sorted by names of their types, entries of a type are in the same order as in the type
+func (container *EntriesContainer) EntriesMatching(filter Filter, now time.Time) []FilteredEntry {
+	entryTypes := []EntryType{}
+	for entryType := range container.entries {
+		entryTypes = append(entryTypes, entryType)
+	}
+	sort.Slice(entryTypes, func(i, j int) bool { return entryTypes[i].Name < entryTypes[j].Name })
+	matchingEntries := []FilteredEntry{}
+	for _, entryType := range entryTypes {
+		for _, entry := range container.entries[entryType] {
+			if filter.Matches(entryType, entry, now) && filter.matchesRelations(container.RelationsOf(entryType.Name, entry.Id)) {
+				matchingEntries = append(matchingEntries, FilteredEntry{entryType, entry})
+			}
+		}
+	}
+	return matchingEntries
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var filterTestNow = time.Date(2020, time.June, 30, 12, 0, 0, 0, time.Local)
+
+func TestThatEmptyFilterMatchesEveryEntry(t *testing.T) {
+	assert.True(t, Filter{}.Matches(comicsEntryType, Entry{}, filterTestNow))
+	assert.True(t, Filter{}.Matches(musicEntryType, GetExampleMusicEntries()[0], filterTestNow))
+}
+
+func TestThatEveryCriterionOfFilterHasToBeMet(t *testing.T) {
+	entry := Entry{Status: PlannedStatus, Title: "Some Horror Movie", Tags: "horror, Classic", Score: 7}
+	filter := Filter{Statuses: []EntryStatus{PlannedStatus, OnHoldStatus}, Tags: []string{"classic", "horror"}, TypeNames: []string{"videos"}, TitleContains: "horror", MinScore: 7}
+	assert.True(t, filter.Matches(videoEntryType, entry, filterTestNow))
+	assert.False(t, filter.Matches(comicsEntryType, entry, filterTestNow))
+	entry.Status = DroppedStatus
+	assert.False(t, filter.Matches(videoEntryType, entry, filterTestNow))
+	entry.Status = OnHoldStatus
+	entry.Tags = "horror"
+	assert.False(t, filter.Matches(videoEntryType, entry, filterTestNow))
+	entry.Tags = "horror,classic"
+	entry.Score = 6
+	assert.False(t, filter.Matches(videoEntryType, entry, filterTestNow))
+	entry.Score = 8
+	entry.Title = "comedy"
+	assert.False(t, filter.Matches(videoEntryType, entry, filterTestNow))
+}
+
+func TestThatFilterMatchesEntriesNotTouchedForGivenAmountOfDays(t *testing.T) {
+	filter := Filter{NotTouchedForDays: 90}
+	assert.True(t, filter.Matches(comicsEntryType, Entry{}, filterTestNow))
+	entry := Entry{StartDate: "01/03/2020"}
+	assert.True(t, filter.Matches(comicsEntryType, entry, filterTestNow))
+	entry.StartDate = "02/04/2020"
+	assert.False(t, filter.Matches(comicsEntryType, entry, filterTestNow))
+	entry.StartDate = "01/01/2020"
+	entry.ConsumptionHistory = []ConsumptionEvent{{Kind: ProgressChangedEvent, Date: filterTestNow.AddDate(0, 0, -10)}}
+	assert.False(t, filter.Matches(comicsEntryType, entry, filterTestNow))
+}
+
+func TestThatCommaSeparatedValuesAreTrimmedAndEmptyOnesAreSkipped(t *testing.T) {
+	assert.Equal(t, []string{"Planned", "On hold"}, SplitCommaSeparatedValues(" Planned, ,On hold,"))
+	assert.Equal(t, []string{}, SplitCommaSeparatedValues(""))
+}
+
+func TestThatEntriesMatchingFilterAreSortedByTheirTypes(t *testing.T) {
+	container := createLoadedTestContainer()
+	matchingEntries := container.EntriesMatching(Filter{MinScore: 5, TitleContains: "2"}, filterTestNow)
+	assert.Equal(t, 3, len(matchingEntries))
+	assert.Equal(t, "comics", matchingEntries[0].Type.Name)
+	assert.Equal(t, "some comic2", matchingEntries[0].Entry.Title)
+	assert.Equal(t, "videos", matchingEntries[2].Type.Name)
+	assert.Equal(t, "some video2", matchingEntries[2].Entry.Title)
+	assert.Empty(t, container.EntriesMatching(Filter{Statuses: []EntryStatus{DroppedStatus}}, filterTestNow))
+}
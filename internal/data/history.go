@@ -0,0 +1,110 @@
+package data
+
+import "github.com/pkg/errors"
+
+/*
+History of changes made to entries, which allows undoing and redoing them. Every change is kept as a record of the change
+that reverts it, e.g. a deleted entry type is kept as a record restoring it with all of its entries, so undoing a change
+is just applying its record, which in turn returns the record of the change that can be redone.
+*/
+
+//Amount of changes that can be undone when it's not set otherwise
+const DefaultUndoDepth = 100
+
+type history struct {
+	//The most recently made changes are at the ends of the stacks
+	undoStack []historyItem
+	redoStack []historyItem
+	maxDepth  int
+}
+
+type historyItem struct {
+	description string
+	record      JournalRecord
+}
+
+//Describes a change that has been undone or redone
+type HistoryChange struct {
+	//Describes the change as it was originally made by the user, e.g. "adding entry type 'comics'"
+	Description string
+	//Change that has been made to undo or redo the original change
+	Made JournalRecord
+}
+
+func newHistory(maxDepth int) history {
+	return history{undoStack: []historyItem{}, redoStack: []historyItem{}, maxDepth: maxDepth}
+}
+
+//New change makes the changes that could be redone obsolete
+func (history *history) changeMade(item historyItem) {
+	history.pushUndoable(item)
+	history.redoStack = []historyItem{}
+}
+
+//When there are more changes than the depth allows, the oldest ones are forgotten
+func (history *history) pushUndoable(item historyItem) {
+	history.undoStack = append(history.undoStack, item)
+	if len(history.undoStack) > history.maxDepth {
+		history.undoStack = history.undoStack[len(history.undoStack)-history.maxDepth:]
+	}
+}
+
+func (history *history) setMaxDepth(maxDepth int) {
+	history.maxDepth = maxDepth
+	if len(history.undoStack) > maxDepth {
+		history.undoStack = history.undoStack[len(history.undoStack)-maxDepth:]
+	}
+	if len(history.redoStack) > maxDepth {
+		history.redoStack = history.redoStack[len(history.redoStack)-maxDepth:]
+	}
+}
+
+func (history *history) clear() {
+	history.undoStack = []historyItem{}
+	history.redoStack = []historyItem{}
+}
+
+func popHistoryItem(stack *[]historyItem) historyItem {
+	item := (*stack)[len(*stack)-1]
+	*stack = (*stack)[:len(*stack)-1]
+	return item
+}
+
+//Depth has to be greater than 0, otherwise nothing could be undone
+func (container *EntriesContainer) SetUndoDepth(depth int) error {
+	if depth <= 0 {
+		return errors.New("Undo depth has to be greater than 0")
+	}
+	container.history.setMaxDepth(depth)
+	return nil
+}
+
+//Reverts the most recent change that has not been undone yet. Like any other change, undoing is recorded in the journal.
+func (container *EntriesContainer) Undo() (HistoryChange, error) {
+	if len(container.history.undoStack) == 0 {
+		return HistoryChange{}, errors.New("There is nothing to undo")
+	}
+	item := container.history.undoStack[len(container.history.undoStack)-1]
+	redoRecord, err := container.apply(item.record)
+	if err != nil {
+		return HistoryChange{}, errors.Wrap(err, "Failed to undo "+item.description)
+	}
+	popHistoryItem(&container.history.undoStack)
+	container.history.redoStack = append(container.history.redoStack, historyItem{item.description, redoRecord})
+	return HistoryChange{Description: item.description, Made: item.record}, nil
+}
+
+//Makes again the most recently undone change
+func (container *EntriesContainer) Redo() (HistoryChange, error) {
+	if len(container.history.redoStack) == 0 {
+		return HistoryChange{}, errors.New("There is nothing to redo")
+	}
+	item := container.history.redoStack[len(container.history.redoStack)-1]
+	undoRecord, err := container.apply(item.record)
+	if err != nil {
+		return HistoryChange{}, errors.Wrap(err, "Failed to redo "+item.description)
+	}
+	popHistoryItem(&container.history.redoStack)
+	container.history.pushUndoable(historyItem{item.description, undoRecord})
+	return HistoryChange{Description: item.description, Made: item.record}, nil
+}
@@ -0,0 +1,141 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"wirwl/internal/log"
+)
+
+func createLoadedTestContainer() *EntriesContainer {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return container
+}
+
+func TestThatEveryChangeCanBeUndoneAndRedone(t *testing.T) {
+	container := createLoadedTestContainer()
+	updatedEntry := GetExampleComicEntries()[1]
+	updatedEntry.Title = "updated title"
+	changes := []func() error{
+		func() error { return container.AddEntryType(EntryType{Name: "books"}) },
+		func() error { return container.UpdateEntryType("music", EntryType{Name: "albums"}) },
+		func() error { return container.DeleteEntryType("videos") },
+		func() error { return container.UpdateEntry("comics", updatedEntry) },
+		func() error { return container.ChangeEntryProgress("comics", 0, 1) },
+	}
+	states := []map[EntryType][]Entry{copyOfEntries(container)}
+	for _, change := range changes {
+		assert.Nil(t, change())
+		states = append(states, copyOfEntries(container))
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		_, err := container.Undo()
+		assert.Nil(t, err)
+		assert.Equal(t, states[i], container.EntriesGroupedByType())
+	}
+	for i := 1; i <= len(changes); i++ {
+		_, err := container.Redo()
+		assert.Nil(t, err)
+		assert.Equal(t, states[i], container.EntriesGroupedByType())
+	}
+}
+
+func copyOfEntries(container *EntriesContainer) map[EntryType][]Entry {
+	entries := map[EntryType][]Entry{}
+	for entryType, entriesOfType := range container.EntriesGroupedByType() {
+		entries[entryType] = append([]Entry{}, entriesOfType...)
+	}
+	return entries
+}
+
+func TestThatUndoneAndRedoneChangesAreDescribed(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	assert.Nil(t, container.DeleteEntryType("videos"))
+	assert.Nil(t, container.UpdateEntryType("music", EntryType{Name: "albums"}))
+	assert.Nil(t, container.UpdateEntry("comics", GetExampleComicEntries()[1]))
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, 1))
+	expectedDescriptions := []string{
+		"changing progress of entry 'some comic1'",
+		"editing entry 'some comic2'",
+		"editing entry type 'music'",
+		"deleting entry type 'videos'",
+		"adding entry type 'books'",
+	}
+	for _, expectedDescription := range expectedDescriptions {
+		change, err := container.Undo()
+		assert.Nil(t, err)
+		assert.Equal(t, expectedDescription, change.Description)
+	}
+	change, err := container.Redo()
+	assert.Nil(t, err)
+	assert.Equal(t, "adding entry type 'books'", change.Description)
+	assert.Equal(t, RestoreEntryTypeOperation, change.Made.Operation)
+	assert.Equal(t, "books", change.Made.EntryType.Name)
+}
+
+func TestThatErrorIsReturnedWhenThereIsNothingToUndoOrRedo(t *testing.T) {
+	container := createLoadedTestContainer()
+	_, err := container.Undo()
+	assert.Equal(t, "There is nothing to undo", err.Error())
+	_, err = container.Redo()
+	assert.Equal(t, "There is nothing to redo", err.Error())
+}
+
+func TestThatNewChangeMakesUndoneChangesImpossibleToRedo(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	_, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "games"}))
+	_, err = container.Redo()
+	assert.NotNil(t, err)
+	assert.False(t, container.typeWithNameExists("books"))
+}
+
+func TestThatOnlyChangesWithinUndoDepthCanBeUndone(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.SetUndoDepth(2))
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, 1))
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, -1))
+	assert.Nil(t, container.ChangeEntryProgress("comics", 0, -1))
+	_, err := container.Undo()
+	assert.Nil(t, err)
+	_, err = container.Undo()
+	assert.Nil(t, err)
+	_, err = container.Undo()
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, container.entries[comicsEntryType][0].ElementsCompleted)
+	assert.NotNil(t, container.SetUndoDepth(0))
+}
+
+func TestThatLoadingDataClearsHistory(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.AddEntryType(EntryType{Name: "books"}))
+	err := container.LoadData()
+	assert.Nil(t, err)
+	_, err = container.Undo()
+	assert.NotNil(t, err)
+}
+
+func TestThatUndoingAndRedoingIsRecordedInJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := createLoadedTestContainer()
+	container.SetJournal(journal)
+	assert.Nil(t, container.DeleteEntryType("videos"))
+	_, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Nil(t, container.ChangeEntryProgress("videos", 0, 1))
+	containerAfterCrash := createLoadedTestContainer()
+	containerAfterCrash.SetJournal(journal)
+	replayedAmount, err := containerAfterCrash.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, replayedAmount)
+	assert.Equal(t, container.EntriesGroupedByType(), containerAfterCrash.EntriesGroupedByType())
+	_, err = containerAfterCrash.Undo()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+/*
+Instance lock is a file in the app data directory that exists as long as a process, e.g. the application or the serve
+command, works with the collection in that directory, so other processes don't change the collection at the same time.
+The lock describes its owner, including the address of the owner's API, so other processes can tell who owns it and
+can pass their work to the owner. Lock whose owner doesn't respond at its address has been left behind by an owner that
+has not exited properly, so it's replaced when another process tries to acquire it.
+*/
+type InstanceLock struct {
+	path  string
+	owner LockOwner
+}
+
+const instanceLockFileName = "wirwl.lock"
+
+//Time for which an owner of a lock can take to respond before it's considered gone
+const lockOwnerResponseTimeout = time.Second
+
+type LockOwner struct {
+	Pid int
+	//Describes what the owner is, e.g. "application" or "serve command"
+	Description string
+	StartTime   time.Time
+	//Address of the owner's API and the token that requests to it need
+	Address string
+	Token   string
+}
+
+//Returned when acquiring a lock that is owned by another process
+type InstanceLockedError struct {
+	Owner LockOwner
+}
+
+func (err InstanceLockedError) Error() string {
+	return "The collection is already used by " + err.Owner.Description + " running as process " +
+		strconv.Itoa(err.Owner.Pid) + " since " + err.Owner.StartTime.Format("02/01/2006 15:04:05")
+}
+
+func instanceLockPath(dirPath string) string {
+	return filepath.Join(dirPath, instanceLockFileName)
+}
+
+//Fails with InstanceLockedError if the lock is owned by another process that responds
+func AcquireInstanceLock(dirPath string, owner LockOwner) (*InstanceLock, error) {
+	path := instanceLockPath(dirPath)
+	ownerAsJSON, err := json.Marshal(owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred when marshaling owner of the instance lock")
+	}
+	//The second attempt is made after a lock left behind has been removed
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if os.IsExist(err) {
+			currentOwner, isOwned, err := InstanceLockOwner(dirPath)
+			if err != nil {
+				return nil, err
+			}
+			if isOwned {
+				return nil, InstanceLockedError{currentOwner}
+			}
+			err = os.Remove(path)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, errors.Wrap(err, "An error occurred when removing the instance lock "+path+" left behind")
+			}
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "An error occurred when creating the instance lock "+path)
+		}
+		_, err = file.Write(ownerAsJSON)
+		closingErr := file.Close()
+		if err == nil {
+			err = closingErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+			return nil, errors.Wrap(err, "An error occurred when writing the instance lock "+path)
+		}
+		return &InstanceLock{path: path, owner: owner}, nil
+	}
+	return nil, errors.New("The instance lock " + path + " has been acquired by another process at the same time")
+}
+
+//Returns the owner of the lock in the directory and whether there is an owner that responds
+func InstanceLockOwner(dirPath string) (LockOwner, bool, error) {
+	path := instanceLockPath(dirPath)
+	ownerAsJSON, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return LockOwner{}, false, nil
+	} else if err != nil {
+		return LockOwner{}, false, errors.Wrap(err, "An error occurred when reading the instance lock "+path)
+	}
+	var owner LockOwner
+	err = json.Unmarshal(ownerAsJSON, &owner)
+	if err != nil {
+		//Owner could have exited in the middle of writing the lock, so there is no owner that could respond
+		return LockOwner{}, false, nil
+	}
+	return owner, owner.responds(), nil
+}
+
+func (owner LockOwner) isSameAs(otherOwner LockOwner) bool {
+	return owner.Pid == otherOwner.Pid && owner.StartTime.Equal(otherOwner.StartTime) && owner.Address == otherOwner.Address
+}
+
+func (owner LockOwner) responds() bool {
+	if owner.Address == "" {
+		return false
+	}
+	connection, err := net.DialTimeout("tcp", owner.Address, lockOwnerResponseTimeout)
+	if err != nil {
+		return false
+	}
+	_ = connection.Close()
+	return true
+}
+
+//Lock is only removed if it's still owned by the process releasing it, as it could have been replaced in the meantime
+func (lock *InstanceLock) Release() error {
+	owner, _, err := InstanceLockOwner(filepath.Dir(lock.path))
+	if err != nil {
+		return err
+	}
+	if !owner.isSameAs(lock.owner) {
+		return nil
+	}
+	return errors.Wrap(os.Remove(lock.path), "An error occurred when removing the instance lock "+lock.path)
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"wirwl/internal/log"
+)
+
+func listenOnRandomPort() net.Listener {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return listener
+}
+
+func TestThatInstanceLockCannotBeAcquiredWhileItsOwnerResponds(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+	listener := listenOnRandomPort()
+	defer listener.Close()
+	owner := LockOwner{Pid: 123, Description: "application", StartTime: time.Date(2020, 5, 1, 10, 30, 0, 0, time.UTC), Address: listener.Addr().String(), Token: "token"}
+	lock, err := AcquireInstanceLock(dirPath, owner)
+	assert.Nil(t, err)
+	_, err = AcquireInstanceLock(dirPath, LockOwner{Pid: 456, Description: "serve command"})
+	assert.Equal(t, InstanceLockedError{owner}, err)
+	assert.EqualError(t, err, "The collection is already used by application running as process 123 since 01/05/2020 10:30:00")
+	currentOwner, isOwned, err := InstanceLockOwner(dirPath)
+	assert.Nil(t, err)
+	assert.True(t, isOwned)
+	assert.Equal(t, owner, currentOwner)
+	assert.Nil(t, lock.Release())
+	_, isOwned, _ = InstanceLockOwner(dirPath)
+	assert.False(t, isOwned)
+	_, err = os.Stat(filepath.Join(dirPath, instanceLockFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestThatInstanceLockLeftBehindIsReplaced(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+	listener := listenOnRandomPort()
+	_, err = AcquireInstanceLock(dirPath, LockOwner{Pid: 123, Description: "application", Address: listener.Addr().String()})
+	assert.Nil(t, err)
+	listener.Close()
+	newOwner := LockOwner{Pid: 456, Description: "serve command", StartTime: time.Now()}
+	lock, err := AcquireInstanceLock(dirPath, newOwner)
+	assert.Nil(t, err)
+	currentOwner, isOwned, _ := InstanceLockOwner(dirPath)
+	assert.False(t, isOwned)
+	assert.True(t, newOwner.isSameAs(currentOwner))
+	assert.Nil(t, lock.Release())
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"os"
+	"time"
+)
+
+/*
+Journal is an append-only file in which changes made to entries are recorded, one JSON record per line, before they are made.
+It's cleared every time the entries get saved so if it has any records when the application starts, the application
+has not been closed properly and the recorded changes can be replayed to restore the state from before it was closed.
+*/
+type Journal struct {
+	path string
+}
+
+type JournalOperation string
+
+const (
+	AddEntryTypeOperation        JournalOperation = "ADD_ENTRY_TYPE"
+	RestoreEntryTypeOperation    JournalOperation = "RESTORE_ENTRY_TYPE"
+	DeleteEntryTypeOperation     JournalOperation = "DELETE_ENTRY_TYPE"
+	UpdateEntryTypeOperation     JournalOperation = "UPDATE_ENTRY_TYPE"
+	AddEntryOperation            JournalOperation = "ADD_ENTRY"
+	DeleteEntryOperation         JournalOperation = "DELETE_ENTRY"
+	UpdateEntryOperation         JournalOperation = "UPDATE_ENTRY"
+	ChangeEntryProgressOperation JournalOperation = "CHANGE_ENTRY_PROGRESS"
+	StartRewatchOperation        JournalOperation = "START_REWATCH"
+	CreateListOperation          JournalOperation = "CREATE_LIST"
+	DeleteListOperation          JournalOperation = "DELETE_LIST"
+	AddToListOperation           JournalOperation = "ADD_TO_LIST"
+	RemoveFromListOperation      JournalOperation = "REMOVE_FROM_LIST"
+	MoveInListOperation          JournalOperation = "MOVE_IN_LIST"
+	AddRelationOperation         JournalOperation = "ADD_RELATION"
+	RemoveRelationOperation      JournalOperation = "REMOVE_RELATION"
+	CreateSeriesOperation        JournalOperation = "CREATE_SERIES"
+	DeleteSeriesOperation        JournalOperation = "DELETE_SERIES"
+	AddToSeriesOperation         JournalOperation = "ADD_TO_SERIES"
+	RemoveFromSeriesOperation    JournalOperation = "REMOVE_FROM_SERIES"
+	MergeEntriesOperation        JournalOperation = "MERGE_ENTRIES"
+	UnmergeEntriesOperation      JournalOperation = "UNMERGE_ENTRIES"
+	MoveEntriesOperation         JournalOperation = "MOVE_ENTRIES"
+	AddEntriesOperation          JournalOperation = "ADD_ENTRIES"
+	DeleteEntriesOperation       JournalOperation = "DELETE_ENTRIES"
+	SetCoverOperation            JournalOperation = "SET_COVER"
+)
+
+//Describes a single change, only the fields needed by the change's operation are set
+type JournalRecord struct {
+	Operation      JournalOperation
+	TypeName       string         `json:",omitempty"`
+	EntryType      *EntryType     `json:",omitempty"`
+	Entry          *Entry         `json:",omitempty"`
+	Entries        []Entry        `json:",omitempty"`
+	EntryId        int            `json:",omitempty"`
+	Amount         int            `json:",omitempty"`
+	Date           time.Time      `json:",omitempty"`
+	ListName       string         `json:",omitempty"`
+	List           *EntriesList   `json:",omitempty"`
+	Lists          []EntriesList  `json:",omitempty"`
+	Position       int            `json:",omitempty"`
+	Relation       *Relation      `json:",omitempty"`
+	Relations      []Relation     `json:",omitempty"`
+	SeriesMembers  []SeriesMember `json:",omitempty"`
+	SeriesName     string         `json:",omitempty"`
+	Series         *Series        `json:",omitempty"`
+	MergedTypeName string         `json:",omitempty"`
+	MergedEntryId  int            `json:",omitempty"`
+	TargetTypeName string         `json:",omitempty"`
+	EntryIds       []int          `json:",omitempty"`
+	Positions      []int          `json:",omitempty"`
+	Cover          string         `json:",omitempty"`
+}
+
+func NewJournal(path string) *Journal {
+	return &Journal{path: path}
+}
+
+func (journal *Journal) Path() string {
+	return journal.path
+}
+
+//Record is synced to the disk before returning so it's not lost even if the application crashes right after
+func (journal *Journal) Append(record JournalRecord) error {
+	recordData, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode a journal record")
+	}
+	file, err := os.OpenFile(journal.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrap(err, "Failed to open journal file in path "+journal.path)
+	}
+	defer file.Close()
+	_, err = file.Write(append(recordData, '\n'))
+	if err != nil {
+		return errors.Wrap(err, "Failed to write to journal file in path "+journal.path)
+	}
+	err = file.Sync()
+	if err != nil {
+		return errors.Wrap(err, "Failed to sync journal file in path "+journal.path)
+	}
+	return nil
+}
+
+//Returns records in the order they were appended. The last line is skipped if it's incomplete, as it can only be a record
+//that was being written when the application crashed.
+func (journal *Journal) Records() ([]JournalRecord, error) {
+	file, err := os.Open(journal.path)
+	if os.IsNotExist(err) {
+		return []JournalRecord{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to open journal file in path "+journal.path)
+	}
+	defer file.Close()
+	records := []JournalRecord{}
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			break
+		}
+		record := JournalRecord{}
+		err = json.Unmarshal(line, &record)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to decode a record of journal file in path "+journal.path)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (journal *Journal) Clear() error {
+	err := os.Remove(journal.path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "Failed to clear journal file in path "+journal.path)
+	}
+	return nil
+}
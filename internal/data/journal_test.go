@@ -0,0 +1,83 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"wirwl/internal/log"
+)
+
+func createTestJournal() (*Journal, func()) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	return NewJournal(filepath.Join(dir, "journal.jsonl")), func() { _ = os.RemoveAll(dir) }
+}
+
+func TestThatJournalWithoutFileHasNoRecords(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+}
+
+func TestThatAppendedRecordsAreReturnedInOrder(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	date := time.Date(2020, time.March, 14, 15, 9, 26, 0, time.UTC)
+	appendedRecords := []JournalRecord{
+		{Operation: AddEntryTypeOperation, EntryType: &EntryType{Name: "books"}},
+		{Operation: UpdateEntryOperation, TypeName: "books", Entry: &Entry{Id: 2, Title: "title"}},
+		{Operation: ChangeEntryProgressOperation, TypeName: "books", EntryId: 2, Amount: -1, Date: date},
+	}
+	for _, record := range appendedRecords {
+		err := journal.Append(record)
+		assert.Nil(t, err)
+	}
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Equal(t, appendedRecords, records)
+}
+
+func TestThatIncompleteLastRecordIsSkipped(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	err := journal.Append(JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: "books"})
+	assert.Nil(t, err)
+	file, err := os.OpenFile(journal.Path(), os.O_WRONLY|os.O_APPEND, 0600)
+	assert.Nil(t, err)
+	_, err = file.Write([]byte(`{"Operation":"DELETE_ENT`))
+	assert.Nil(t, err)
+	file.Close()
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Equal(t, []JournalRecord{{Operation: DeleteEntryTypeOperation, TypeName: "books"}}, records)
+}
+
+func TestThatErrorIsReturnedWhenJournalHasCorruptedRecord(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	err := ioutil.WriteFile(journal.Path(), []byte("not a record\n"), 0600)
+	assert.Nil(t, err)
+	_, err = journal.Records()
+	assert.NotNil(t, err)
+}
+
+func TestThatClearedJournalHasNoRecords(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	err := journal.Append(JournalRecord{Operation: DeleteEntryTypeOperation, TypeName: "books"})
+	assert.Nil(t, err)
+	err = journal.Clear()
+	assert.Nil(t, err)
+	records, err := journal.Records()
+	assert.Nil(t, err)
+	assert.Empty(t, records)
+	err = journal.Clear()
+	assert.Nil(t, err)
+}
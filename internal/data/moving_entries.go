@@ -0,0 +1,250 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+)
+
+/*
+Entries can be moved or copied to another entry type, e.g. when they have been added to a wrong type or when a type
+gets split into a few types. Their statuses and scores are mapped to the other type the same way as when their type
+gets edited, see Entry.migratedTo. Moved entries keep their ids, unless the ids are already used in the other type,
+and lists, series and relations follow them. Copies are new entries, so they don't belong to any lists or series, but
+both moved and copied entries keep their covers, which are stored by their content rather than by their entries.
+Moving or copying many entries is a single change, so it can be undone at once.
+*/
+
+//Entries are moved to the end of the other type in the order of the given ids
+func (container *EntriesContainer) MoveEntries(typeName string, entryIds []int, targetTypeName string) error {
+	entries, err := container.entriesMappedTo(typeName, entryIds, targetTypeName, "move")
+	if err != nil {
+		return err
+	}
+	targetType, _ := container.EntryTypeWithName(targetTypeName)
+	usedIds := map[int]bool{}
+	for _, entry := range container.entries[targetType] {
+		usedIds[entry.Id] = true
+	}
+	for i := range entries {
+		if usedIds[entries[i].Id] {
+			entries[i].Id = nextUnusedId(usedIds)
+		}
+		usedIds[entries[i].Id] = true
+	}
+	record := JournalRecord{Operation: MoveEntriesOperation, TypeName: typeName, TargetTypeName: targetTypeName, EntryIds: entryIds, Entries: entries}
+	return container.execute(record, "moving "+container.describeEntries(typeName, entryIds)+" to entry type '"+targetTypeName+"'")
+}
+
+//Copies are added to the end of the other type in the order of the given ids and get new ids
+func (container *EntriesContainer) CopyEntries(typeName string, entryIds []int, targetTypeName string) error {
+	entries, err := container.entriesMappedTo(typeName, entryIds, targetTypeName, "copy")
+	if err != nil {
+		return err
+	}
+	targetType, _ := container.EntryTypeWithName(targetTypeName)
+	nextId := nextEntryIdIn(container.entries[targetType])
+	for i := range entries {
+		entries[i].Id = nextId + i
+	}
+	record := JournalRecord{Operation: AddEntriesOperation, TypeName: targetTypeName, Entries: entries}
+	return container.execute(record, "copying "+container.describeEntries(typeName, entryIds)+" to entry type '"+targetTypeName+"'")
+}
+
+//Operation is used only to describe the failure
+func (container *EntriesContainer) entriesMappedTo(typeName string, entryIds []int, targetTypeName string, operation string) ([]Entry, error) {
+	entryType, err := container.EntryTypeWithName(typeName)
+	if err != nil {
+		return nil, errors.New("Cannot " + operation + " entries of entry type '" + typeName + "' as no such type exists")
+	}
+	targetType, err := container.EntryTypeWithName(targetTypeName)
+	if err != nil {
+		return nil, errors.New("Cannot " + operation + " entries to entry type '" + targetTypeName + "' as no such type exists")
+	} else if typeName == targetTypeName {
+		return nil, errors.New("Cannot " + operation + " entries to entry type '" + targetTypeName + "' as they are already of that type")
+	} else if len(entryIds) == 0 {
+		return nil, errors.New("Cannot " + operation + " entries to entry type '" + targetTypeName + "' as no entries have been chosen")
+	}
+	entries := []Entry{}
+	for _, entryId := range entryIds {
+		entry, exists := container.entryWithId(typeName, entryId)
+		if !exists {
+			return nil, errors.New("Cannot " + operation + " entry with id " + strconv.Itoa(entryId) + " of entry type '" + typeName + "' as no such entry exists")
+		}
+		entries = append(entries, entry.migratedTo(entryType, targetType))
+	}
+	return entries, nil
+}
+
+func nextUnusedId(usedIds map[int]bool) int {
+	id := 0
+	for usedIds[id] {
+		id++
+	}
+	return id
+}
+
+func (container *EntriesContainer) describeEntries(typeName string, entryIds []int) string {
+	if len(entryIds) == 1 {
+		return container.describeEntry(typeName, entryIds[0])
+	}
+	return strconv.Itoa(len(entryIds)) + " entries"
+}
+
+//Record's entries are the moved entries as they are after the move, in the order of the record's ids. They are inserted
+//at the record's positions if it has any, so entries moved back when the move gets reverted get back to where they were.
+func (container *EntriesContainer) moveEntries(record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(record.TypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot move entries of entry type '" + record.TypeName + "' as no such type exists")
+	}
+	targetType, err := container.EntryTypeWithName(record.TargetTypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot move entries to entry type '" + record.TargetTypeName + "' as no such type exists")
+	} else if entryType == targetType || len(record.EntryIds) != len(record.Entries) {
+		return JournalRecord{}, errors.New("Cannot move entries of entry type '" + record.TypeName + "' to entry type '" + record.TargetTypeName + "' as the move is incorrect")
+	}
+	positions := []int{}
+	for _, entryId := range record.EntryIds {
+		entryNum, exists := entryNumIn(container.entries[entryType], entryId)
+		if !exists {
+			return JournalRecord{}, errors.New("Cannot move entry with id " + strconv.Itoa(entryId) + " of entry type '" + record.TypeName + "' as no such entry exists")
+		}
+		positions = append(positions, entryNum)
+	}
+	for _, entry := range record.Entries {
+		if _, exists := entryNumIn(container.entries[targetType], entry.Id); exists {
+			return JournalRecord{}, errors.New("Cannot move entry with id " + strconv.Itoa(entry.Id) + " to entry type '" + record.TargetTypeName + "' as its id is already used")
+		}
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	entriesBefore := []Entry{}
+	for _, position := range positions {
+		entriesBefore = append(entriesBefore, container.entries[entryType][position])
+	}
+	container.entries[entryType] = entriesWithout(container.entries[entryType], positions)
+	container.entries[targetType] = entriesWithInserted(container.entries[targetType], record.Entries, record.Positions)
+	movedItems := map[ListItem]ListItem{}
+	newIds := []int{}
+	for i, entryId := range record.EntryIds {
+		movedItems[ListItem{record.TypeName, entryId}] = ListItem{record.TargetTypeName, record.Entries[i].Id}
+		newIds = append(newIds, record.Entries[i].Id)
+	}
+	container.moveEntriesInLists(movedItems)
+	container.moveEntriesInSeries(movedItems)
+	container.moveEntriesInRelations(movedItems)
+	for i := range record.Entries {
+		entryBefore, entryAfter := entriesBefore[i], record.Entries[i]
+		container.notifyListenersAboutChange(ChangeEvent{Kind: EntryMovedChange, TypeBefore: &entryType, TypeAfter: &targetType,
+			EntryBefore: &entryBefore, EntryAfter: &entryAfter})
+	}
+	return JournalRecord{Operation: MoveEntriesOperation, TypeName: record.TargetTypeName, TargetTypeName: record.TypeName,
+		EntryIds: newIds, Entries: entriesBefore, Positions: positions}, nil
+}
+
+//Entries with already used ids are not added at all. Entries are inserted at the record's positions if it has any,
+//so entries restored after their deletion get back to where they were, along with their relations and series.
+func (container *EntriesContainer) addEntries(record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(record.TypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot add entries to entry type '" + record.TypeName + "' as no such type exists")
+	}
+	for _, entry := range record.Entries {
+		if _, exists := entryNumIn(container.entries[entryType], entry.Id); exists {
+			return JournalRecord{}, errors.New("Cannot add entry with id " + strconv.Itoa(entry.Id) + " to entry type '" + record.TypeName + "' as its id is already used")
+		}
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	container.entries[entryType] = entriesWithInserted(container.entries[entryType], record.Entries, record.Positions)
+	container.restoreRelations(record.Relations)
+	container.restoreSeriesMembers(record.SeriesMembers)
+	entryIds := []int{}
+	for i := range record.Entries {
+		addedEntry := record.Entries[i]
+		entryIds = append(entryIds, addedEntry.Id)
+		container.notifyListenersAboutChange(entryChangeEvent(EntryAddedChange, entryType, nil, &addedEntry))
+	}
+	return JournalRecord{Operation: DeleteEntriesOperation, TypeName: record.TypeName, EntryIds: entryIds}, nil
+}
+
+func (container *EntriesContainer) deleteEntries(record JournalRecord) (JournalRecord, error) {
+	entryType, err := container.EntryTypeWithName(record.TypeName)
+	if err != nil {
+		return JournalRecord{}, errors.New("Cannot delete entries of entry type '" + record.TypeName + "' as no such type exists")
+	}
+	positions := []int{}
+	deletedIds := map[int]bool{}
+	for _, entryId := range record.EntryIds {
+		entryNum, exists := entryNumIn(container.entries[entryType], entryId)
+		if !exists {
+			return JournalRecord{}, errors.New("Cannot delete entry with id " + strconv.Itoa(entryId) + " in entry type '" + record.TypeName + "' as no such entry exists")
+		}
+		positions = append(positions, entryNum)
+		deletedIds[entryId] = true
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	deletedEntries := []Entry{}
+	for _, position := range positions {
+		deletedEntries = append(deletedEntries, container.entries[entryType][position])
+	}
+	container.entries[entryType] = entriesWithout(container.entries[entryType], positions)
+	isDeletedEntry := func(typeName string, entryId int) bool {
+		return typeName == record.TypeName && deletedIds[entryId]
+	}
+	relations := container.removeRelationsOfEntries(isDeletedEntry)
+	seriesMembers := container.removeSeriesMembersOfEntries(isDeletedEntry)
+	for i := range deletedEntries {
+		deletedEntry := deletedEntries[i]
+		container.notifyListenersAboutChange(entryChangeEvent(EntryDeletedChange, entryType, &deletedEntry, nil))
+	}
+	return JournalRecord{Operation: AddEntriesOperation, TypeName: record.TypeName, Entries: deletedEntries, Positions: positions,
+		Relations: relations, SeriesMembers: seriesMembers}, nil
+}
+
+func entriesWithout(entries []Entry, positions []int) []Entry {
+	removed := map[int]bool{}
+	for _, position := range positions {
+		removed[position] = true
+	}
+	keptEntries := []Entry{}
+	for i, entry := range entries {
+		if !removed[i] {
+			keptEntries = append(keptEntries, entry)
+		}
+	}
+	return keptEntries
+}
+
+//Entries are inserted starting from the lowest position, so each of them ends up at its position. Entries without
+//positions are appended.
+func entriesWithInserted(entries []Entry, insertedEntries []Entry, positions []int) []Entry {
+	order := []int{}
+	for i := range insertedEntries {
+		order = append(order, i)
+	}
+	positionOf := func(i int) int {
+		if i < len(positions) {
+			return positions[i]
+		}
+		return len(entries) + len(insertedEntries)
+	}
+	sort.SliceStable(order, func(i, j int) bool { return positionOf(order[i]) < positionOf(order[j]) })
+	result := append([]Entry{}, entries...)
+	for _, i := range order {
+		position := positionOf(i)
+		if position < 0 || position > len(result) {
+			position = len(result)
+		}
+		result = append(append(append([]Entry{}, result[:position]...), insertedEntries[i]), result[position:]...)
+	}
+	return result
+}
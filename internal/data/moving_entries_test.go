@@ -0,0 +1,110 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatMovedEntriesKeepTheirIdsAndAreFollowedByListsSeriesAndRelations(t *testing.T) {
+	container := createContainerWithTestRelations()
+	_ = container.CreateList("favourites")
+	_ = container.AddToList("favourites", "comics", 1)
+	_ = container.CreateSeries("franchise")
+	_ = container.AddToSeries("franchise", "comics", 0)
+	_ = container.AddEntryType(gamesEntryType)
+	assert.Nil(t, container.MoveEntries("comics", []int{1, 0}, "games"))
+	assert.Empty(t, container.entries[comicsEntryType])
+	games := container.entries[gamesEntryType]
+	assert.Equal(t, 2, len(games))
+	assert.Equal(t, "some comic2", games[0].Title)
+	assert.Equal(t, 1, games[0].Id)
+	assert.Equal(t, EntryStatus("Playing"), games[0].Status)
+	assert.Equal(t, "some comic1", games[1].Title)
+	assert.Equal(t, 0, games[1].Id)
+	list, _ := container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"games", 1}}, list.Items)
+	series, _ := container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"games", 0}}, series.Members)
+	assert.Equal(t, []Relation{{AdaptationRelation, "games", 0, "videos", 1}, {SequelRelation, "games", 0, "games", 1}}, container.RelationsOf("games", 0))
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "moving 2 entries to entry type 'games'", change.Description)
+	assert.Equal(t, GetExampleComicEntries(), container.entries[comicsEntryType])
+	assert.Empty(t, container.entries[gamesEntryType])
+	list, _ = container.ListWithName("favourites")
+	assert.Equal(t, []ListItem{{"comics", 1}}, list.Items)
+	assert.Equal(t, 2, len(container.RelationsOf("comics", 0)))
+	_, err = container.Redo()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(container.entries[gamesEntryType]))
+}
+
+func TestThatMovedEntryGetsNewIdIfItsIdIsAlreadyUsedAndGetsBackToWhereItWasWhenMoveIsUndone(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.Nil(t, container.MoveEntries("comics", []int{0}, "music"))
+	assert.Equal(t, []Entry{GetExampleComicEntries()[1]}, container.entries[comicsEntryType])
+	moved, exists := container.entryWithId("music", 2)
+	assert.True(t, exists)
+	assert.Equal(t, "some comic1", moved.Title)
+	change, _ := container.Undo()
+	assert.Equal(t, "moving entry 'some comic1' to entry type 'music'", change.Description)
+	assert.Equal(t, GetTestEntries(), container.entries)
+}
+
+func TestThatCopiedEntriesGetNewIdsAndCopyingCanBeUndone(t *testing.T) {
+	container := createContainerWithTestRelations()
+	assert.Nil(t, container.CopyEntries("videos", []int{0, 1}, "comics"))
+	assert.Equal(t, GetExampleVideoEntries(), container.entries[videoEntryType])
+	comics := container.entries[comicsEntryType]
+	assert.Equal(t, 4, len(comics))
+	assert.Equal(t, "some video1", comics[2].Title)
+	assert.Equal(t, 2, comics[2].Id)
+	assert.Equal(t, "some video2", comics[3].Title)
+	assert.Equal(t, 3, comics[3].Id)
+	assert.Empty(t, container.RelationsOf("comics", 3))
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "copying 2 entries to entry type 'comics'", change.Description)
+	assert.Equal(t, GetExampleComicEntries(), container.entries[comicsEntryType])
+}
+
+func TestThatMovedAndCopiedEntriesKeepTheirCovers(t *testing.T) {
+	container := createLoadedTestContainer()
+	_ = container.SetCover("comics", 0, "some cover")
+	_ = container.SetCover("comics", 1, "other cover")
+	assert.Nil(t, container.MoveEntries("comics", []int{0}, "music"))
+	assert.Nil(t, container.CopyEntries("comics", []int{1}, "videos"))
+	moved, _ := container.entryWithId("music", 2)
+	assert.Equal(t, "some cover", moved.Cover)
+	copied, _ := container.entryWithId("videos", 2)
+	assert.Equal(t, "other cover", copied.Cover)
+	original, _ := container.entryWithId("comics", 1)
+	assert.Equal(t, "other cover", original.Cover)
+}
+
+func TestThatInvalidMovesAndCopiesAreNotMade(t *testing.T) {
+	container := createLoadedTestContainer()
+	assert.EqualError(t, container.MoveEntries("books", []int{0}, "music"), "Cannot move entries of entry type 'books' as no such type exists")
+	assert.EqualError(t, container.MoveEntries("comics", []int{0}, "books"), "Cannot move entries to entry type 'books' as no such type exists")
+	assert.EqualError(t, container.CopyEntries("comics", []int{0}, "comics"), "Cannot copy entries to entry type 'comics' as they are already of that type")
+	assert.EqualError(t, container.CopyEntries("comics", []int{}, "music"), "Cannot copy entries to entry type 'music' as no entries have been chosen")
+	assert.EqualError(t, container.MoveEntries("comics", []int{0, 5}, "music"), "Cannot move entry with id 5 of entry type 'comics' as no such entry exists")
+	assert.Equal(t, GetTestEntries(), container.entries)
+}
+
+func TestThatMovesAndCopiesAreReplayedFromJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := createLoadedTestContainer()
+	container.SetJournal(journal)
+	_ = container.MoveEntries("music", []int{1, 0}, "videos")
+	_, _ = container.Undo()
+	_ = container.CopyEntries("comics", []int{1}, "music")
+	_ = container.MoveEntries("comics", []int{0}, "videos")
+	replayingContainer := createLoadedTestContainer()
+	replayingContainer.SetJournal(journal)
+	amount, err := replayingContainer.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, amount)
+	assert.Equal(t, container.entries, replayingContainer.entries)
+}
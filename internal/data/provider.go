@@ -3,4 +3,12 @@ package data
 type Provider interface {
 	SaveEntries(map[EntryType][]Entry) error
 	LoadEntries() (map[EntryType][]Entry, error)
+	SaveLists([]EntriesList) error
+	LoadLists() ([]EntriesList, error)
+	SaveRelations([]Relation) error
+	LoadRelations() ([]Relation, error)
+	SaveSeries([]Series) error
+	LoadSeries() ([]Series, error)
+	//Saves everything at once, so either all of it gets saved or none of it does
+	SaveAll(entries map[EntryType][]Entry, lists []EntriesList, relations []Relation, series []Series) error
 }
@@ -0,0 +1,239 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+/*
+Relations link entries that belong together, e.g. a book and its film adaptation or a series and its sequel, no matter
+whether they are of the same entry type. Every relation has its inverse, e.g. the film is an adaptation of the book and
+the book is the source of the film, so it's stored only once and viewed from either of its entries. Like lists, relations
+only refer to entries, but unlike lists they are removed along with their entries, as ids of deleted entries can be
+used again, and they get restored when the deletion is undone.
+*/
+type RelationKind string
+
+const (
+	SequelRelation        RelationKind = "sequel"
+	PrequelRelation       RelationKind = "prequel"
+	AdaptationRelation    RelationKind = "adaptation"
+	SourceRelation        RelationKind = "source"
+	SpinOffRelation       RelationKind = "spin-off"
+	OriginalRelation      RelationKind = "original"
+	SameFranchiseRelation RelationKind = "same franchise"
+)
+
+//Related entry is of the kind in relation to the entry, e.g. it's a sequel of the entry
+type Relation struct {
+	Kind            RelationKind
+	TypeName        string
+	EntryId         int
+	RelatedTypeName string
+	RelatedEntryId  int
+}
+
+func RelationKinds() []RelationKind {
+	return []RelationKind{SequelRelation, PrequelRelation, AdaptationRelation, SourceRelation, SpinOffRelation,
+		OriginalRelation, SameFranchiseRelation}
+}
+
+func IsValidRelationKind(kind RelationKind) bool {
+	for _, validKind := range RelationKinds() {
+		if kind == validKind {
+			return true
+		}
+	}
+	return false
+}
+
+func relationKindsAsText() string {
+	kinds := []string{}
+	for _, kind := range RelationKinds() {
+		kinds = append(kinds, string(kind))
+	}
+	return strings.Join(kinds, ", ")
+}
+
+//Returns the kind of the entry in relation to the related entry, e.g. the entry is a prequel of its sequel
+func (kind RelationKind) Inverse() RelationKind {
+	switch kind {
+	case SequelRelation:
+		return PrequelRelation
+	case PrequelRelation:
+		return SequelRelation
+	case AdaptationRelation:
+		return SourceRelation
+	case SourceRelation:
+		return AdaptationRelation
+	case SpinOffRelation:
+		return OriginalRelation
+	case OriginalRelation:
+		return SpinOffRelation
+	}
+	return kind
+}
+
+//Returns the same relation viewed from the related entry
+func (relation Relation) Inverse() Relation {
+	return Relation{Kind: relation.Kind.Inverse(), TypeName: relation.RelatedTypeName, EntryId: relation.RelatedEntryId,
+		RelatedTypeName: relation.TypeName, RelatedEntryId: relation.EntryId}
+}
+
+func (relation Relation) sameAs(otherRelation Relation) bool {
+	return relation == otherRelation || relation == otherRelation.Inverse()
+}
+
+//Returns copies of all relations in the order they were added
+func (container *EntriesContainer) Relations() []Relation {
+	return append([]Relation{}, container.relations...)
+}
+
+//Returns the relations of the entry viewed from it, so the entry is never the related one, in the order they were added
+func (container *EntriesContainer) RelationsOf(typeName string, entryId int) []Relation {
+	relations := []Relation{}
+	for _, relation := range container.relations {
+		if relation.TypeName == typeName && relation.EntryId == entryId {
+			relations = append(relations, relation)
+		} else if relation.RelatedTypeName == typeName && relation.RelatedEntryId == entryId {
+			relations = append(relations, relation.Inverse())
+		}
+	}
+	return relations
+}
+
+func (container *EntriesContainer) AddRelation(relation Relation) error {
+	record := JournalRecord{Operation: AddRelationOperation, Relation: &relation, Position: len(container.relations)}
+	return container.execute(record, "marking "+container.describeRelation(relation))
+}
+
+//Relation can be given as viewed from either of its entries
+func (container *EntriesContainer) RemoveRelation(relation Relation) error {
+	record := JournalRecord{Operation: RemoveRelationOperation, Relation: &relation}
+	return container.execute(record, "unmarking "+container.describeRelation(relation))
+}
+
+func (container *EntriesContainer) describeRelation(relation Relation) string {
+	return container.describeEntry(relation.RelatedTypeName, relation.RelatedEntryId) + " as " + string(relation.Kind) +
+		" of " + container.describeEntry(relation.TypeName, relation.EntryId)
+}
+
+//Only existing entries can be related and two entries can be related in the same way only once.
+//Relation is inserted at the record's position, so a relation restored after its removal gets back to where it was.
+func (container *EntriesContainer) addRelation(record JournalRecord) (JournalRecord, error) {
+	relation := *record.Relation
+	if !IsValidRelationKind(relation.Kind) {
+		return JournalRecord{}, errors.New("There is no relation '" + string(relation.Kind) + "', it has to be one of: " + relationKindsAsText())
+	}
+	entry, exists := container.entryWithId(relation.TypeName, relation.EntryId)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot add relation to entry with id " + strconv.Itoa(relation.EntryId) + " of entry type '" + relation.TypeName + "' as no such entry exists")
+	}
+	relatedEntry, exists := container.entryWithId(relation.RelatedTypeName, relation.RelatedEntryId)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot add relation to entry with id " + strconv.Itoa(relation.RelatedEntryId) + " of entry type '" + relation.RelatedTypeName + "' as no such entry exists")
+	}
+	if relation.TypeName == relation.RelatedTypeName && relation.EntryId == relation.RelatedEntryId {
+		return JournalRecord{}, errors.New("Entry '" + entry.Title + "' cannot be related to itself")
+	}
+	if _, exists := container.relationNum(relation); exists {
+		return JournalRecord{}, errors.New("Entry '" + relatedEntry.Title + "' is already marked as " + string(relation.Kind) + " of entry '" + entry.Title + "'")
+	}
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	position := record.Position
+	if position < 0 || position > len(container.relations) {
+		position = len(container.relations)
+	}
+	container.relations = append(append(append([]Relation{}, container.relations[:position]...), relation), container.relations[position:]...)
+	container.notifyListenersAboutChange(ChangeEvent{Kind: RelationAddedChange, RelationAfter: &relation})
+	return JournalRecord{Operation: RemoveRelationOperation, Relation: &relation}, nil
+}
+
+func (container *EntriesContainer) removeRelation(record JournalRecord) (JournalRecord, error) {
+	num, exists := container.relationNum(*record.Relation)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot remove relation as " + container.describeRelation(*record.Relation) + " is not marked as such")
+	}
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	relation := container.relations[num]
+	container.relations = append(container.relations[:num:num], container.relations[num+1:]...)
+	container.notifyListenersAboutChange(ChangeEvent{Kind: RelationRemovedChange, RelationBefore: &relation})
+	return JournalRecord{Operation: AddRelationOperation, Relation: &relation, Position: num}, nil
+}
+
+func (container *EntriesContainer) relationNum(relation Relation) (int, bool) {
+	for i, existingRelation := range container.relations {
+		if existingRelation.sameAs(relation) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+//Removing relations is a part of deleting their entries so it's not recorded in the journal on its own.
+//Returns the removed relations, so they can be restored along with the entries.
+func (container *EntriesContainer) removeRelationsOfEntries(isRemovedEntry func(typeName string, entryId int) bool) []Relation {
+	removedRelations := []Relation{}
+	keptRelations := []Relation{}
+	for _, relation := range container.relations {
+		if isRemovedEntry(relation.TypeName, relation.EntryId) || isRemovedEntry(relation.RelatedTypeName, relation.RelatedEntryId) {
+			removedRelations = append(removedRelations, relation)
+		} else {
+			keptRelations = append(keptRelations, relation)
+		}
+	}
+	container.relations = keptRelations
+	return removedRelations
+}
+
+//Restored relations are added at the end, as they are only restored along with their entries
+func (container *EntriesContainer) restoreRelations(relations []Relation) {
+	container.relations = append(container.relations, relations...)
+}
+
+//Renaming relations is a part of renaming the entry type so it's not recorded in the journal on its own
+func (container *EntriesContainer) renameEntryTypeInRelations(oldName string, newName string) {
+	for i, relation := range container.relations {
+		if relation.TypeName == oldName {
+			container.relations[i].TypeName = newName
+		}
+		if relation.RelatedTypeName == oldName {
+			container.relations[i].RelatedTypeName = newName
+		}
+	}
+}
+
+//Relations follow their entries moved to another entry type, which are described the same way as items of lists.
+//Relations that would relate an entry to itself or repeat another relation, which happens when an entry is merged into
+//another one, are removed instead.
+func (container *EntriesContainer) moveEntriesInRelations(movedEntries map[ListItem]ListItem) {
+	movedRelations := []Relation{}
+	for _, relation := range container.relations {
+		if movedEntry, exists := movedEntries[ListItem{relation.TypeName, relation.EntryId}]; exists {
+			relation.TypeName, relation.EntryId = movedEntry.TypeName, movedEntry.EntryId
+		}
+		if movedEntry, exists := movedEntries[ListItem{relation.RelatedTypeName, relation.RelatedEntryId}]; exists {
+			relation.RelatedTypeName, relation.RelatedEntryId = movedEntry.TypeName, movedEntry.EntryId
+		}
+		if relation.TypeName == relation.RelatedTypeName && relation.EntryId == relation.RelatedEntryId {
+			continue
+		}
+		isRepeated := false
+		for _, movedRelation := range movedRelations {
+			if movedRelation.sameAs(relation) {
+				isRepeated = true
+			}
+		}
+		if !isRepeated {
+			movedRelations = append(movedRelations, relation)
+		}
+	}
+	container.relations = movedRelations
+}
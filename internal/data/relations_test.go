@@ -0,0 +1,138 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var comicAdaptedToVideo = Relation{Kind: AdaptationRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "videos", RelatedEntryId: 1}
+
+func createContainerWithTestRelations() *EntriesContainer {
+	container := createLoadedTestContainer()
+	_ = container.AddRelation(comicAdaptedToVideo)
+	_ = container.AddRelation(Relation{Kind: SequelRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "comics", RelatedEntryId: 1})
+	return container
+}
+
+func TestThatRelationsAreViewedFromEitherOfTheirEntries(t *testing.T) {
+	container := createContainerWithTestRelations()
+	assert.Equal(t, []Relation{comicAdaptedToVideo, {SequelRelation, "comics", 0, "comics", 1}}, container.RelationsOf("comics", 0))
+	assert.Equal(t, []Relation{{SourceRelation, "videos", 1, "comics", 0}}, container.RelationsOf("videos", 1))
+	assert.Equal(t, []Relation{{PrequelRelation, "comics", 1, "comics", 0}}, container.RelationsOf("comics", 1))
+	assert.Empty(t, container.RelationsOf("music", 0))
+	assert.Equal(t, SameFranchiseRelation, SameFranchiseRelation.Inverse())
+}
+
+func TestThatInvalidRelationsAreNotAdded(t *testing.T) {
+	container := createContainerWithTestRelations()
+	err := container.AddRelation(Relation{Kind: "remake", TypeName: "comics", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 0})
+	assert.EqualError(t, err, "There is no relation 'remake', it has to be one of: sequel, prequel, adaptation, source, spin-off, original, same franchise")
+	err = container.AddRelation(Relation{Kind: SequelRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 7})
+	assert.EqualError(t, err, "Cannot add relation to entry with id 7 of entry type 'music' as no such entry exists")
+	err = container.AddRelation(Relation{Kind: SequelRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "comics", RelatedEntryId: 0})
+	assert.EqualError(t, err, "Entry 'some comic1' cannot be related to itself")
+	err = container.AddRelation(comicAdaptedToVideo.Inverse())
+	assert.EqualError(t, err, "Entry 'some comic1' is already marked as source of entry 'some video2'")
+	err = container.RemoveRelation(Relation{Kind: SpinOffRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "music", RelatedEntryId: 0})
+	assert.EqualError(t, err, "Cannot remove relation as entry 'some music1' as spin-off of entry 'some comic1' is not marked as such")
+	assert.Equal(t, 2, len(container.RelationsOf("comics", 0)))
+}
+
+func TestThatRelationsCanBeRemovedAndRestoredWhenUndone(t *testing.T) {
+	container := createContainerWithTestRelations()
+	assert.Nil(t, container.RemoveRelation(comicAdaptedToVideo.Inverse()))
+	assert.Equal(t, []Relation{{SequelRelation, "comics", 0, "comics", 1}}, container.RelationsOf("comics", 0))
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "unmarking entry 'some comic1' as source of entry 'some video2'", change.Description)
+	assert.Equal(t, comicAdaptedToVideo, container.RelationsOf("comics", 0)[0])
+}
+
+func TestThatRelationsAreRemovedWithTheirEntriesAndRestoredWhenDeletionIsUndone(t *testing.T) {
+	container := createContainerWithTestRelations()
+	assert.Nil(t, container.DeleteEntry("comics", 1))
+	assert.Equal(t, []Relation{comicAdaptedToVideo}, container.RelationsOf("comics", 0))
+	_, err := container.AddEntry("comics", Entry{Title: "new comic", Status: PlannedStatus})
+	assert.Nil(t, err)
+	assert.Empty(t, container.RelationsOf("comics", 1))
+	_, _ = container.Undo()
+	_, _ = container.Undo()
+	assert.Equal(t, 2, len(container.RelationsOf("comics", 0)))
+	assert.Nil(t, container.DeleteEntryType("comics"))
+	assert.Empty(t, container.RelationsOf("videos", 1))
+	_, _ = container.Undo()
+	assert.Equal(t, []Relation{{SourceRelation, "videos", 1, "comics", 0}}, container.RelationsOf("videos", 1))
+}
+
+func TestThatRelationsFollowRenamedEntryType(t *testing.T) {
+	container := createContainerWithTestRelations()
+	renamedType := comicsEntryType
+	renamedType.Name = "manga"
+	assert.Nil(t, container.UpdateEntryType("comics", renamedType))
+	assert.Equal(t, []Relation{{SourceRelation, "videos", 1, "manga", 0}}, container.RelationsOf("videos", 1))
+	assert.Equal(t, 1, len(container.RelationsOf("manga", 1)))
+}
+
+func TestThatChangesOfRelationsAreReplayedFromJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := createLoadedTestContainer()
+	container.SetJournal(journal)
+	_ = container.AddRelation(comicAdaptedToVideo)
+	_ = container.AddRelation(Relation{Kind: SpinOffRelation, TypeName: "music", EntryId: 0, RelatedTypeName: "videos", RelatedEntryId: 1})
+	_ = container.DeleteEntry("music", 0)
+	replayingContainer := createLoadedTestContainer()
+	replayingContainer.SetJournal(journal)
+	amount, err := replayingContainer.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, amount)
+	assert.Equal(t, container.relations, replayingContainer.relations)
+}
+
+func TestThatFilterMatchesEntriesRelatedToOtherEntriesAsGivenKinds(t *testing.T) {
+	container := createContainerWithTestRelations()
+	adaptations := container.EntriesMatching(Filter{RelatedAs: []RelationKind{AdaptationRelation}}, filterTestNow)
+	assert.Equal(t, 1, len(adaptations))
+	assert.Equal(t, "some video2", adaptations[0].Entry.Title)
+	sources := container.EntriesMatching(Filter{RelatedAs: []RelationKind{SourceRelation, SequelRelation}}, filterTestNow)
+	assert.Equal(t, 2, len(sources))
+	assert.Empty(t, container.EntriesMatching(Filter{RelatedAs: []RelationKind{AdaptationRelation}, Statuses: []EntryStatus{PlannedStatus}}, filterTestNow))
+}
+
+func TestThatRelationsAreSavedAndLoaded(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	dataProvider := NewBoltProvider(testDbPath)
+	relations, err := dataProvider.LoadRelations()
+	assert.Nil(t, err)
+	assert.Empty(t, relations)
+	savedRelations := []Relation{}
+	for i := 0; i < 12; i++ {
+		savedRelations = append(savedRelations, Relation{Kind: SequelRelation, TypeName: "comics", EntryId: i, RelatedTypeName: "comics", RelatedEntryId: i + 1})
+	}
+	assert.Nil(t, dataProvider.SaveRelations(savedRelations))
+	relations, err = dataProvider.LoadRelations()
+	assert.Nil(t, err)
+	assert.Equal(t, savedRelations, relations)
+	assert.Nil(t, dataProvider.SaveRelations(savedRelations[:1]))
+	relations, err = dataProvider.LoadRelations()
+	assert.Nil(t, err)
+	assert.Equal(t, savedRelations[:1], relations)
+}
+
+func TestThatRelationsAreLoadedAndSavedWithEntries(t *testing.T) {
+	provider := NewAbstractProvider()
+	provider.LoadRelationsFunc = func() ([]Relation, error) {
+		return []Relation{{Kind: SequelRelation, TypeName: "comics", EntryId: 0, RelatedTypeName: "comics", RelatedEntryId: 1}}, nil
+	}
+	var savedRelations []Relation
+	provider.SaveRelationsFunc = func(relations []Relation) error {
+		savedRelations = relations
+		return nil
+	}
+	container := NewEntriesContainer(provider)
+	assert.Nil(t, container.LoadData())
+	assert.Equal(t, 1, len(container.RelationsOf("comics", 1)))
+	assert.Nil(t, container.SaveData())
+	assert.Equal(t, 1, len(savedRelations))
+}
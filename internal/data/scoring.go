@@ -0,0 +1,182 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Every entry type scores its entries with one of the scoring systems, e.g. from 1 to 100 or with 5 stars. Scores are kept
+in entries as numbers from 1 to the highest score of the system, 0 meaning that the entry has not been scored,
+so stars are kept as amounts of half stars and thumbs as 1 for down and 2 for up. Scores of different systems are
+compared after they are normalized to the scale from 1 to 10, e.g. in statistics or when entries are sorted by scores.
+Entry type with no scoring system uses the 1-10 one.
+*/
+type ScoringSystem string
+
+const (
+	TenPointScoring     ScoringSystem = "1-10"
+	HundredPointScoring ScoringSystem = "1-100"
+	FiveStarScoring     ScoringSystem = "5 stars"
+	ThumbsScoring       ScoringSystem = "thumbs"
+)
+
+//Highest score on the scale scores of all systems are normalized to
+const NormalizedMaxScore = 10
+
+const (
+	fullStar  = "★"
+	halfStar  = "½"
+	thumbDown = "down"
+	thumbUp   = "up"
+)
+
+func ScoringSystems() []ScoringSystem {
+	return []ScoringSystem{TenPointScoring, HundredPointScoring, FiveStarScoring, ThumbsScoring}
+}
+
+func IsValidScoringSystem(system ScoringSystem) bool {
+	if system == "" {
+		return true
+	}
+	for _, validSystem := range ScoringSystems() {
+		if system == validSystem {
+			return true
+		}
+	}
+	return false
+}
+
+func scoringSystemsAsText() string {
+	systems := []string{}
+	for _, system := range ScoringSystems() {
+		systems = append(systems, string(system))
+	}
+	return strings.Join(systems, ", ")
+}
+
+func (entryType EntryType) Scoring() ScoringSystem {
+	if entryType.ScoringSystem == "" {
+		return TenPointScoring
+	}
+	return entryType.ScoringSystem
+}
+
+func (system ScoringSystem) MaxScore() int {
+	switch system {
+	case HundredPointScoring:
+		return 100
+	case ThumbsScoring:
+		return 2
+	}
+	return 10
+}
+
+func (system ScoringSystem) IsValidScore(score int) bool {
+	return score >= 0 && score <= system.MaxScore()
+}
+
+//Returns the score as it's displayed, stars and thumbs of an entry that has not been scored are not displayed at all
+func (system ScoringSystem) FormatScore(score int) string {
+	switch system {
+	case FiveStarScoring:
+		return strings.Repeat(fullStar, score/2) + strings.Repeat(halfStar, score%2)
+	case ThumbsScoring:
+		switch score {
+		case 1:
+			return thumbDown
+		case 2:
+			return thumbUp
+		}
+		return ""
+	}
+	return strconv.Itoa(score)
+}
+
+//Accepts scores formatted by FormatScore and, for stars, also amounts of stars like "3.5". Empty text means no score.
+func (system ScoringSystem) ParseScore(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	var score int
+	var err error
+	switch system {
+	case FiveStarScoring:
+		score, err = parseStars(text)
+	case ThumbsScoring:
+		score, err = parseThumb(text)
+	default:
+		score, err = strconv.Atoi(text)
+	}
+	if err != nil || !system.IsValidScore(score) {
+		return 0, system.invalidScoreError(text)
+	}
+	return score, nil
+}
+
+func parseStars(text string) (int, error) {
+	if strings.Trim(text, fullStar+halfStar) == "" {
+		return 2*strings.Count(text, fullStar) + strings.Count(text, halfStar), nil
+	}
+	stars, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+	if err != nil || stars*2 != float64(int(stars*2)) {
+		return 0, errors.New("Not an amount of half stars")
+	}
+	return int(stars * 2), nil
+}
+
+func parseThumb(text string) (int, error) {
+	switch strings.ToLower(text) {
+	case thumbDown, "-":
+		return 1, nil
+	case thumbUp, "+":
+		return 2, nil
+	}
+	return 0, errors.New("Not a thumb")
+}
+
+func (system ScoringSystem) invalidScoreError(text string) error {
+	switch system {
+	case FiveStarScoring:
+		return errors.New("Score '" + text + "' has to be an amount of stars from 0.5 to 5 in halves, e.g. 3.5")
+	case ThumbsScoring:
+		return errors.New("Score '" + text + "' has to be '" + thumbUp + "' or '" + thumbDown + "'")
+	}
+	return errors.New("Score '" + text + "' has to be a number from 1 to " + strconv.Itoa(system.MaxScore()))
+}
+
+//Scales the score from 1 to the highest score of the system onto the scale from 1 to NormalizedMaxScore,
+//so e.g. a thumb down is 1 and a thumb up is 10. Entry that has not been scored has score 0 on every scale.
+func (system ScoringSystem) NormalizedScore(score int) float64 {
+	if score <= 0 {
+		return 0
+	}
+	maxScore := system.MaxScore()
+	return 1 + float64(score-1)*float64(NormalizedMaxScore-1)/float64(maxScore-1)
+}
+
+//Returns the score of the system that is the closest to the score of the other system after normalization
+func (system ScoringSystem) convertScoreFrom(otherSystem ScoringSystem, score int) int {
+	if score <= 0 {
+		return 0
+	}
+	normalizedScore := otherSystem.NormalizedScore(score)
+	return int(math.Round(1 + (normalizedScore-1)*float64(system.MaxScore()-1)/float64(NormalizedMaxScore-1)))
+}
+
+func (entryType EntryType) NormalizedScoreOf(entry Entry) float64 {
+	return entryType.Scoring().NormalizedScore(entry.Score)
+}
+
+//Returns the entries sorted from the highest normalized score to the lowest, entries with equal scores stay in the same order
+func SortedByScore(entries []FilteredEntry) []FilteredEntry {
+	sortedEntries := append([]FilteredEntry{}, entries...)
+	sort.SliceStable(sortedEntries, func(i, j int) bool {
+		return sortedEntries[i].Type.NormalizedScoreOf(sortedEntries[i].Entry) > sortedEntries[j].Type.NormalizedScoreOf(sortedEntries[j].Entry)
+	})
+	return sortedEntries
+}
@@ -0,0 +1,90 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"wirwl/internal/log"
+)
+
+func TestThatScoresAreFormattedAndParsedAccordingToScoringSystem(t *testing.T) {
+	assert.Equal(t, "7", TenPointScoring.FormatScore(7))
+	assert.Equal(t, "★★★½", FiveStarScoring.FormatScore(7))
+	assert.Equal(t, "", FiveStarScoring.FormatScore(0))
+	assert.Equal(t, "up", ThumbsScoring.FormatScore(2))
+	for _, system := range ScoringSystems() {
+		for score := 0; score <= system.MaxScore(); score++ {
+			parsedScore, err := system.ParseScore(system.FormatScore(score))
+			assert.Nil(t, err)
+			assert.Equal(t, score, parsedScore)
+		}
+	}
+	score, err := FiveStarScoring.ParseScore("3.5")
+	assert.Nil(t, err)
+	assert.Equal(t, 7, score)
+	score, err = ThumbsScoring.ParseScore("Down")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, score)
+}
+
+func TestThatScoresOutsideOfScoringSystemCannotBeParsed(t *testing.T) {
+	_, err := TenPointScoring.ParseScore("11")
+	assert.EqualError(t, err, "Score '11' has to be a number from 1 to 10")
+	_, err = HundredPointScoring.ParseScore("great")
+	assert.EqualError(t, err, "Score 'great' has to be a number from 1 to 100")
+	_, err = FiveStarScoring.ParseScore("3.3")
+	assert.EqualError(t, err, "Score '3.3' has to be an amount of stars from 0.5 to 5 in halves, e.g. 3.5")
+	_, err = FiveStarScoring.ParseScore("6")
+	assert.NotNil(t, err)
+	_, err = ThumbsScoring.ParseScore("sideways")
+	assert.EqualError(t, err, "Score 'sideways' has to be 'up' or 'down'")
+}
+
+func TestThatScoresAreNormalizedToCommonScale(t *testing.T) {
+	assert.Equal(t, 7.0, TenPointScoring.NormalizedScore(7))
+	assert.Equal(t, 10.0, HundredPointScoring.NormalizedScore(100))
+	assert.Equal(t, 1.0, HundredPointScoring.NormalizedScore(1))
+	assert.Equal(t, 7.0, FiveStarScoring.NormalizedScore(7))
+	assert.Equal(t, 1.0, ThumbsScoring.NormalizedScore(1))
+	assert.Equal(t, 10.0, ThumbsScoring.NormalizedScore(2))
+	assert.Equal(t, 0.0, HundredPointScoring.NormalizedScore(0))
+	assert.Equal(t, 7.0, EntryType{}.NormalizedScoreOf(Entry{Score: 7}))
+}
+
+func TestThatEntriesOfDifferentTypesAreSortedByNormalizedScores(t *testing.T) {
+	hundredPointType := EntryType{Name: "books", ScoringSystem: HundredPointScoring}
+	thumbsType := EntryType{Name: "games", ScoringSystem: ThumbsScoring}
+	entries := []FilteredEntry{
+		{hundredPointType, Entry{Id: 0, Score: 80}},
+		{thumbsType, Entry{Id: 1, Score: 2}},
+		{EntryType{}, Entry{Id: 2, Score: 0}},
+		{EntryType{}, Entry{Id: 3, Score: 9}},
+	}
+	sortedEntries := SortedByScore(entries)
+	assert.Equal(t, []FilteredEntry{entries[1], entries[3], entries[0], entries[2]}, sortedEntries)
+	assert.True(t, Filter{MinScore: 8}.Matches(hundredPointType, Entry{Score: 80}, filterTestNow))
+	assert.False(t, Filter{MinScore: 8}.Matches(hundredPointType, Entry{Score: 75}, filterTestNow))
+}
+
+func TestThatScoresAreValidatedAndConvertedWhenScoringSystemChanges(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = container.UpdateEntryType("comics", EntryType{Name: "comics", ScoringSystem: "letters"})
+	assert.EqualError(t, err, "There is no scoring system 'letters', it has to be one of: 1-10, 1-100, 5 stars, thumbs")
+	updatedType := comicsEntryType
+	updatedType.ScoringSystem = HundredPointScoring
+	assert.Nil(t, container.UpdateEntryType("comics", updatedType))
+	assert.Equal(t, 23, container.entries[updatedType][0].Score)
+	assert.Equal(t, 56, container.entries[updatedType][1].Score)
+	entry := container.entries[updatedType][0]
+	entry.Score = 101
+	err = container.UpdateEntry("comics", entry)
+	assert.EqualError(t, err, "Entry 'some comic1' cannot have score 101 as entry type 'comics' scores from 1 to 100")
+	_, err = container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, container.entries[comicsEntryType][0].Score)
+	_, err = container.AddEntry("comics", Entry{Title: "new comic", Status: PlannedStatus, Score: 11})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,332 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+)
+
+/*
+Series group entries that belong to the same franchise, e.g. all volumes of a manga and its anime, so their progress
+can be followed as a whole. Like lists, series only refer to their members, which can be of any entry type. Unlike lists,
+an entry can be a member of only one series, as it can only belong to one franchise, so members are removed along with
+their entries, like relations, and restored when the deletion gets undone. Otherwise a new entry that gets the id of
+a deleted one would take over its membership.
+*/
+type Series struct {
+	Name    string
+	Members []ListItem
+}
+
+//Member of a series at its position in the series, kept to restore the member after its entry gets restored
+type SeriesMember struct {
+	SeriesName string
+	Position   int
+	Member     ListItem
+}
+
+//Progress of the existing members of a series taken together
+type SeriesProgress struct {
+	//Built-in status describing the whole series, see EntriesContainer.ProgressOfSeries
+	Status            EntryStatus
+	Members           int
+	CompletedMembers  int
+	ElementsCompleted int
+	TotalElements     int
+}
+
+//Returns copies of all series sorted by their names
+func (container *EntriesContainer) AllSeries() []Series {
+	allSeries := []Series{}
+	for _, series := range container.series {
+		allSeries = append(allSeries, series.copy())
+	}
+	return allSeries
+}
+
+func (container *EntriesContainer) SeriesWithName(name string) (Series, error) {
+	num, exists := container.seriesNum(name)
+	if !exists {
+		return Series{}, errors.New("Cannot retrieve series with name '" + name + "' as such series doesn't exist")
+	}
+	return container.series[num].copy(), nil
+}
+
+func (container *EntriesContainer) seriesNum(name string) (int, bool) {
+	for i, series := range container.series {
+		if series.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+//Returns the series the entry is a member of, if there is one
+func (container *EntriesContainer) SeriesOf(typeName string, entryId int) (Series, bool) {
+	for _, series := range container.series {
+		if _, isMember := series.PositionOf(typeName, entryId); isMember {
+			return series.copy(), true
+		}
+	}
+	return Series{}, false
+}
+
+func (series Series) copy() Series {
+	series.Members = append([]ListItem{}, series.Members...)
+	return series
+}
+
+//Returns the position of the member referring to the entry, if the entry is a member of the series
+func (series Series) PositionOf(typeName string, entryId int) (int, bool) {
+	for i, member := range series.Members {
+		if member.TypeName == typeName && member.EntryId == entryId {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (container *EntriesContainer) CreateSeries(name string) error {
+	record := JournalRecord{Operation: CreateSeriesOperation, Series: &Series{Name: name}}
+	return container.execute(record, "creating series '"+name+"'")
+}
+
+func (container *EntriesContainer) DeleteSeries(name string) error {
+	record := JournalRecord{Operation: DeleteSeriesOperation, SeriesName: name}
+	return container.execute(record, "deleting series '"+name+"'")
+}
+
+//Entry is added as the last member of the series
+func (container *EntriesContainer) AddToSeries(seriesName string, typeName string, entryId int) error {
+	record := JournalRecord{Operation: AddToSeriesOperation, SeriesName: seriesName, TypeName: typeName, EntryId: entryId}
+	if num, exists := container.seriesNum(seriesName); exists {
+		record.Position = len(container.series[num].Members)
+	}
+	return container.execute(record, "adding "+container.describeEntry(typeName, entryId)+" to series '"+seriesName+"'")
+}
+
+func (container *EntriesContainer) RemoveFromSeries(seriesName string, position int) error {
+	record := JournalRecord{Operation: RemoveFromSeriesOperation, SeriesName: seriesName, Position: position}
+	return container.execute(record, "removing "+container.describeSeriesMember(seriesName, position)+" from series '"+seriesName+"'")
+}
+
+func (container *EntriesContainer) describeSeriesMember(seriesName string, position int) string {
+	num, exists := container.seriesNum(seriesName)
+	if !exists || position < 0 || position >= len(container.series[num].Members) {
+		return "member at position " + strconv.Itoa(position)
+	}
+	member := container.series[num].Members[position]
+	return container.describeEntry(member.TypeName, member.EntryId)
+}
+
+//Created series has no members unless it's restored after being deleted
+func (container *EntriesContainer) createSeries(record JournalRecord) (JournalRecord, error) {
+	series := record.Series.copy()
+	if series.Name == "" {
+		return JournalRecord{}, errors.New("Cannot create series with an empty name")
+	} else if _, exists := container.seriesNum(series.Name); exists {
+		return JournalRecord{}, errors.New("Series with name '" + series.Name + "' already exists")
+	}
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	container.series = append(container.series, series)
+	sort.Slice(container.series, func(i, j int) bool { return container.series[i].Name < container.series[j].Name })
+	container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesCreatedChange, SeriesAfter: &series})
+	return JournalRecord{Operation: DeleteSeriesOperation, SeriesName: series.Name}, nil
+}
+
+//Deleted series is restored with all of its members when the deletion gets reverted
+func (container *EntriesContainer) deleteSeries(record JournalRecord) (JournalRecord, error) {
+	num, exists := container.seriesNum(record.SeriesName)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot delete series with name '" + record.SeriesName + "' as there is no such series")
+	}
+	err := container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	series := container.series[num]
+	container.series = append(container.series[:num:num], container.series[num+1:]...)
+	container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesDeletedChange, SeriesBefore: &series})
+	return JournalRecord{Operation: CreateSeriesOperation, Series: &series}, nil
+}
+
+//Only existing entries that are not members of any series can be added
+func (container *EntriesContainer) addToSeries(record JournalRecord) (JournalRecord, error) {
+	return container.updateSeries(record.SeriesName, "add entry to", record, func(series Series) (Series, JournalRecord, error) {
+		entry, exists := container.entryWithId(record.TypeName, record.EntryId)
+		if !exists {
+			return series, JournalRecord{}, errors.New("Cannot add entry with id " + strconv.Itoa(record.EntryId) + " of entry type '" + record.TypeName + "' to series '" + series.Name + "' as no such entry exists")
+		} else if otherSeries, isMember := container.SeriesOf(record.TypeName, record.EntryId); isMember {
+			return series, JournalRecord{}, errors.New("Entry '" + entry.Title + "' is already a member of series '" + otherSeries.Name + "'")
+		} else if record.Position < 0 || record.Position > len(series.Members) {
+			return series, JournalRecord{}, errors.New("Cannot add entry '" + entry.Title + "' to series '" + series.Name + "' at position " + strconv.Itoa(record.Position))
+		}
+		member := ListItem{TypeName: record.TypeName, EntryId: record.EntryId}
+		series.Members = append(series.Members[:record.Position], append([]ListItem{member}, series.Members[record.Position:]...)...)
+		return series, JournalRecord{Operation: RemoveFromSeriesOperation, SeriesName: series.Name, Position: record.Position}, nil
+	})
+}
+
+func (container *EntriesContainer) removeFromSeries(record JournalRecord) (JournalRecord, error) {
+	return container.updateSeries(record.SeriesName, "remove entry from", record, func(series Series) (Series, JournalRecord, error) {
+		if record.Position < 0 || record.Position >= len(series.Members) {
+			return series, JournalRecord{}, errors.New("Cannot remove member at position " + strconv.Itoa(record.Position) + " from series '" + series.Name + "' as there is no such member")
+		}
+		member := series.Members[record.Position]
+		series.Members = append(series.Members[:record.Position], series.Members[record.Position+1:]...)
+		return series, JournalRecord{Operation: AddToSeriesOperation, SeriesName: series.Name, TypeName: member.TypeName, EntryId: member.EntryId, Position: record.Position}, nil
+	})
+}
+
+//Change is made on a copy of the series, so the series stays the same if the change fails
+func (container *EntriesContainer) updateSeries(seriesName string, changeName string, record JournalRecord, change func(Series) (Series, JournalRecord, error)) (JournalRecord, error) {
+	num, exists := container.seriesNum(seriesName)
+	if !exists {
+		return JournalRecord{}, errors.New("Cannot " + changeName + " series '" + seriesName + "' as no such series exists")
+	}
+	seriesBefore := container.series[num]
+	seriesAfter, revertingRecord, err := change(seriesBefore.copy())
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	err = container.recordInJournal(record)
+	if err != nil {
+		return JournalRecord{}, err
+	}
+	container.series[num] = seriesAfter
+	container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesUpdatedChange, SeriesBefore: &seriesBefore, SeriesAfter: &seriesAfter})
+	return revertingRecord, nil
+}
+
+//Renaming members is a part of renaming the entry type so it's not recorded in the journal on its own
+func (container *EntriesContainer) renameEntryTypeInSeries(oldName string, newName string) {
+	for num, seriesBefore := range container.series {
+		seriesAfter := seriesBefore.copy()
+		renamed := false
+		for i, member := range seriesAfter.Members {
+			if member.TypeName == oldName {
+				seriesAfter.Members[i].TypeName = newName
+				renamed = true
+			}
+		}
+		if renamed {
+			container.series[num] = seriesAfter
+			container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesUpdatedChange, SeriesBefore: &seriesBefore, SeriesAfter: &seriesAfter})
+		}
+	}
+}
+
+//Removing members is a part of deleting their entries so it's not recorded in the journal on its own
+func (container *EntriesContainer) removeSeriesMembersOfEntries(isRemovedEntry func(typeName string, entryId int) bool) []SeriesMember {
+	removedMembers := []SeriesMember{}
+	for num, seriesBefore := range container.series {
+		seriesAfter := Series{Name: seriesBefore.Name, Members: []ListItem{}}
+		for position, member := range seriesBefore.Members {
+			if isRemovedEntry(member.TypeName, member.EntryId) {
+				removedMembers = append(removedMembers, SeriesMember{seriesBefore.Name, position, member})
+			} else {
+				seriesAfter.Members = append(seriesAfter.Members, member)
+			}
+		}
+		if len(seriesAfter.Members) != len(seriesBefore.Members) {
+			container.series[num] = seriesAfter
+			container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesUpdatedChange, SeriesBefore: &seriesBefore, SeriesAfter: &seriesAfter})
+		}
+	}
+	return removedMembers
+}
+
+//Members are removed in the order of their positions, so inserting them in the same order puts them back where they were
+func (container *EntriesContainer) restoreSeriesMembers(members []SeriesMember) {
+	for _, member := range members {
+		num, exists := container.seriesNum(member.SeriesName)
+		if !exists {
+			continue
+		}
+		seriesBefore := container.series[num]
+		seriesAfter := seriesBefore.copy()
+		position := member.Position
+		if position < 0 || position > len(seriesAfter.Members) {
+			position = len(seriesAfter.Members)
+		}
+		seriesAfter.Members = append(seriesAfter.Members[:position], append([]ListItem{member.Member}, seriesAfter.Members[position:]...)...)
+		container.series[num] = seriesAfter
+		container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesUpdatedChange, SeriesBefore: &seriesBefore, SeriesAfter: &seriesAfter})
+	}
+}
+
+func (container *EntriesContainer) moveEntriesInSeries(movedMembers map[ListItem]ListItem) {
+	for num, seriesBefore := range container.series {
+		seriesAfter := seriesBefore.copy()
+		moved := false
+		for i, member := range seriesAfter.Members {
+			if movedMember, exists := movedMembers[member]; exists {
+				seriesAfter.Members[i] = movedMember
+				moved = true
+			}
+		}
+		if moved {
+			container.series[num] = seriesAfter
+			container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesUpdatedChange, SeriesBefore: &seriesBefore, SeriesAfter: &seriesAfter})
+		}
+	}
+}
+
+//Restored series replaces the series with its name, as it's only restored along with the entries it contained
+func (container *EntriesContainer) restoreSeries(series Series) {
+	num, exists := container.seriesNum(series.Name)
+	if !exists {
+		return
+	}
+	seriesBefore, seriesAfter := container.series[num], series.copy()
+	container.series[num] = seriesAfter
+	container.notifyListenersAboutChange(ChangeEvent{Kind: SeriesUpdatedChange, SeriesBefore: &seriesBefore, SeriesAfter: &seriesAfter})
+}
+
+//Members count by the built-in statuses their statuses belong to. A series is in progress when any of its members is,
+//or when some of them have been completed and others haven't, and it's completed when all of its members that have not
+//been dropped are completed. Series with no existing members is planned.
+func (container *EntriesContainer) ProgressOfSeries(name string) (SeriesProgress, error) {
+	series, err := container.SeriesWithName(name)
+	if err != nil {
+		return SeriesProgress{}, err
+	}
+	progress := SeriesProgress{}
+	membersPerStatus := map[EntryStatus]int{}
+	for _, member := range series.Members {
+		entryType, _ := container.EntryTypeWithName(member.TypeName)
+		entry, exists := container.entryWithId(member.TypeName, member.EntryId)
+		if !exists {
+			continue
+		}
+		progress.Members++
+		progress.ElementsCompleted += entry.ElementsCompleted
+		progress.TotalElements += entry.TotalAmountOfElementsToComplete
+		membersPerStatus[entryType.CategoryOf(entry.Status)]++
+	}
+	progress.CompletedMembers = membersPerStatus[CompletedStatus]
+	progress.Status = seriesStatus(membersPerStatus, progress.Members)
+	return progress, nil
+}
+
+func seriesStatus(membersPerStatus map[EntryStatus]int, members int) EntryStatus {
+	completed, dropped := membersPerStatus[CompletedStatus], membersPerStatus[DroppedStatus]
+	switch {
+	case members == 0:
+		return PlannedStatus
+	case membersPerStatus[InProgressStatus] > 0:
+		return InProgressStatus
+	case completed > 0 && completed+dropped == members:
+		return CompletedStatus
+	case membersPerStatus[OnHoldStatus] > 0:
+		return OnHoldStatus
+	case completed > 0:
+		return InProgressStatus
+	case dropped == members:
+		return DroppedStatus
+	}
+	return PlannedStatus
+}
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func createContainerWithTestSeries() *EntriesContainer {
+	container := createLoadedTestContainer()
+	_ = container.CreateSeries("franchise")
+	_ = container.AddToSeries("franchise", "comics", 0)
+	_ = container.AddToSeries("franchise", "comics", 1)
+	_ = container.AddToSeries("franchise", "videos", 0)
+	return container
+}
+
+func TestThatEntriesOfAnyTypeCanBeMembersOfSeries(t *testing.T) {
+	container := createContainerWithTestSeries()
+	series, err := container.SeriesWithName("franchise")
+	assert.Nil(t, err)
+	assert.Equal(t, []ListItem{{"comics", 0}, {"comics", 1}, {"videos", 0}}, series.Members)
+	seriesOfEntry, isMember := container.SeriesOf("videos", 0)
+	assert.True(t, isMember)
+	assert.Equal(t, "franchise", seriesOfEntry.Name)
+	_, isMember = container.SeriesOf("videos", 1)
+	assert.False(t, isMember)
+	assert.Nil(t, container.CreateSeries("another franchise"))
+	assert.Equal(t, "another franchise", container.AllSeries()[0].Name)
+}
+
+func TestThatInvalidChangesOfSeriesAreNotMade(t *testing.T) {
+	container := createContainerWithTestSeries()
+	_ = container.CreateSeries("other")
+	assert.EqualError(t, container.CreateSeries(""), "Cannot create series with an empty name")
+	assert.EqualError(t, container.CreateSeries("franchise"), "Series with name 'franchise' already exists")
+	assert.EqualError(t, container.AddToSeries("other", "comics", 0), "Entry 'some comic1' is already a member of series 'franchise'")
+	assert.EqualError(t, container.AddToSeries("other", "comics", 7), "Cannot add entry with id 7 of entry type 'comics' to series 'other' as no such entry exists")
+	assert.EqualError(t, container.AddToSeries("missing", "music", 0), "Cannot add entry to series 'missing' as no such series exists")
+	assert.EqualError(t, container.RemoveFromSeries("franchise", 3), "Cannot remove member at position 3 from series 'franchise' as there is no such member")
+	assert.EqualError(t, container.DeleteSeries("missing"), "Cannot delete series with name 'missing' as there is no such series")
+}
+
+func TestThatChangesOfSeriesCanBeUndone(t *testing.T) {
+	container := createContainerWithTestSeries()
+	assert.Nil(t, container.RemoveFromSeries("franchise", 1))
+	assert.Nil(t, container.DeleteSeries("franchise"))
+	assert.Empty(t, container.AllSeries())
+	_, _ = container.Undo()
+	change, err := container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, "removing entry 'some comic2' from series 'franchise'", change.Description)
+	series, _ := container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"comics", 0}, {"comics", 1}, {"videos", 0}}, series.Members)
+}
+
+func TestThatMembersOfSeriesFollowRenamedEntryTypeAndAreRemovedWithTheirEntriesUntilDeletionIsUndone(t *testing.T) {
+	container := createContainerWithTestSeries()
+	renamedType := comicsEntryType
+	renamedType.Name = "manga"
+	assert.Nil(t, container.UpdateEntryType("comics", renamedType))
+	series, _ := container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"manga", 0}, {"manga", 1}, {"videos", 0}}, series.Members)
+	assert.Nil(t, container.DeleteEntryType("videos"))
+	series, _ = container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"manga", 0}, {"manga", 1}}, series.Members)
+	_, _ = container.Undo()
+	assert.Nil(t, container.DeleteEntry("manga", 1))
+	series, _ = container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"manga", 0}, {"videos", 0}}, series.Members)
+	id, err := container.AddEntry("manga", Entry{Title: "new manga", Status: InProgressStatus})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, id)
+	_, isMember := container.SeriesOf("manga", 1)
+	assert.False(t, isMember)
+	_, _ = container.Undo()
+	_, _ = container.Undo()
+	series, _ = container.SeriesWithName("franchise")
+	assert.Equal(t, []ListItem{{"manga", 0}, {"manga", 1}, {"videos", 0}}, series.Members)
+}
+
+func TestThatProgressOfSeriesIsAggregatedFromItsMembers(t *testing.T) {
+	container := createContainerWithTestSeries()
+	progress, err := container.ProgressOfSeries("franchise")
+	assert.Nil(t, err)
+	entries := container.entries[comicsEntryType]
+	assert.Equal(t, SeriesProgress{Status: InProgressStatus, Members: 3, CompletedMembers: 0,
+		ElementsCompleted: entries[0].ElementsCompleted + entries[1].ElementsCompleted + container.entries[videoEntryType][0].ElementsCompleted,
+		TotalElements: entries[0].TotalAmountOfElementsToComplete + entries[1].TotalAmountOfElementsToComplete +
+			container.entries[videoEntryType][0].TotalAmountOfElementsToComplete}, progress)
+	_, err = container.ProgressOfSeries("missing")
+	assert.NotNil(t, err)
+}
+
+func TestThatStatusOfSeriesDependsOnStatusesOfItsMembers(t *testing.T) {
+	statusOf := func(statuses ...EntryStatus) EntryStatus {
+		membersPerStatus := map[EntryStatus]int{}
+		for _, status := range statuses {
+			membersPerStatus[status]++
+		}
+		return seriesStatus(membersPerStatus, len(statuses))
+	}
+	assert.Equal(t, PlannedStatus, statusOf())
+	assert.Equal(t, InProgressStatus, statusOf(CompletedStatus, InProgressStatus, OnHoldStatus))
+	assert.Equal(t, CompletedStatus, statusOf(CompletedStatus, DroppedStatus))
+	assert.Equal(t, OnHoldStatus, statusOf(CompletedStatus, OnHoldStatus))
+	assert.Equal(t, InProgressStatus, statusOf(CompletedStatus, PlannedStatus))
+	assert.Equal(t, DroppedStatus, statusOf(DroppedStatus, DroppedStatus))
+	assert.Equal(t, PlannedStatus, statusOf(DroppedStatus, PlannedStatus))
+}
+
+func TestThatChangesOfSeriesAreReplayedFromJournal(t *testing.T) {
+	journal, cleanup := createTestJournal()
+	defer cleanup()
+	container := createLoadedTestContainer()
+	container.SetJournal(journal)
+	_ = container.CreateSeries("franchise")
+	_ = container.AddToSeries("franchise", "comics", 0)
+	_ = container.AddToSeries("franchise", "music", 1)
+	_ = container.RemoveFromSeries("franchise", 0)
+	replayingContainer := createLoadedTestContainer()
+	replayingContainer.SetJournal(journal)
+	amount, err := replayingContainer.ReplayJournal()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, amount)
+	assert.Equal(t, container.AllSeries(), replayingContainer.AllSeries())
+}
+
+func TestThatSeriesAreSavedAndLoaded(t *testing.T) {
+	testDbPath, cleanup := getTempDbPath()
+	defer cleanup()
+	dataProvider := NewBoltProvider(testDbPath)
+	allSeries, err := dataProvider.LoadSeries()
+	assert.Nil(t, err)
+	assert.Empty(t, allSeries)
+	savedSeries := []Series{{Name: "empty", Members: []ListItem{}}, {Name: "franchise", Members: []ListItem{{"comics", 0}, {"videos", 1}}}}
+	assert.Nil(t, dataProvider.SaveSeries(savedSeries))
+	allSeries, err = dataProvider.LoadSeries()
+	assert.Nil(t, err)
+	assert.Equal(t, savedSeries, allSeries)
+	assert.Nil(t, dataProvider.SaveSeries(savedSeries[1:]))
+	allSeries, err = dataProvider.LoadSeries()
+	assert.Nil(t, err)
+	assert.Equal(t, savedSeries[1:], allSeries)
+}
+
+func TestThatSeriesAreLoadedAndSavedWithEntries(t *testing.T) {
+	provider := NewAbstractProvider()
+	provider.LoadSeriesFunc = func() ([]Series, error) {
+		return []Series{{Name: "second"}, {Name: "first"}}, nil
+	}
+	var savedSeries []Series
+	provider.SaveSeriesFunc = func(series []Series) error {
+		savedSeries = series
+		return nil
+	}
+	container := NewEntriesContainer(provider)
+	assert.Nil(t, container.LoadData())
+	assert.Equal(t, "first", container.AllSeries()[0].Name)
+	assert.Nil(t, container.SaveData())
+	assert.Equal(t, 2, len(savedSeries))
+}
@@ -0,0 +1,153 @@
+package data
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+)
+
+/*
+Every entry type has its own ordered list of statuses its entries can have, e.g. games can be in "Backlog" or at "100%".
+Each of them belongs to one of the built-in statuses, which serve as categories, so it's known e.g. which entries have
+been completed or should be marked as in progress no matter how their statuses are named. Statuses are kept in the entry
+type as text, e.g. "Backlog=Planned, Playing=In progress, 100%=Completed", so entry types stay comparable. A built-in
+status can be listed without its category as it's the category of itself. Entry type with no statuses has the built-in ones.
+*/
+type TypeStatus struct {
+	Name     EntryStatus
+	Category EntryStatus
+}
+
+const statusesSeparator = ","
+const categorySeparator = "="
+
+//Returns the statuses described by the text in the order they are listed in it, see TypeStatus
+func ParseStatuses(text string) ([]TypeStatus, error) {
+	if strings.TrimSpace(text) == "" {
+		return builtInTypeStatuses(), nil
+	}
+	statuses := []TypeStatus{}
+	names := make(map[EntryStatus]bool)
+	for _, statusText := range strings.Split(text, statusesSeparator) {
+		status, err := parseStatus(statusText)
+		if err != nil {
+			return nil, err
+		}
+		if names[status.Name] {
+			return nil, errors.New("Status '" + string(status.Name) + "' is listed more than once")
+		}
+		names[status.Name] = true
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func parseStatus(text string) (TypeStatus, error) {
+	nameAndCategory := strings.SplitN(text, categorySeparator, 2)
+	name := EntryStatus(strings.TrimSpace(nameAndCategory[0]))
+	if name == "" {
+		return TypeStatus{}, errors.New("Status cannot have an empty name")
+	}
+	if len(nameAndCategory) == 1 {
+		if !IsValidStatus(name) {
+			return TypeStatus{}, errors.New("Status '" + string(name) + "' has to belong to one of the built-in statuses, e.g. '" +
+				string(name) + categorySeparator + string(PlannedStatus) + "'")
+		}
+		return TypeStatus{name, name}, nil
+	}
+	category := EntryStatus(strings.TrimSpace(nameAndCategory[1]))
+	if !IsValidStatus(category) {
+		return TypeStatus{}, errors.New("Status '" + string(name) + "' cannot belong to '" + string(category) +
+			"' as it's not one of the built-in statuses: " + builtInStatusesAsText())
+	}
+	return TypeStatus{name, category}, nil
+}
+
+func builtInTypeStatuses() []TypeStatus {
+	statuses := []TypeStatus{}
+	for _, status := range EntryStatuses() {
+		statuses = append(statuses, TypeStatus{status, status})
+	}
+	return statuses
+}
+
+func builtInStatusesAsText() string {
+	statuses := []string{}
+	for _, status := range EntryStatuses() {
+		statuses = append(statuses, string(status))
+	}
+	return strings.Join(statuses, statusesSeparator+" ")
+}
+
+//Returns the text describing the statuses that ParseStatuses turns back into the same statuses
+func FormatStatuses(statuses []TypeStatus) string {
+	statusesTexts := []string{}
+	for _, status := range statuses {
+		if status.Name == status.Category {
+			statusesTexts = append(statusesTexts, string(status.Name))
+		} else {
+			statusesTexts = append(statusesTexts, string(status.Name)+categorySeparator+string(status.Category))
+		}
+	}
+	return strings.Join(statusesTexts, statusesSeparator+" ")
+}
+
+//Statuses of an entry type are validated when it's added or updated, so the built-in ones are only returned
+//for an entry type that has been created with incorrect statuses in some other way
+func (entryType EntryType) StatusesList() []TypeStatus {
+	statuses, err := ParseStatuses(entryType.Statuses)
+	if err != nil {
+		return builtInTypeStatuses()
+	}
+	return statuses
+}
+
+func (entryType EntryType) StatusNames() []EntryStatus {
+	names := []EntryStatus{}
+	for _, status := range entryType.StatusesList() {
+		names = append(names, status.Name)
+	}
+	return names
+}
+
+func (entryType EntryType) HasStatus(status EntryStatus) bool {
+	for _, typeStatus := range entryType.StatusesList() {
+		if typeStatus.Name == status {
+			return true
+		}
+	}
+	return false
+}
+
+//Status that the entry type doesn't have is the category of itself
+func (entryType EntryType) CategoryOf(status EntryStatus) EntryStatus {
+	for _, typeStatus := range entryType.StatusesList() {
+		if typeStatus.Name == status {
+			return typeStatus.Category
+		}
+	}
+	return status
+}
+
+//Returns the first status of the entry type that belongs to the category or, if there is no such status,
+//the first status of the entry type
+func (entryType EntryType) StatusIn(category EntryStatus) EntryStatus {
+	statuses := entryType.StatusesList()
+	for _, status := range statuses {
+		if status.Category == category {
+			return status.Name
+		}
+	}
+	return statuses[0].Name
+}
+
+//Entry keeps its status if it's also a status of the new entry type, otherwise it gets the status of the new entry
+//type in the same category, see EntryType.StatusIn. Its score is converted to the scoring system of the new entry type.
+func (entry Entry) migratedTo(oldEntryType EntryType, newEntryType EntryType) Entry {
+	if !newEntryType.HasStatus(entry.Status) {
+		entry.Status = newEntryType.StatusIn(oldEntryType.CategoryOf(entry.Status))
+	}
+	if oldEntryType.Scoring() != newEntryType.Scoring() {
+		entry.Score = newEntryType.Scoring().convertScoreFrom(oldEntryType.Scoring(), entry.Score)
+	}
+	return entry
+}
@@ -0,0 +1,105 @@
+package data
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"wirwl/internal/log"
+)
+
+var gamesEntryType = EntryType{Name: "games", Statuses: "Backlog=Planned, Playing=In progress, Beaten=Completed, 100%=Completed, Dropped"}
+
+func TestThatStatusesAreParsedInOrderWithTheirCategories(t *testing.T) {
+	statuses, err := ParseStatuses(" Backlog = Planned,Playing=In progress, Dropped")
+	assert.Nil(t, err)
+	assert.Equal(t, []TypeStatus{{"Backlog", PlannedStatus}, {"Playing", InProgressStatus}, {DroppedStatus, DroppedStatus}}, statuses)
+	assert.Equal(t, "Backlog=Planned, Playing=In progress, Dropped", FormatStatuses(statuses))
+}
+
+func TestThatEntryTypeWithoutStatusesHasBuiltInOnes(t *testing.T) {
+	assert.Equal(t, EntryStatuses(), EntryType{}.StatusNames())
+	assert.Equal(t, OnHoldStatus, EntryType{}.CategoryOf(OnHoldStatus))
+}
+
+func TestThatIncorrectStatusesCannotBeParsed(t *testing.T) {
+	_, err := ParseStatuses("Backlog")
+	assert.EqualError(t, err, "Status 'Backlog' has to belong to one of the built-in statuses, e.g. 'Backlog=Planned'")
+	_, err = ParseStatuses("Backlog=Someday")
+	assert.EqualError(t, err, "Status 'Backlog' cannot belong to 'Someday' as it's not one of the built-in statuses: "+
+		"In progress, Completed, On hold, Dropped, Planned")
+	_, err = ParseStatuses("Backlog=Planned, Backlog=On hold")
+	assert.EqualError(t, err, "Status 'Backlog' is listed more than once")
+	_, err = ParseStatuses("Backlog=Planned, =Dropped")
+	assert.EqualError(t, err, "Status cannot have an empty name")
+}
+
+func TestThatStatusesOfEntryTypeAreFoundByTheirCategories(t *testing.T) {
+	assert.Equal(t, EntryStatus("Beaten"), gamesEntryType.StatusIn(CompletedStatus))
+	assert.Equal(t, EntryStatus("Backlog"), gamesEntryType.StatusIn(OnHoldStatus))
+	assert.Equal(t, CompletedStatus, gamesEntryType.CategoryOf("100%"))
+	assert.True(t, gamesEntryType.HasStatus("Playing"))
+	assert.False(t, gamesEntryType.HasStatus(InProgressStatus))
+}
+
+func TestThatProgressChangesStatusToStatusesOfEntryType(t *testing.T) {
+	entry := Entry{Status: "Backlog", TotalAmountOfElementsToComplete: 2}
+	entry = entry.WithProgressChangedBy(gamesEntryType, 1, progressChangeDate)
+	assert.Equal(t, EntryStatus("Playing"), entry.Status)
+	entry = entry.WithProgressChangedBy(gamesEntryType, 1, progressChangeDate)
+	assert.Equal(t, EntryStatus("Beaten"), entry.Status)
+	entry.Status = "100%"
+	assert.Equal(t, EntryStatus("100%"), entry.WithProgressChangedBy(gamesEntryType, 0, progressChangeDate).Status)
+	assert.Equal(t, EntryStatus("Playing"), entry.WithProgressChangedBy(gamesEntryType, -1, progressChangeDate).Status)
+}
+
+func TestThatStatusesOfEntriesAreMigratedWhenStatusesOfTheirTypeChange(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.LoadData()
+	if err != nil {
+		log.Fatal(err)
+	}
+	entries := container.entries[comicsEntryType]
+	entries[1].Status = OnHoldStatus
+	updatedType := comicsEntryType
+	updatedType.Statuses = "Reading=In progress, Paused=On hold, Read=Completed"
+	assert.Nil(t, container.UpdateEntryType("comics", updatedType))
+	assert.Equal(t, EntryStatus("Reading"), container.entries[updatedType][0].Status)
+	assert.Equal(t, EntryStatus("Paused"), container.entries[updatedType][1].Status)
+	_, err = container.Undo()
+	assert.Nil(t, err)
+	assert.Equal(t, InProgressStatus, container.entries[comicsEntryType][0].Status)
+	assert.Equal(t, OnHoldStatus, container.entries[comicsEntryType][1].Status)
+}
+
+func TestThatEntryTypeCannotHaveIncorrectStatuses(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	err := container.AddEntryType(EntryType{Name: "games", Statuses: "Backlog"})
+	assert.EqualError(t, err, "Cannot add entry type 'games' with incorrect statuses: "+
+		"Status 'Backlog' has to belong to one of the built-in statuses, e.g. 'Backlog=Planned'")
+	assert.Nil(t, container.AddEntryType(gamesEntryType))
+	err = container.UpdateEntryType("games", EntryType{Name: "games", Statuses: "Backlog=Backlog"})
+	assert.Contains(t, err.Error(), "Cannot update entry type 'games' to type with incorrect statuses")
+}
+
+func TestThatEntriesCanOnlyGetStatusesOfTheirType(t *testing.T) {
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	_ = container.AddEntryType(gamesEntryType)
+	_, err := container.AddEntry("games", Entry{Title: "some game", Status: PlannedStatus})
+	assert.EqualError(t, err, "Entry 'some game' cannot have status 'Planned' as it's not a status of entry type 'games'")
+	id, err := container.AddEntry("games", Entry{Title: "some game", Status: "Backlog"})
+	assert.Nil(t, err)
+	err = container.UpdateEntry("games", Entry{Id: id, Title: "some game", Status: "Finished"})
+	assert.EqualError(t, err, "Entry 'some game' cannot have status 'Finished' as it's not a status of entry type 'games'")
+	assert.Nil(t, container.UpdateEntry("games", Entry{Id: id, Title: "some game", Status: "100%"}))
+}
+
+func TestThatFilterMatchesStatusesBelongingToFilteredStatuses(t *testing.T) {
+	filter := Filter{Statuses: []EntryStatus{CompletedStatus}}
+	assert.True(t, filter.Matches(gamesEntryType, Entry{Status: "100%"}, filterTestNow))
+	assert.False(t, filter.Matches(gamesEntryType, Entry{Status: "Playing"}, filterTestNow))
+	assert.True(t, Filter{Statuses: []EntryStatus{"Playing"}}.Matches(gamesEntryType, Entry{Status: "Playing"}, filterTestNow))
+	container := NewEntriesContainer(NewSampleTestDataProvider(""))
+	_ = container.AddEntryType(gamesEntryType)
+	assert.True(t, container.IsValidStatus("Playing"))
+	assert.True(t, container.IsValidStatus(DroppedStatus))
+	assert.False(t, container.IsValidStatus("Finished"))
+}
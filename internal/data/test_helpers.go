@@ -166,6 +166,34 @@ func (provider *AlwaysFailingProvider) LoadEntries() (map[EntryType][]Entry, err
 	return nil, AlwaysFailingProviderError
 }
 
+func (provider *AlwaysFailingProvider) SaveLists([]EntriesList) error {
+	return AlwaysFailingProviderError
+}
+
+func (provider *AlwaysFailingProvider) LoadLists() ([]EntriesList, error) {
+	return nil, AlwaysFailingProviderError
+}
+
+func (provider *AlwaysFailingProvider) SaveRelations([]Relation) error {
+	return AlwaysFailingProviderError
+}
+
+func (provider *AlwaysFailingProvider) LoadRelations() ([]Relation, error) {
+	return nil, AlwaysFailingProviderError
+}
+
+func (provider *AlwaysFailingProvider) SaveSeries([]Series) error {
+	return AlwaysFailingProviderError
+}
+
+func (provider *AlwaysFailingProvider) LoadSeries() ([]Series, error) {
+	return nil, AlwaysFailingProviderError
+}
+
+func (provider *AlwaysFailingProvider) SaveAll(map[EntryType][]Entry, []EntriesList, []Relation, []Series) error {
+	return AlwaysFailingProviderError
+}
+
 //It's purpose is to provide some semblance of functionality of an actual provider, that is to return some test data
 //on load and a creation of file with some data on save.
 type SampleTestDataProvider struct {
@@ -191,12 +219,50 @@ func (provider SampleTestDataProvider) LoadEntries() (map[EntryType][]Entry, err
 	return testEntries, nil
 }
 
-/*It is supposed to provide default functions that return empty values but every single one can be overwritten
+//Lists are saved along with entries so saving them doesn't have to create the file again
+func (provider SampleTestDataProvider) SaveLists([]EntriesList) error {
+	return nil
+}
+
+func (provider SampleTestDataProvider) LoadLists() ([]EntriesList, error) {
+	return []EntriesList{}, nil
+}
+
+//Relations are saved along with entries like lists
+func (provider SampleTestDataProvider) SaveRelations([]Relation) error {
+	return nil
+}
+
+func (provider SampleTestDataProvider) LoadRelations() ([]Relation, error) {
+	return []Relation{}, nil
+}
+
+//Series are saved along with entries like lists
+func (provider SampleTestDataProvider) SaveSeries([]Series) error {
+	return nil
+}
+
+func (provider SampleTestDataProvider) LoadSeries() ([]Series, error) {
+	return []Series{}, nil
+}
+
+func (provider SampleTestDataProvider) SaveAll(entries map[EntryType][]Entry, _ []EntriesList, _ []Relation, _ []Series) error {
+	return provider.SaveEntries(entries)
+}
+
+/*
+It is supposed to provide default functions that return empty values but every single one can be overwritten
 so that desired functionality when testing can be achieved
 */
 type AbstractProvider struct {
-	SaveEntriesFunc func(map[EntryType][]Entry) error
-	LoadEntriesFunc func() (map[EntryType][]Entry, error)
+	SaveEntriesFunc   func(map[EntryType][]Entry) error
+	LoadEntriesFunc   func() (map[EntryType][]Entry, error)
+	SaveListsFunc     func([]EntriesList) error
+	LoadListsFunc     func() ([]EntriesList, error)
+	SaveRelationsFunc func([]Relation) error
+	LoadRelationsFunc func() ([]Relation, error)
+	SaveSeriesFunc    func([]Series) error
+	LoadSeriesFunc    func() ([]Series, error)
 }
 
 func NewAbstractProvider() *AbstractProvider {
@@ -207,6 +273,24 @@ func NewAbstractProvider() *AbstractProvider {
 		LoadEntriesFunc: func() (map[EntryType][]Entry, error) {
 			return make(map[EntryType][]Entry), nil
 		},
+		SaveListsFunc: func(lists []EntriesList) error {
+			return nil
+		},
+		LoadListsFunc: func() ([]EntriesList, error) {
+			return []EntriesList{}, nil
+		},
+		SaveRelationsFunc: func(relations []Relation) error {
+			return nil
+		},
+		LoadRelationsFunc: func() ([]Relation, error) {
+			return []Relation{}, nil
+		},
+		SaveSeriesFunc: func(series []Series) error {
+			return nil
+		},
+		LoadSeriesFunc: func() ([]Series, error) {
+			return []Series{}, nil
+		},
 	}
 }
 
@@ -217,3 +301,44 @@ func (provider *AbstractProvider) SaveEntries(entries map[EntryType][]Entry) err
 func (provider *AbstractProvider) LoadEntries() (map[EntryType][]Entry, error) {
 	return provider.LoadEntriesFunc()
 }
+
+func (provider *AbstractProvider) SaveLists(lists []EntriesList) error {
+	return provider.SaveListsFunc(lists)
+}
+
+func (provider *AbstractProvider) LoadLists() ([]EntriesList, error) {
+	return provider.LoadListsFunc()
+}
+
+func (provider *AbstractProvider) SaveRelations(relations []Relation) error {
+	return provider.SaveRelationsFunc(relations)
+}
+
+func (provider *AbstractProvider) LoadRelations() ([]Relation, error) {
+	return provider.LoadRelationsFunc()
+}
+
+func (provider *AbstractProvider) SaveSeries(series []Series) error {
+	return provider.SaveSeriesFunc(series)
+}
+
+func (provider *AbstractProvider) LoadSeries() ([]Series, error) {
+	return provider.LoadSeriesFunc()
+}
+
+//Saves everything with the functions saving each part, so tests can make saving fail at any of them
+func (provider *AbstractProvider) SaveAll(entries map[EntryType][]Entry, lists []EntriesList, relations []Relation, series []Series) error {
+	err := provider.SaveEntriesFunc(entries)
+	if err != nil {
+		return err
+	}
+	err = provider.SaveListsFunc(lists)
+	if err != nil {
+		return err
+	}
+	err = provider.SaveRelationsFunc(relations)
+	if err != nil {
+		return err
+	}
+	return provider.SaveSeriesFunc(series)
+}
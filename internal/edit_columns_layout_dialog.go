@@ -0,0 +1,128 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"wirwl/internal/widget"
+)
+
+/*
+The dialog for editing columns layout of the current entry type's table has an input field for every available column.
+Value of every field should be in format 'position' or 'position:width' e.g. '2' or '2:300'.
+Columns are displayed in the order of their positions and columns with empty values are not displayed at all.
+If width is not given, width of the column is adjusted automatically.
+*/
+
+func (app *App) createEditColumnsLayoutDialog() {
+	formItemFactory := widget.NewFormDialogFormItemFactory(app.mainWindow.Canvas(), app.inputHandler)
+	formItems := []*widget.FormDialogFormItem{}
+	for _, column := range entriesTableColumns {
+		formItems = append(formItems, formItemFactory.FormItemWithInputField(column.name))
+	}
+	app.editColumnsLayoutDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Editing columns layout", formItems...)
+	app.editColumnsLayoutDialog.OnEnterPressed = app.onEnterPressedInEditColumnsLayoutDialog
+}
+
+func (app *App) displayDialogForEditingColumnsLayout() {
+	if app.failIfListIsSelected() {
+		return
+	}
+	app.editColumnsLayoutDialog.CleanItemValues()
+	for i, columnSettings := range app.config.ColumnsLayoutFor(app.getCurrentTabText()) {
+		app.editColumnsLayoutDialog.SetItemValue(columnSettings.Name, columnSpecification(i+1, columnSettings.Width))
+	}
+	app.editColumnsLayoutDialog.Display()
+}
+
+func columnSpecification(position int, width int) string {
+	if width > 0 {
+		return strconv.Itoa(position) + ":" + strconv.Itoa(width)
+	}
+	return strconv.Itoa(position)
+}
+
+func (app *App) onEnterPressedInEditColumnsLayoutDialog() {
+	layout, err := app.getColumnsLayoutFromDialog()
+	if err != nil {
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.editColumnsLayoutDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		return
+	}
+	app.config.SetColumnsLayoutFor(app.getCurrentTabText(), layout)
+	app.refreshEntriesViews(app.getCurrentEntryType())
+}
+
+func (app *App) getColumnsLayoutFromDialog() ([]ColumnSettings, error) {
+	specifications := make(map[string]string, len(entriesTableColumns))
+	for _, column := range entriesTableColumns {
+		specifications[column.name] = app.editColumnsLayoutDialog.ItemValue(column.name)
+	}
+	return columnsLayoutFromSpecifications(specifications)
+}
+
+type positionedColumnSettings struct {
+	ColumnSettings
+	position int
+}
+
+//Columns with the same position are ordered in the same way as in the default layout
+func columnsLayoutFromSpecifications(specifications map[string]string) ([]ColumnSettings, error) {
+	positionedColumns := []positionedColumnSettings{}
+	for _, column := range entriesTableColumns {
+		specification := strings.TrimSpace(specifications[column.name])
+		if specification == "" {
+			continue
+		}
+		positionedColumn, err := parseColumnSpecification(column.name, specification)
+		if err != nil {
+			return nil, err
+		}
+		positionedColumns = append(positionedColumns, positionedColumn)
+	}
+	if len(positionedColumns) == 0 {
+		return nil, errors.New("At least one column has to be displayed")
+	}
+	sort.SliceStable(positionedColumns, func(i, j int) bool {
+		return positionedColumns[i].position < positionedColumns[j].position
+	})
+	layout := make([]ColumnSettings, 0, len(positionedColumns))
+	for _, positionedColumn := range positionedColumns {
+		layout = append(layout, positionedColumn.ColumnSettings)
+	}
+	return layout, nil
+}
+
+func parseColumnSpecification(columnName string, specification string) (positionedColumnSettings, error) {
+	parts := strings.Split(specification, ":")
+	if len(parts) > 2 {
+		return positionedColumnSettings{}, errors.New("Column '" + columnName + "' has incorrect value '" + specification + "'. It should be either 'position' or 'position:width'")
+	}
+	position, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || position < 1 {
+		return positionedColumnSettings{}, errors.New("Position of column '" + columnName + "' has to be a number greater than 0")
+	}
+	width := 0
+	if len(parts) == 2 {
+		width, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || width < 1 {
+			return positionedColumnSettings{}, errors.New("Width of column '" + columnName + "' has to be a number greater than 0")
+		}
+	}
+	return positionedColumnSettings{
+		ColumnSettings: ColumnSettings{Name: columnName, Width: width},
+		position:       position,
+	}, nil
+}
+
+//Layouts of deleted entry types are kept until the application starts again so deleting a type can be undone
+func (app *App) removeOrphanedColumnsLayouts() {
+	for entryTypeName := range app.config.ColumnsLayouts {
+		if _, err := app.entriesContainer.EntryTypeWithName(entryTypeName); err != nil {
+			app.config.deleteColumnsLayout(entryTypeName)
+		}
+	}
+}
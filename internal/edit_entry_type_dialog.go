@@ -1,6 +1,7 @@
 package wirwl
 
 import (
+	"strings"
 	"wirwl/internal/data"
 	"wirwl/internal/log"
 	"wirwl/internal/widget"
@@ -11,15 +12,18 @@ func (app *App) createEditEntryTypeDialog() {
 	app.editEntryTypeDialog.OnEnterPressed = app.applyChangesToCurrentEntryType
 }
 
+//Layout and images follow the renamed type, see updateGUIAfterChange. Statuses of entries follow the statuses of
+//the type, see data.EntriesContainer.UpdateEntryType. Type that cannot be updated, e.g. because of its incorrect
+//statuses, can be corrected in the dialog displayed again.
 func (app *App) applyChangesToCurrentEntryType() {
-	currentTabIndex := app.entriesTypesTabs.CurrentTabIndex()
-	nameOfEntryToUpdate := app.getCurrentTabText()
-	entryToUpdateWith := app.getEntryToUpdateWith()
-	err := app.entriesContainer.UpdateEntryType(nameOfEntryToUpdate, entryToUpdateWith)
+	err := app.entriesContainer.UpdateEntryType(app.getCurrentTabText(), app.getEntryToUpdateWith())
 	if err != nil {
 		log.Error(err)
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.editEntryTypeDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
 	}
-	app.entriesTypesTabs.SelectTabIndex(currentTabIndex)
 }
 
 func (app *App) getEntryToUpdateWith() data.EntryType {
@@ -27,5 +31,8 @@ func (app *App) getEntryToUpdateWith() data.EntryType {
 		Name:                  app.editEntryTypeDialog.ItemValue("Name"),
 		CompletionElementName: "",
 		ImageQuery:            app.editEntryTypeDialog.ItemValue("Image query"),
+		MetadataURL:           app.editEntryTypeDialog.ItemValue("Metadata URL"),
+		Statuses:              app.editEntryTypeDialog.ItemValue("Statuses"),
+		ScoringSystem:         data.ScoringSystem(strings.TrimSpace(app.editEntryTypeDialog.ItemValue("Scoring system"))),
 	}
 }
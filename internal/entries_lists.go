@@ -0,0 +1,311 @@
+package wirwl
+
+import (
+	"strconv"
+	"strings"
+	"wirwl/internal/data"
+	"wirwl/internal/input"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Lists group entries of any entry type in a chosen order and are displayed in their own tabs, next to the tabs of entry
+types, as tables that can't be edited directly. Selected entry of a list, or a smart list, is the current entry, so
+actions made on entries, e.g. changing progress, work in lists just like in entry types, while actions made on entry
+types don't. Entries that no longer exist are still displayed in their lists, so their position can be seen and they
+can be removed.
+*/
+
+//Tabs of lists start with the prefix so they can't be confused with tabs of entry types
+const listTabPrefix = data.ListDisplayNamePrefix
+
+const missingListEntryTitle = "Entry no longer exists"
+
+var listTableColumns = []widget.TableColumn{
+	{Type: widget.TextColumn, Name: "Num"},
+	{Type: widget.TextColumn, Name: "Type"},
+	{Type: widget.TextColumn, Name: "Status"},
+	{Type: widget.TextColumn, Name: "Title"},
+	{Type: widget.TextColumn, Name: "Elements completed"},
+}
+
+func listTabName(listName string) string {
+	return listTabPrefix + listName
+}
+
+//Returns the name of the list whose tab is selected, if the tab of a list is selected
+func (app *App) currentListName() (string, bool) {
+	tabText := app.getCurrentTabText()
+	if !strings.HasPrefix(tabText, listTabPrefix) {
+		return "", false
+	}
+	listName := strings.TrimPrefix(tabText, listTabPrefix)
+	_, exists := app.listsTables[listName]
+	return listName, exists
+}
+
+//Returns the table of the list, the smart list or the series whose tab is selected, if the tab of any of them is selected
+func (app *App) currentListTable() (*widget.Table, bool) {
+	if app.isSeriesTabSelected() {
+		return app.seriesTable, true
+	}
+	if listName, isList := app.currentListName(); isList {
+		return app.listsTables[listName], true
+	}
+	if smartListName, isSmartList := app.currentSmartListName(); isSmartList {
+		return app.smartListsTables[smartListName], true
+	}
+	return nil, false
+}
+
+//Returns the item of the current list or smart list, or the member of a series, that is selected in its table, if there is any
+func (app *App) currentListItem() (data.ListItem, bool) {
+	table, isList := app.currentListTable()
+	if !isList {
+		return data.ListItem{}, false
+	}
+	if app.isSeriesTabSelected() {
+		row, _ := app.currentSeriesRow()
+		if row.member == nil {
+			return data.ListItem{}, false
+		}
+		return *row.member, true
+	}
+	items := []data.ListItem{}
+	if listName, isList := app.currentListName(); isList {
+		list, _ := app.entriesContainer.ListWithName(listName)
+		items = list.Items
+	} else if smartListName, isSmartList := app.currentSmartListName(); isSmartList {
+		items = app.smartListItems(smartListName)
+	}
+	position := table.CurrentRowNum()
+	if position >= len(items) {
+		return data.ListItem{}, false
+	}
+	return items[position], true
+}
+
+//Number of the current list item's entry among entries of its type, which is the amount of the entries if it doesn't exist
+func (app *App) currentListEntryNum() int {
+	item, _ := app.currentListItem()
+	entries := app.entriesContainer.EntriesGroupedByType()[app.getCurrentEntryType()]
+	for i, entry := range entries {
+		if entry.Id == item.EntryId {
+			return i
+		}
+	}
+	return len(entries)
+}
+
+//Actions that change entry types can't be made in a list, as entries of a list can belong to many entry types
+func (app *App) failIfListIsSelected() bool {
+	if _, isList := app.currentListTable(); isList {
+		app.msgDialog.Display(widget.WarningPopUp, "This action can only be made in a tab of an entry type!")
+		return true
+	}
+	return false
+}
+
+func (app *App) createListTable(items []data.ListItem) *widget.Table {
+	entriesByType := app.entriesContainer.EntriesGroupedByType()
+	rowData := []widget.TableRow{}
+	for i, item := range items {
+		entryType, _ := app.entriesContainer.EntryTypeWithName(item.TypeName)
+		row := widget.TableRow{newSpreadsheetLabelWithText(strconv.Itoa(i)), newSpreadsheetLabelWithText(item.TypeName)}
+		entry, exists := entryWithIdIn(entriesByType[entryType], item.EntryId)
+		if exists {
+			row = append(row, newSpreadsheetLabelWithText(string(entry.Status)), newSpreadsheetLabelWithText(entry.Title),
+				newSpreadsheetLabelWithText(strconv.Itoa(entry.ElementsCompleted)))
+		} else {
+			row = append(row, newSpreadsheetLabelWithText(""), newSpreadsheetLabelWithText(missingListEntryTitle), newSpreadsheetLabelWithText(""))
+		}
+		rowData = append(rowData, row)
+	}
+	table := widget.NewTable(app.mainWindow.Canvas(), app.inputHandler, listTableColumns, rowData)
+	table.SetOnExitCallbackFunction(table.ExitInputMode)
+	app.inputHandler.BindFunctionWithCountToAction(table, input.IncrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(count) })
+	app.inputHandler.BindFunctionWithCountToAction(table, input.DecrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(-count) })
+	app.inputHandler.BindFunctionToAction(table, input.UndoAction, func() { app.undoLastChange() })
+	app.inputHandler.BindFunctionToAction(table, input.RedoAction, func() { app.redoLastUndoneChange() })
+	app.inputHandler.BindFunctionToAction(table, input.ShowEntryDetailsAction, func() { app.displayDetailsOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.StartRewatchAction, func() { app.startRewatchingCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.RemoveFromListAction, func() { app.removeCurrentEntryFromList() })
+	app.inputHandler.BindFunctionWithCountToAction(table, input.MoveDownInListAction, func(count int) { app.moveCurrentEntryInList(count) })
+	app.inputHandler.BindFunctionWithCountToAction(table, input.MoveUpInListAction, func(count int) { app.moveCurrentEntryInList(-count) })
+	return table
+}
+
+func entryWithIdIn(entries []data.Entry, entryId int) (data.Entry, bool) {
+	for _, entry := range entries {
+		if entry.Id == entryId {
+			return entry, true
+		}
+	}
+	return data.Entry{}, false
+}
+
+func (app *App) recreateListView(list data.EntriesList, state entriesViewState) {
+	table := app.createListTable(list.Items)
+	app.listsTables[list.Name] = table
+	app.entriesTypesTabs.SetTab(listTabName(list.Name), table)
+	restoreListTableState(table, state)
+}
+
+//Entry that was selected stays selected unless it has been removed, in which case the entry in its place is selected
+func restoreListTableState(table *widget.Table, state entriesViewState) {
+	entryNum := state.entryNum
+	if entryNum >= table.RowAmount() && table.RowAmount() > 0 {
+		entryNum = table.RowAmount() - 1
+	}
+	table.SelectCell(entryNum, state.columnNum)
+	if state.focused {
+		table.EnterInputMode()
+	}
+}
+
+func (app *App) listTableStateOf(table *widget.Table) entriesViewState {
+	if table == nil {
+		return entriesViewState{}
+	}
+	focused := app.mainWindow.Canvas().Focused()
+	return entriesViewState{table.CurrentRowNum(), table.CurrentColumnNum(), focused != nil && focused == table}
+}
+
+func (app *App) updateListViewAfterChange(event data.ChangeEvent) {
+	switch event.Kind {
+	case data.ListCreatedChange:
+		app.recreateListView(*event.ListAfter, entriesViewState{})
+	case data.ListDeletedChange:
+		delete(app.listsTables, event.ListBefore.Name)
+		app.entriesTypesTabs.RemoveTab(listTabName(event.ListBefore.Name))
+	case data.ListUpdatedChange:
+		app.recreateListView(*event.ListAfter, app.listTableStateOf(app.listsTables[event.ListAfter.Name]))
+	}
+}
+
+//Lists display values of their entries, so they have to be recreated when entries of the given type change
+func (app *App) refreshListViewsContaining(typeName string) {
+	for _, list := range app.entriesContainer.Lists() {
+		for _, item := range list.Items {
+			if item.TypeName == typeName {
+				app.recreateListView(list, app.listTableStateOf(app.listsTables[list.Name]))
+				break
+			}
+		}
+	}
+}
+
+func (app *App) createListDialogs() {
+	formItemFactory := widget.NewFormDialogFormItemFactory(app.mainWindow.Canvas(), app.inputHandler)
+	app.createListDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Create new list", formItemFactory.FormItemWithInputField("Name"))
+	app.createListDialog.OnEnterPressed = app.onEnterPressedInCreateListDialog
+	app.deleteListDialog = app.newConfirmationDialog()
+}
+
+func (app *App) displayDialogForCreatingList() {
+	app.createListDialog.CleanItemValues()
+	app.createListDialog.Display()
+}
+
+func (app *App) onEnterPressedInCreateListDialog() {
+	err := app.entriesContainer.CreateList(strings.TrimSpace(app.createListDialog.ItemValue("Name")))
+	if err != nil {
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.createListDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+func (app *App) tryDeletingCurrentList() {
+	if smartListName, isSmartList := app.currentSmartListName(); isSmartList {
+		app.deleteListDialog.OnConfirm = func() { app.deleteSmartList(smartListName) }
+		app.deleteListDialog.Display("Are you sure you want to delete smart list '" + smartListName + "'?")
+		return
+	}
+	listName, isList := app.currentListName()
+	if !isList {
+		app.msgDialog.Display(widget.WarningPopUp, "Select the tab of a list to delete it!")
+		return
+	}
+	app.deleteListDialog.OnConfirm = func() {
+		err := app.entriesContainer.DeleteList(listName)
+		if err != nil {
+			log.Error(err)
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		}
+	}
+	app.deleteListDialog.Display("Are you sure you want to delete list '" + listName + "'?")
+}
+
+//The list to add the current entry to is chosen from a menu of all lists
+func (app *App) displayListsToAddCurrentEntryTo() {
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to add to a list!")
+		return
+	}
+	lists := app.entriesContainer.Lists()
+	if len(lists) == 0 {
+		app.msgDialog.Display(widget.WarningPopUp, "There are no lists to add the entry to!")
+		return
+	}
+	listsNames := []string{}
+	for _, list := range lists {
+		listsNames = append(listsNames, list.Name)
+	}
+	typeName := app.getCurrentEntryType().Name
+	app.listsMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, listsNames...)
+	app.listsMenu.OnChoiceSelectedCallback = func(listName string) {
+		err := app.entriesContainer.AddToList(listName, typeName, entry.Id)
+		if err != nil {
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		}
+	}
+	app.listsMenu.Show()
+}
+
+func (app *App) removeCurrentEntryFromList() {
+	listName, isList := app.currentListName()
+	if !isList {
+		app.msgDialog.Display(widget.WarningPopUp, "Select the tab of a list to remove an entry from it!")
+		return
+	}
+	list, _ := app.entriesContainer.ListWithName(listName)
+	if len(list.Items) == 0 {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to remove from the list!")
+		return
+	}
+	err := app.entriesContainer.RemoveFromList(listName, app.listsTables[listName].CurrentRowNum())
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+//Moved entry stays selected. Entry can't be moved beyond either end of the list, so it stops there.
+func (app *App) moveCurrentEntryInList(amount int) {
+	listName, isList := app.currentListName()
+	if !isList {
+		return
+	}
+	list, _ := app.entriesContainer.ListWithName(listName)
+	position := app.listsTables[listName].CurrentRowNum()
+	newPosition := position + amount
+	if newPosition < 0 {
+		newPosition = 0
+	} else if newPosition >= len(list.Items) {
+		newPosition = len(list.Items) - 1
+	}
+	if newPosition == position || position >= len(list.Items) {
+		return
+	}
+	err := app.entriesContainer.MoveInList(listName, position, newPosition-position)
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		return
+	}
+	app.listsTables[listName].SelectCell(newPosition, app.listsTables[listName].CurrentColumnNum())
+}
@@ -0,0 +1,101 @@
+package wirwl
+
+import (
+	"sort"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Entries can be moved or copied to another entry type chosen from a menu of the other types. When some entries of the
+current entry type are marked, all of them are moved or copied, otherwise only the current entry is. Marked entries
+are displayed in bold in the table of their type and stop being marked once they have been moved or copied.
+*/
+
+func (app *App) createMovingEntriesDialogs() {
+	app.entryTypesMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, "")
+}
+
+func (app *App) toggleMarkOfCurrentEntry() {
+	if app.failIfListIsSelected() {
+		return
+	}
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to mark!")
+		return
+	}
+	entryType := app.getCurrentEntryType()
+	if app.markedEntries[entryType.Name] == nil {
+		app.markedEntries[entryType.Name] = map[int]bool{}
+	}
+	if app.isEntryMarked(entryType.Name, entry.Id) {
+		delete(app.markedEntries[entryType.Name], entry.Id)
+	} else {
+		app.markedEntries[entryType.Name][entry.Id] = true
+	}
+	app.refreshEntriesViews(entryType)
+}
+
+func (app *App) isEntryMarked(typeName string, entryId int) bool {
+	return app.markedEntries[typeName][entryId]
+}
+
+//Marked entries are returned in the order they are displayed in, so they keep it in the other entry type
+func (app *App) idsOfEntriesToMoveOrCopy() []int {
+	entryType := app.getCurrentEntryType()
+	entryIds := []int{}
+	for _, entry := range app.entriesContainer.EntriesGroupedByType()[entryType] {
+		if app.isEntryMarked(entryType.Name, entry.Id) {
+			entryIds = append(entryIds, entry.Id)
+		}
+	}
+	if entry, exists := app.currentEntry(); exists && len(entryIds) == 0 {
+		entryIds = append(entryIds, entry.Id)
+	}
+	return entryIds
+}
+
+func (app *App) displayEntryTypesToMoveEntriesTo() {
+	app.displayEntryTypesToTransferEntriesTo("move", app.entriesContainer.MoveEntries)
+}
+
+func (app *App) displayEntryTypesToCopyEntriesTo() {
+	app.displayEntryTypesToTransferEntriesTo("copy", app.entriesContainer.CopyEntries)
+}
+
+//Operation is used only to describe what cannot be done
+func (app *App) displayEntryTypesToTransferEntriesTo(operation string, transfer func(typeName string, entryIds []int, targetTypeName string) error) {
+	if app.failIfListIsSelected() {
+		return
+	}
+	entryIds := app.idsOfEntriesToMoveOrCopy()
+	if len(entryIds) == 0 {
+		app.msgDialog.Display(widget.WarningPopUp, "There are no entries to "+operation+"!")
+		return
+	}
+	entryType := app.getCurrentEntryType()
+	typesNames := []string{}
+	for otherEntryType := range app.entriesContainer.EntriesGroupedByType() {
+		if otherEntryType.Name != entryType.Name {
+			typesNames = append(typesNames, otherEntryType.Name)
+		}
+	}
+	if len(typesNames) == 0 {
+		app.msgDialog.Display(widget.WarningPopUp, "There are no other entry types to "+operation+" entries to!")
+		return
+	}
+	sort.Strings(typesNames)
+	app.entryTypesMenu.SetChoices(typesNames...)
+	app.entryTypesMenu.OnChoiceSelectedCallback = func(targetTypeName string) {
+		err := transfer(entryType.Name, entryIds, targetTypeName)
+		if err != nil {
+			log.Error(err)
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+			return
+		}
+		delete(app.markedEntries, entryType.Name)
+		app.refreshEntriesViews(entryType)
+	}
+	app.entryTypesMenu.Show()
+}
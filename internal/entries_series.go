@@ -0,0 +1,248 @@
+package wirwl
+
+import (
+	"strconv"
+	"strings"
+	"wirwl/internal/data"
+	"wirwl/internal/input"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Series group entries of any entry type that belong to the same franchise and are displayed together in a single tab,
+which exists only when there are any series. Every series takes a row of its table showing the aggregate progress of
+its members, and it can be expanded to show its members in the rows below it. Like in lists, the selected member is the
+current entry, so actions made on entries work in the tab of series. Members are removed from series along with their
+entries, so members whose entries no longer exist are only displayed for collections saved before that was the case.
+*/
+
+const seriesTabName = data.SeriesDisplayName
+
+const collapsedSeriesMarker = "[+] "
+const expandedSeriesMarker = "[-] "
+
+var seriesTableColumns = []widget.TableColumn{
+	{Type: widget.TextColumn, Name: "Series"},
+	{Type: widget.TextColumn, Name: "Type"},
+	{Type: widget.TextColumn, Name: "Status"},
+	{Type: widget.TextColumn, Name: "Title"},
+	{Type: widget.TextColumn, Name: "Elements completed"},
+	{Type: widget.TextColumn, Name: "Members completed"},
+}
+
+//Row of the table of series, which either describes a whole series or, if it has a member, one of its members
+type seriesRow struct {
+	seriesName string
+	position   int
+	member     *data.ListItem
+}
+
+func (app *App) isSeriesTabSelected() bool {
+	return app.seriesTable != nil && app.getCurrentTabText() == seriesTabName
+}
+
+//Returns the row selected in the table of series, if the tab of series is selected
+func (app *App) currentSeriesRow() (seriesRow, bool) {
+	if !app.isSeriesTabSelected() || app.seriesTable.CurrentRowNum() >= len(app.seriesRows) {
+		return seriesRow{}, false
+	}
+	return app.seriesRows[app.seriesTable.CurrentRowNum()], true
+}
+
+func (app *App) createSeriesTable() *widget.Table {
+	entriesByType := app.entriesContainer.EntriesGroupedByType()
+	app.seriesRows = []seriesRow{}
+	rowData := []widget.TableRow{}
+	for _, series := range app.entriesContainer.AllSeries() {
+		app.seriesRows = append(app.seriesRows, seriesRow{seriesName: series.Name})
+		rowData = append(rowData, app.seriesTableRow(series))
+		if !app.expandedSeries[series.Name] {
+			continue
+		}
+		for i := range series.Members {
+			member := series.Members[i]
+			app.seriesRows = append(app.seriesRows, seriesRow{seriesName: series.Name, position: i, member: &member})
+			rowData = append(rowData, app.seriesMemberTableRow(member, entriesByType))
+		}
+	}
+	table := widget.NewTable(app.mainWindow.Canvas(), app.inputHandler, seriesTableColumns, rowData)
+	table.SetOnExitCallbackFunction(table.ExitInputMode)
+	table.SetOnEditCellCallbackFunction(func(rowNum int, columnNum int) { app.toggleSeriesOrSelectMemberAt(rowNum) })
+	app.inputHandler.BindFunctionWithCountToAction(table, input.IncrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(count) })
+	app.inputHandler.BindFunctionWithCountToAction(table, input.DecrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(-count) })
+	app.inputHandler.BindFunctionToAction(table, input.UndoAction, func() { app.undoLastChange() })
+	app.inputHandler.BindFunctionToAction(table, input.RedoAction, func() { app.redoLastUndoneChange() })
+	app.inputHandler.BindFunctionToAction(table, input.ShowEntryDetailsAction, func() { app.displayDetailsOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.StartRewatchAction, func() { app.startRewatchingCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.RemoveFromSeriesAction, func() { app.removeCurrentEntryFromSeries() })
+	app.inputHandler.BindFunctionToAction(table, input.DeleteSeriesAction, func() { app.tryDeletingCurrentSeries() })
+	return table
+}
+
+func (app *App) seriesTableRow(series data.Series) widget.TableRow {
+	marker := collapsedSeriesMarker
+	if app.expandedSeries[series.Name] {
+		marker = expandedSeriesMarker
+	}
+	progress, _ := app.entriesContainer.ProgressOfSeries(series.Name)
+	elementsCompleted := strconv.Itoa(progress.ElementsCompleted)
+	if progress.TotalElements > 0 {
+		elementsCompleted += "/" + strconv.Itoa(progress.TotalElements)
+	}
+	return widget.TableRow{newSpreadsheetLabelWithText(marker + series.Name), newSpreadsheetLabelWithText(""),
+		newSpreadsheetLabelWithText(string(progress.Status)), newSpreadsheetLabelWithText(""),
+		newSpreadsheetLabelWithText(elementsCompleted),
+		newSpreadsheetLabelWithText(strconv.Itoa(progress.CompletedMembers) + "/" + strconv.Itoa(progress.Members))}
+}
+
+func (app *App) seriesMemberTableRow(member data.ListItem, entriesByType map[data.EntryType][]data.Entry) widget.TableRow {
+	entryType, _ := app.entriesContainer.EntryTypeWithName(member.TypeName)
+	row := widget.TableRow{newSpreadsheetLabelWithText(""), newSpreadsheetLabelWithText(member.TypeName)}
+	entry, exists := entryWithIdIn(entriesByType[entryType], member.EntryId)
+	if exists {
+		row = append(row, newSpreadsheetLabelWithText(string(entry.Status)), newSpreadsheetLabelWithText(entry.Title),
+			newSpreadsheetLabelWithText(strconv.Itoa(entry.ElementsCompleted)))
+	} else {
+		row = append(row, newSpreadsheetLabelWithText(""), newSpreadsheetLabelWithText(missingListEntryTitle), newSpreadsheetLabelWithText(""))
+	}
+	return append(row, newSpreadsheetLabelWithText(""))
+}
+
+//Row of a series expands or collapses it, while row of a member switches to the tab of its entry and selects it there
+func (app *App) toggleSeriesOrSelectMemberAt(rowNum int) {
+	if rowNum >= len(app.seriesRows) {
+		return
+	}
+	row := app.seriesRows[rowNum]
+	if row.member != nil {
+		app.selectEntry(row.member.TypeName, row.member.EntryId)
+		return
+	}
+	app.expandedSeries[row.seriesName] = !app.expandedSeries[row.seriesName]
+	app.refreshSeriesView()
+}
+
+//Tab of series is removed when the last series gets deleted and added back when a series gets created
+func (app *App) recreateSeriesView(state entriesViewState) {
+	if len(app.entriesContainer.AllSeries()) == 0 {
+		if app.seriesTable != nil {
+			app.seriesTable, app.seriesRows = nil, nil
+			app.entriesTypesTabs.RemoveTab(seriesTabName)
+		}
+		return
+	}
+	app.seriesTable = app.createSeriesTable()
+	app.entriesTypesTabs.SetTab(seriesTabName, app.seriesTable)
+	restoreListTableState(app.seriesTable, state)
+}
+
+//Series display values of their members, so the view has to be recreated whenever entries change
+func (app *App) refreshSeriesView() {
+	app.recreateSeriesView(app.listTableStateOf(app.seriesTable))
+}
+
+func (app *App) updateSeriesViewAfterChange(event data.ChangeEvent) {
+	if event.Kind == data.SeriesDeletedChange {
+		delete(app.expandedSeries, event.SeriesBefore.Name)
+	}
+	app.refreshSeriesView()
+}
+
+func (app *App) createSeriesDialogs() {
+	formItemFactory := widget.NewFormDialogFormItemFactory(app.mainWindow.Canvas(), app.inputHandler)
+	app.createSeriesDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Create new series", formItemFactory.FormItemWithInputField("Name"))
+	app.createSeriesDialog.OnEnterPressed = app.onEnterPressedInCreateSeriesDialog
+	app.deleteSeriesDialog = app.newConfirmationDialog()
+}
+
+func (app *App) displayDialogForCreatingSeries() {
+	app.createSeriesDialog.CleanItemValues()
+	app.createSeriesDialog.Display()
+}
+
+func (app *App) onEnterPressedInCreateSeriesDialog() {
+	err := app.entriesContainer.CreateSeries(strings.TrimSpace(app.createSeriesDialog.ItemValue("Name")))
+	if err != nil {
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.createSeriesDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+//Series containing the selected row gets deleted, no matter whether the row is of the series or of one of its members
+func (app *App) tryDeletingCurrentSeries() {
+	row, isSelected := app.currentSeriesRow()
+	if !isSelected {
+		app.msgDialog.Display(widget.WarningPopUp, "Select a series in the tab of series to delete it!")
+		return
+	}
+	app.deleteSeriesDialog.OnConfirm = func() {
+		err := app.entriesContainer.DeleteSeries(row.seriesName)
+		if err != nil {
+			log.Error(err)
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		}
+	}
+	app.deleteSeriesDialog.Display("Are you sure you want to delete series '" + row.seriesName + "'?")
+}
+
+//The series to add the current entry to is chosen from a menu of all series
+func (app *App) displaySeriesToAddCurrentEntryTo() {
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to add to a series!")
+		return
+	}
+	allSeries := app.entriesContainer.AllSeries()
+	if len(allSeries) == 0 {
+		app.msgDialog.Display(widget.WarningPopUp, "There are no series to add the entry to!")
+		return
+	}
+	seriesNames := []string{}
+	for _, series := range allSeries {
+		seriesNames = append(seriesNames, series.Name)
+	}
+	typeName := app.getCurrentEntryType().Name
+	app.seriesMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, seriesNames...)
+	app.seriesMenu.OnChoiceSelectedCallback = func(seriesName string) {
+		err := app.entriesContainer.AddToSeries(seriesName, typeName, entry.Id)
+		if err != nil {
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		}
+	}
+	app.seriesMenu.Show()
+}
+
+//In the tab of series the selected member gets removed, even if its entry no longer exists,
+//while in other tabs the current entry gets removed from the series it's a member of
+func (app *App) removeCurrentEntryFromSeries() {
+	seriesName, position, isMember := app.currentSeriesMember()
+	if !isMember {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no member of a series to remove from it!")
+		return
+	}
+	err := app.entriesContainer.RemoveFromSeries(seriesName, position)
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+func (app *App) currentSeriesMember() (string, int, bool) {
+	if app.isSeriesTabSelected() {
+		row, exists := app.currentSeriesRow()
+		return row.seriesName, row.position, exists && row.member != nil
+	}
+	entry, exists := app.currentEntry()
+	if !exists {
+		return "", 0, false
+	}
+	series, isMember := app.entriesContainer.SeriesOf(app.getCurrentEntryType().Name, entry.Id)
+	if !isMember {
+		return "", 0, false
+	}
+	position, _ := series.PositionOf(app.getCurrentEntryType().Name, entry.Id)
+	return series.Name, position, true
+}
@@ -4,39 +4,203 @@ import (
 	fyneWidget "fyne.io/fyne/widget"
 	"strconv"
 	"wirwl/internal/data"
+	"wirwl/internal/input"
 	widget "wirwl/internal/widget"
 )
 
-//Should be equal to amount of fields Entry type has, minus the id field
-const columnAmount = 14
+//Describes a column that can be displayed in an entries table, what text a cell in that column has for an entry
+//and, if the column is editable, what editor should be used to edit it and how edited value is set in the entry.
+//Both depend on the entry type of the entry, e.g. its statuses or scoring system.
+type entriesTableColumn struct {
+	name       string
+	columnType widget.ColumnType
+	cellText   func(entryType data.EntryType, rowNum int, entry data.Entry) string
+	editorType cellEditorType
+	setValue   func(entryType data.EntryType, entry *data.Entry, value string) error
+}
+
+//All columns that can be displayed in an entries table in the order they are displayed by default
+var entriesTableColumns = []entriesTableColumn{
+	{
+		name:       "Num",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return strconv.Itoa(rowNum) },
+	},
+	{
+		name:       "Image",
+		columnType: widget.ImageColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return "" },
+	},
+	{
+		name:       "Status",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return string(entry.Status) },
+		editorType: statusCellEditor,
+		setValue:   setStatus,
+	},
+	{
+		name:       "Title",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.Title },
+		editorType: textCellEditor,
+		setValue:   setTitle,
+	},
+	{
+		name:       "Elements completed",
+		columnType: widget.TextColumn,
+		cellText: func(entryType data.EntryType, rowNum int, entry data.Entry) string {
+			return strconv.Itoa(entry.ElementsCompleted)
+		},
+		editorType: numberCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setNumber(&entry.ElementsCompleted, "Elements completed", value)
+		},
+	},
+	{
+		name:       "Total amount",
+		columnType: widget.TextColumn,
+		cellText: func(entryType data.EntryType, rowNum int, entry data.Entry) string {
+			return strconv.Itoa(entry.TotalAmountOfElementsToComplete)
+		},
+		editorType: numberCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setNumber(&entry.TotalAmountOfElementsToComplete, "Total amount", value)
+		},
+	},
+	{
+		name:       "Score",
+		columnType: widget.TextColumn,
+		cellText: func(entryType data.EntryType, rowNum int, entry data.Entry) string {
+			return entryType.Scoring().FormatScore(entry.Score)
+		},
+		editorType: textCellEditor,
+		setValue:   setScore,
+	},
+	{
+		name:       "Start date",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.StartDate },
+		editorType: dateCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setDate(&entry.StartDate, "Start date", value)
+		},
+	},
+	{
+		name:       "Finish date",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.FinishDate },
+		editorType: dateCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setDate(&entry.FinishDate, "Finish date", value)
+		},
+	},
+	{
+		name:       "Link",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.Link },
+		editorType: textCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setText(&entry.Link, value)
+		},
+	},
+	{
+		name:       "Description",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.Description },
+		editorType: textCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setText(&entry.Description, value)
+		},
+	},
+	{
+		name:       "Comment",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.Comment },
+		editorType: textCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setText(&entry.Comment, value)
+		},
+	},
+	{
+		name:       "Tags",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.Tags },
+		editorType: textCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setText(&entry.Tags, value)
+		},
+	},
+	{
+		name:       "Image query",
+		columnType: widget.TextColumn,
+		cellText:   func(entryType data.EntryType, rowNum int, entry data.Entry) string { return entry.ImageQuery },
+		editorType: textCellEditor,
+		setValue: func(entryType data.EntryType, entry *data.Entry, value string) error {
+			return setText(&entry.ImageQuery, value)
+		},
+	},
+}
+
+//Columns that are only used internally by the application and therefore are not displayed unless a user chooses to
+var columnsHiddenByDefault = map[string]bool{
+	"Image query": true,
+}
+
+func defaultColumnsLayout() []ColumnSettings {
+	layout := []ColumnSettings{}
+	for _, column := range entriesTableColumns {
+		if !columnsHiddenByDefault[column.name] {
+			layout = append(layout, ColumnSettings{Name: column.name, Width: 0})
+		}
+	}
+	return layout
+}
+
+func entriesTableColumnWithName(name string) (entriesTableColumn, bool) {
+	for _, column := range entriesTableColumns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return entriesTableColumn{}, false
+}
 
 func (app *App) createEntriesTable(entryType data.EntryType, entries []data.Entry) {
+	columns, columnData := createColumnData(app.config.ColumnsLayoutFor(entryType.Name))
 	rowData := []widget.TableRow{}
-	columnData := createColumnData()
 	for i, entry := range entries {
-		row := createEntriesTableRow(i, entry)
+		row := app.createEntriesTableRow(entryType, columns, i, entry)
 		rowData = append(rowData, row)
 	}
 	table := widget.NewTable(app.mainWindow.Canvas(), app.inputHandler, columnData, rowData)
+	table.SetOnExitCallbackFunction(table.ExitInputMode)
+	table.SetOnEditCellCallbackFunction(func(rowNum int, columnNum int) {
+		app.editEntriesTableCell(table, entryType, rowNum, entries[rowNum], columns[columnNum])
+	})
+	app.inputHandler.BindFunctionWithCountToAction(table, input.IncrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(count) })
+	app.inputHandler.BindFunctionWithCountToAction(table, input.DecrementProgressAction, func(count int) { app.changeProgressOfCurrentEntry(-count) })
+	app.inputHandler.BindFunctionToAction(table, input.ToggleDisplayModeAction, func() { app.toggleCoverDisplayModeOfCurrentEntryType() })
+	app.inputHandler.BindFunctionToAction(table, input.UndoAction, func() { app.undoLastChange() })
+	app.inputHandler.BindFunctionToAction(table, input.RedoAction, func() { app.redoLastUndoneChange() })
+	app.inputHandler.BindFunctionToAction(table, input.ShowEntryDetailsAction, func() { app.displayDetailsOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.StartRewatchAction, func() { app.startRewatchingCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.ToggleEntryMarkAction, func() { app.toggleMarkOfCurrentEntry() })
+	app.inputHandler.BindFunctionToAction(table, input.MoveEntriesAction, func() { app.displayEntryTypesToMoveEntriesTo() })
+	app.inputHandler.BindFunctionToAction(table, input.CopyEntriesAction, func() { app.displayEntryTypesToCopyEntriesTo() })
 	app.entriesTables[entryType] = table
 }
 
-func createEntriesTableRow(rowNum int, entry data.Entry) widget.TableRow {
+func (app *App) createEntriesTableRow(entryType data.EntryType, columns []entriesTableColumn, rowNum int, entry data.Entry) widget.TableRow {
 	row := widget.TableRow{}
-	row = append(row, newSpreadsheetLabelWithNumber(rowNum))
-	row = append(row, newSpreadsheetLabelWithText("This will be an image"))
-	row = append(row, newSpreadsheetLabelWithText(string(entry.Status)))
-	row = append(row, newSpreadsheetLabelWithText(entry.Title))
-	row = append(row, newSpreadsheetLabelWithNumber(entry.ElementsCompleted))
-	row = append(row, newSpreadsheetLabelWithNumber(entry.TotalAmountOfElementsToComplete))
-	row = append(row, newSpreadsheetLabelWithNumber(entry.Score))
-	row = append(row, newSpreadsheetLabelWithText(entry.StartDate))
-	row = append(row, newSpreadsheetLabelWithText(entry.FinishDate))
-	row = append(row, newSpreadsheetLabelWithText(entry.Link))
-	row = append(row, newSpreadsheetLabelWithText(entry.Description))
-	row = append(row, newSpreadsheetLabelWithText(entry.Comment))
-	row = append(row, newSpreadsheetLabelWithText(entry.Tags))
-	row = append(row, newSpreadsheetLabelWithText(entry.ImageQuery))
+	for _, column := range columns {
+		if column.columnType == widget.ImageColumn {
+			row = append(row, app.coverImageFor(entryType, entry))
+		} else {
+			label := newSpreadsheetLabelWithText(column.cellText(entryType, rowNum, entry))
+			label.TextStyle.Bold = app.isEntryMarked(entryType.Name, entry.Id)
+			row = append(row, label)
+		}
+	}
 	return row
 }
 
@@ -45,31 +209,16 @@ func newSpreadsheetLabelWithText(text string) *fyneWidget.Label {
 	return label
 }
 
-func newSpreadsheetLabelWithNumber(number int) *fyneWidget.Label {
-	return newSpreadsheetLabelWithText(strconv.Itoa(number))
-}
-
-func createColumnData() []widget.TableColumn {
-	columnsNames := []string{
-		"Num",
-		"Image",
-		"Status",
-		"Title",
-		"Elements completed",
-		"Total amount",
-		"Score",
-		"Start date",
-		"Finish date",
-		"Link",
-		"Description",
-		"Comment",
-		"Tags",
-		"Image query",
-	}
+//Columns with names that don't match any existing column are skipped as they can only come from a manually edited config
+func createColumnData(layout []ColumnSettings) ([]entriesTableColumn, []widget.TableColumn) {
+	columns := []entriesTableColumn{}
 	columnData := []widget.TableColumn{}
-	for _, columnName := range columnsNames {
-		column := widget.TableColumn{Type: widget.TextColumn, Name: columnName}
-		columnData = append(columnData, column)
+	for _, columnSettings := range layout {
+		column, columnExists := entriesTableColumnWithName(columnSettings.Name)
+		if columnExists {
+			columns = append(columns, column)
+			columnData = append(columnData, widget.TableColumn{Type: column.columnType, Name: column.name, Width: columnSettings.Width})
+		}
 	}
-	return columnData
+	return columns, columnData
 }
@@ -0,0 +1,138 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Cells of entries tables are edited in place using one of the editors below, depending on the column.
+Editors are created once and shared by all of the tables as only one cell can be edited at a time, so the statuses
+offered by the status editor are replaced with the ones of the edited entry's type every time it's used.
+Confirming the edition validates the value and, if it's correct, updates the entry in the entries container.
+If it's not correct, the error is displayed below the edited cell and editing continues.
+Cancelling the edition discards the typed in value.
+*/
+
+type cellEditorType string
+
+const (
+	textCellEditor   cellEditorType = "TEXT"
+	numberCellEditor cellEditorType = "NUMBER"
+	dateCellEditor   cellEditorType = "DATE"
+	statusCellEditor cellEditorType = "STATUS"
+)
+
+func (app *App) prepareCellEditors() {
+	canvas := app.mainWindow.Canvas()
+	app.cellEditors = map[cellEditorType]widget.FormDialogEmbeddableWidget{
+		textCellEditor:   widget.NewInputField(canvas, app.inputHandler),
+		numberCellEditor: widget.NewNumericInputField(canvas, app.inputHandler),
+		dateCellEditor:   widget.NewDateInputField(canvas, app.inputHandler),
+		statusCellEditor: widget.NewSelect(canvas, app.inputHandler, entryStatusesAsStrings(data.EntryType{})...),
+	}
+}
+
+func entryStatusesAsStrings(entryType data.EntryType) []string {
+	statuses := []string{}
+	for _, status := range entryType.StatusNames() {
+		statuses = append(statuses, string(status))
+	}
+	return statuses
+}
+
+func (app *App) editEntriesTableCell(table *widget.Table, entryType data.EntryType, rowNum int, entry data.Entry, column entriesTableColumn) {
+	if column.setValue == nil {
+		return
+	}
+	editor := app.cellEditors[column.editorType]
+	originalValue := column.cellText(entryType, rowNum, entry)
+	editor.SetText(originalValue)
+	confirm := func() {
+		app.confirmCellEdition(table, entryType, entry, column, originalValue)
+	}
+	if column.editorType == statusCellEditor {
+		editor.(*widget.Select).SetChoices(entryStatusesAsStrings(entryType)...)
+		//Select exits input mode as soon as a choice is made so there is nothing else to confirm
+		editor.SetOnExitInputModeFunction(confirm)
+	} else {
+		editor.SetOnConfirm(confirm)
+		editor.SetOnExitInputModeFunction(table.StopEditing)
+	}
+	table.StartEditingCurrentCell(editor)
+}
+
+func (app *App) confirmCellEdition(table *widget.Table, entryType data.EntryType, entry data.Entry, column entriesTableColumn, originalValue string) {
+	value := app.cellEditors[column.editorType].GetText()
+	if value == originalValue {
+		table.StopEditing()
+		return
+	}
+	err := column.setValue(entryType, &entry, value)
+	if err != nil {
+		table.SetEditingError(err.Error())
+		return
+	}
+	err = app.entriesContainer.UpdateEntry(entryType.Name, entry)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred when updating an edited entry. This is most likely a programming error")
+		log.Error(err)
+		table.SetEditingError(err.Error())
+		return
+	}
+	//Focus was on the cell editor so it doesn't get passed to the table recreated after the update
+	app.getCurrentEntryTypeTable().EnterInputMode()
+}
+
+func setStatus(entryType data.EntryType, entry *data.Entry, value string) error {
+	if !entryType.HasStatus(data.EntryStatus(value)) {
+		return errors.New("'" + value + "' is not a status of entry type '" + entryType.Name + "'")
+	}
+	entry.Status = data.EntryStatus(value)
+	return nil
+}
+
+//Score is typed in as it's displayed in the scoring system of the entry's type, e.g. as stars, see data.ScoringSystem.ParseScore
+func setScore(entryType data.EntryType, entry *data.Entry, value string) error {
+	score, err := entryType.Scoring().ParseScore(value)
+	if err != nil {
+		return err
+	}
+	entry.Score = score
+	return nil
+}
+
+func setTitle(entryType data.EntryType, entry *data.Entry, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("Title cannot be empty")
+	}
+	entry.Title = value
+	return nil
+}
+
+func setNumber(field *int, fieldName string, value string) error {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return errors.New(fieldName + " has to be a number equal to or greater than 0")
+	}
+	*field = number
+	return nil
+}
+
+//Dates are optional so an empty value is accepted as well
+func setDate(field *string, fieldName string, value string) error {
+	if value != "" && !data.IsValidDate(value) {
+		return errors.New(fieldName + " has to be a date in format DD/MM/YYYY")
+	}
+	*field = value
+	return nil
+}
+
+func setText(field *string, value string) error {
+	*field = value
+	return nil
+}
@@ -0,0 +1,129 @@
+package wirwl
+
+import (
+	"fyne.io/fyne"
+	"wirwl/internal/data"
+)
+
+/*
+Entries of every entry type are displayed in its tab either in a table or in a cover grid. After a change of entries
+only the views of the changed entry type are recreated, so the selected tab, entry and focus stay the same, even when
+the selected entry type gets renamed. Columns layout, cover images and display mode follow a renamed entry type,
+no matter whether it has been renamed by editing it or by undoing or redoing a change.
+*/
+
+//Selection and focus of an entry type's views that are kept when the views get recreated
+type entriesViewState struct {
+	entryNum  int
+	columnNum int
+	focused   bool
+}
+
+//Tables are created even for entry types in cover display mode so the table is always available e.g. for changing its layout
+func (app *App) createEntriesViews(entryType data.EntryType, entries []data.Entry) []fyne.CanvasObject {
+	app.createEntriesTable(entryType, entries)
+	if app.isInCoverDisplayMode(entryType) {
+		app.createEntriesCoverGrid(entryType, entries)
+		return []fyne.CanvasObject{app.entriesCoverGrids[entryType]}
+	}
+	delete(app.entriesCoverGrids, entryType)
+	return []fyne.CanvasObject{app.entriesTables[entryType]}
+}
+
+//Lists and series are updated after entry types as they display entries of the types
+func (app *App) updateGUIAfterChange(event data.ChangeEvent) {
+	switch event.Kind {
+	case data.ListCreatedChange, data.ListUpdatedChange, data.ListDeletedChange:
+		app.updateListViewAfterChange(event)
+		return
+	case data.RelationAddedChange, data.RelationRemovedChange:
+		app.refreshSmartListViews()
+		return
+	case data.SeriesCreatedChange, data.SeriesUpdatedChange, data.SeriesDeletedChange:
+		app.updateSeriesViewAfterChange(event)
+		return
+	case data.EntryTypeAddedChange:
+		app.recreateEntriesViews(*event.TypeAfter, entriesViewState{})
+	case data.EntryTypeDeletedChange:
+		app.removeEntriesViews(*event.TypeBefore)
+		app.entriesTypesTabs.RemoveTab(event.TypeBefore.Name)
+	case data.EntryTypeUpdatedChange, data.EntryTypeRenamedChange:
+		state := app.entriesViewStateOf(*event.TypeBefore)
+		app.removeEntriesViews(*event.TypeBefore)
+		if event.Kind == data.EntryTypeRenamedChange {
+			app.moveEntryTypeSettings(event.TypeBefore.Name, event.TypeAfter.Name)
+		}
+		app.recreateEntriesViews(*event.TypeAfter, state)
+	case data.EntryMovedChange:
+		app.refreshEntriesViews(*event.TypeBefore)
+		app.refreshEntriesViews(*event.TypeAfter)
+	default:
+		app.refreshEntriesViews(*event.TypeAfter)
+	}
+	if event.TypeBefore != nil && event.Kind != data.EntryTypeRenamedChange {
+		app.refreshListViewsContaining(event.TypeBefore.Name)
+	}
+	if event.TypeAfter != nil && (event.TypeBefore == nil || event.TypeAfter.Name != event.TypeBefore.Name) {
+		app.refreshListViewsContaining(event.TypeAfter.Name)
+	}
+	app.refreshSmartListViews()
+	app.refreshSeriesView()
+}
+
+func (app *App) moveEntryTypeSettings(oldName string, newName string) {
+	app.config.renameColumnsLayout(oldName, newName)
+	app.config.renameEntryTypeInSmartLists(oldName, newName)
+	if marked, exists := app.markedEntries[oldName]; exists {
+		delete(app.markedEntries, oldName)
+		app.markedEntries[newName] = marked
+	}
+	if app.typesInCoverDisplayMode[oldName] {
+		delete(app.typesInCoverDisplayMode, oldName)
+		app.typesInCoverDisplayMode[newName] = true
+	}
+	app.entriesTypesTabs.RenameTab(oldName, newName)
+}
+
+//Recreates views of the entry type keeping their state, e.g. after its cover images have changed
+func (app *App) refreshEntriesViews(entryType data.EntryType) {
+	app.recreateEntriesViews(entryType, app.entriesViewStateOf(entryType))
+}
+
+func (app *App) recreateEntriesViews(entryType data.EntryType, state entriesViewState) {
+	entries := app.entriesContainer.EntriesGroupedByType()[entryType]
+	app.entriesTypesTabs.SetTab(entryType.Name, app.createEntriesViews(entryType, entries)...)
+	table := app.entriesTables[entryType]
+	table.SelectCell(state.entryNum, state.columnNum)
+	grid, gridExists := app.entriesCoverGrids[entryType]
+	if gridExists {
+		grid.SelectItem(state.entryNum)
+	}
+	if !state.focused {
+		return
+	}
+	if gridExists {
+		grid.EnterInputMode()
+	} else {
+		table.EnterInputMode()
+	}
+}
+
+//Selected entry is taken from the cover grid if the entry type is displayed in it, as the table is not used then
+func (app *App) entriesViewStateOf(entryType data.EntryType) entriesViewState {
+	state := entriesViewState{}
+	focused := app.mainWindow.Canvas().Focused()
+	if table, tableExists := app.entriesTables[entryType]; tableExists {
+		state.entryNum, state.columnNum = table.CurrentRowNum(), table.CurrentColumnNum()
+		state.focused = focused != nil && focused == fyne.Focusable(table)
+	}
+	if grid, gridExists := app.entriesCoverGrids[entryType]; gridExists {
+		state.entryNum = grid.CurrentItemNum()
+		state.focused = focused != nil && focused == fyne.Focusable(grid)
+	}
+	return state
+}
+
+func (app *App) removeEntriesViews(entryType data.EntryType) {
+	delete(app.entriesTables, entryType)
+	delete(app.entriesCoverGrids, entryType)
+}
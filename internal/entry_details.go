@@ -0,0 +1,60 @@
+package wirwl
+
+import (
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Details of the entry selected in the current entry type's table or cover grid can be displayed in a dialog, which
+contains values of all text columns of the entries table, even those hidden by the layout, and the consumption history
+of the entry, with the most recent events first, followed by its related entries. A completed entry can be rewatched, which resets its progress and
+gets recorded in its history like any change of its progress.
+*/
+
+func (app *App) displayDetailsOfCurrentEntry() {
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to display details of!")
+		return
+	}
+	details := []widget.Detail{}
+	for _, column := range entriesTableColumns {
+		if column.columnType == widget.TextColumn {
+			details = append(details, widget.Detail{Name: column.name, Value: column.cellText(app.getCurrentEntryType(), app.currentEntryNum(), entry)})
+		}
+	}
+	details = append(details, widget.Detail{Value: "Consumption history"})
+	if len(entry.ConsumptionHistory) == 0 {
+		details = append(details, widget.Detail{Value: "Nothing has been recorded yet"})
+	}
+	for i := len(entry.ConsumptionHistory) - 1; i >= 0; i-- {
+		event := entry.ConsumptionHistory[i]
+		details = append(details, widget.Detail{Name: event.Date.Local().Format(data.EventDateLayout), Value: event.Description()})
+	}
+	details = append(details, app.relationsDetails(entry)...)
+	app.entryDetailsDialog.Display(entry.Title, details...)
+}
+
+func (app *App) startRewatchingCurrentEntry() {
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to rewatch!")
+		return
+	}
+	err := app.entriesContainer.StartRewatch(app.getCurrentEntryType().Name, entry.Id)
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+func (app *App) currentEntry() (data.Entry, bool) {
+	entries := app.entriesContainer.EntriesGroupedByType()[app.getCurrentEntryType()]
+	entryNum := app.currentEntryNum()
+	if entryNum >= len(entries) {
+		return data.Entry{}, false
+	}
+	return entries[entryNum], true
+}
@@ -0,0 +1,85 @@
+package wirwl
+
+import (
+	"strconv"
+	"strings"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Entries that are likely to be duplicates, e.g. the same entry imported twice, are listed in a menu as pairs. Chosen pair
+is compared field by field and then one of its entries can be chosen to be kept, with the other one merged into it,
+or both entries can be kept by cancelling the choice.
+*/
+
+func (app *App) createDuplicatesDialogs() {
+	app.duplicatesMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, "")
+	app.duplicatesComparisonDialog = widget.NewComparisonDialog(app.mainWindow.Canvas())
+	app.mergeMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, "")
+}
+
+func describeEntryOfType(typeName string, entry data.Entry) string {
+	return entry.Title + " (" + typeName + ")"
+}
+
+func (app *App) displayDuplicateEntries() {
+	pairs := app.entriesContainer.DuplicateCandidates()
+	if len(pairs) == 0 {
+		app.msgDialog.Display(widget.InfoPopUp, "There are no duplicate entries!")
+		return
+	}
+	choices := []string{}
+	for _, pair := range pairs {
+		reasons := []string{}
+		for _, reason := range pair.Reasons {
+			reasons = append(reasons, string(reason))
+		}
+		choices = append(choices, describeEntryOfType(pair.TypeName, pair.Entry)+" and "+
+			describeEntryOfType(pair.OtherTypeName, pair.OtherEntry)+": "+strings.Join(reasons, ", "))
+	}
+	app.duplicatesMenu.SetChoices(choices...)
+	app.duplicatesMenu.OnChoiceSelectedCallback = func(string) {
+		app.compareDuplicates(pairs[app.duplicatesMenu.CurrentChoiceNum()])
+	}
+	app.duplicatesMenu.Show()
+}
+
+//Entry to keep is chosen after the comparison gets hidden
+func (app *App) compareDuplicates(pair data.DuplicatePair) {
+	comparedValues := []widget.ComparedValues{{Name: "Type", Value: pair.TypeName, OtherValue: pair.OtherTypeName}}
+	entryType, _ := app.entriesContainer.EntryTypeWithName(pair.TypeName)
+	otherEntryType, _ := app.entriesContainer.EntryTypeWithName(pair.OtherTypeName)
+	for _, column := range entriesTableColumns {
+		if column.columnType == widget.TextColumn && column.name != "Num" {
+			comparedValues = append(comparedValues, widget.ComparedValues{Name: column.name,
+				Value:      column.cellText(entryType, 0, pair.Entry),
+				OtherValue: column.cellText(otherEntryType, 0, pair.OtherEntry)})
+		}
+	}
+	comparedValues = append(comparedValues, widget.ComparedValues{Name: "Recorded events",
+		Value:      strconv.Itoa(len(pair.Entry.ConsumptionHistory)),
+		OtherValue: strconv.Itoa(len(pair.OtherEntry.ConsumptionHistory))})
+	app.duplicatesComparisonDialog.SetOneTimeOnHideCallback(func() { app.displayEntriesToKeepOf(pair) })
+	app.duplicatesComparisonDialog.Display("Duplicates", describeEntryOfType(pair.TypeName, pair.Entry),
+		describeEntryOfType(pair.OtherTypeName, pair.OtherEntry), comparedValues...)
+}
+
+func (app *App) displayEntriesToKeepOf(pair data.DuplicatePair) {
+	app.mergeMenu.SetChoices("Keep "+describeEntryOfType(pair.TypeName, pair.Entry),
+		"Keep "+describeEntryOfType(pair.OtherTypeName, pair.OtherEntry))
+	app.mergeMenu.OnChoiceSelectedCallback = func(string) {
+		var err error
+		if app.mergeMenu.CurrentChoiceNum() == 0 {
+			err = app.entriesContainer.MergeEntries(pair.TypeName, pair.Entry.Id, pair.OtherTypeName, pair.OtherEntry.Id)
+		} else {
+			err = app.entriesContainer.MergeEntries(pair.OtherTypeName, pair.OtherEntry.Id, pair.TypeName, pair.Entry.Id)
+		}
+		if err != nil {
+			log.Error(err)
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		}
+	}
+	app.mergeMenu.Show()
+}
@@ -0,0 +1,115 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+	"wirwl/internal/covers"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/metadata"
+	"wirwl/internal/widget"
+)
+
+/*
+Entries can be enriched with metadata found by a provider configured separately for every entry type, as different
+kinds of media are usually described by different catalogs. Candidates found for the title of the entry selected in
+the current entry type's table or cover grid are displayed in a menu and, once one of them gets chosen, changes it
+would make to the entry are displayed so they can be confirmed before being applied.
+*/
+
+func (app *App) trySearchingForMetadataOfCurrentEntry() {
+	entryType := app.getCurrentEntryType()
+	if entryType.MetadataURL == "" {
+		app.msgDialog.Display(widget.WarningPopUp, "Metadata URL is not set for entry type '"+entryType.Name+"'!")
+		return
+	}
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to search for metadata of!")
+		return
+	}
+	app.msgDialog.Display(widget.InfoPopUp, "Searching for metadata...")
+	app.runInBackground(func() {
+		candidates, err := app.metadataProviderFor(entryType.MetadataURL).Search(entry.Title)
+		app.runInForeground(func() {
+			app.msgDialog.Hide()
+			if err != nil {
+				log.Error(err)
+				app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+				return
+			}
+			if len(candidates) == 0 {
+				app.msgDialog.Display(widget.InfoPopUp, "No metadata has been found for '"+entry.Title+"'")
+				return
+			}
+			app.displayMetadataCandidates(entryType, entry, candidates)
+		})
+	})
+}
+
+func (app *App) displayMetadataCandidates(entryType data.EntryType, entry data.Entry, candidates []metadata.Candidate) {
+	titles := []string{}
+	for _, candidate := range candidates {
+		titles = append(titles, candidate.Title)
+	}
+	app.metadataCandidatesMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, titles...)
+	//Many works in a catalog can share a title so the choice has to be identified by its number
+	app.metadataCandidatesMenu.OnChoiceSelectedCallback = func(string) {
+		app.displayChangesProposedByCandidate(entryType, entry, candidates[app.metadataCandidatesMenu.CurrentChoiceNum()])
+	}
+	app.metadataCandidatesMenu.Show()
+}
+
+func (app *App) displayChangesProposedByCandidate(entryType data.EntryType, entry data.Entry, candidate metadata.Candidate) {
+	enrichedEntry := candidate.AppliedTo(entry)
+	changes := describeChangesOfEntry(entryType, entry, enrichedEntry)
+	downloadCover := candidate.CoverURL != "" && !app.imageStore.HasImage(entry.Cover)
+	if downloadCover {
+		changes = append(changes, "Cover: will be downloaded from "+candidate.CoverURL)
+	}
+	if len(changes) == 0 {
+		app.msgDialog.Display(widget.InfoPopUp, "Entry '"+entry.Title+"' already has all of the metadata of '"+candidate.Title+"'")
+		return
+	}
+	app.enrichConfirmationDialog.OnConfirm = func() {
+		err := app.applyMetadataToEntry(entryType.Name, entry.Id, candidate)
+		if err != nil {
+			log.Error(err)
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+			return
+		}
+		if downloadCover {
+			app.downloadCover(entryType, entry, covers.Candidate{Title: candidate.Title, ImageURL: candidate.CoverURL})
+		}
+	}
+	app.enrichConfirmationDialog.Display("Following changes will be made to '" + entry.Title + "':\n" + strings.Join(changes, "\n") + "\nApply them")
+}
+
+//Changes are described using the names and values of entries table columns so they look the same as in the table
+func describeChangesOfEntry(entryType data.EntryType, entry data.Entry, changedEntry data.Entry) []string {
+	changes := []string{}
+	for _, column := range entriesTableColumns {
+		if column.columnType != widget.TextColumn {
+			continue
+		}
+		oldValue, newValue := column.cellText(entryType, 0, entry), column.cellText(entryType, 0, changedEntry)
+		if oldValue != newValue {
+			changes = append(changes, column.name+": '"+oldValue+"' -> '"+newValue+"'")
+		}
+	}
+	return changes
+}
+
+//Entry could have been changed since the proposed changes were displayed, so only the metadata is applied to its
+//current version instead of replacing it with the version the changes were proposed for
+func (app *App) applyMetadataToEntry(typeName string, entryId int, candidate metadata.Candidate) error {
+	entryType, err := app.entriesContainer.EntryTypeWithName(typeName)
+	if err != nil {
+		return errors.New("Cannot apply metadata of '" + candidate.Title + "' as entry type '" + typeName + "' no longer exists")
+	}
+	entry, exists := entryWithIdIn(app.entriesContainer.EntriesGroupedByType()[entryType], entryId)
+	if !exists {
+		return errors.New("Cannot apply metadata of '" + candidate.Title + "' as the entry no longer exists")
+	}
+	return app.entriesContainer.UpdateEntry(typeName, candidate.AppliedTo(entry))
+}
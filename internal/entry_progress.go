@@ -0,0 +1,24 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+//Changes progress of the entry selected in the current entry type's table or cover grid.
+//The table or grid doesn't have to be focused, in which case the entry that was last selected in it is changed.
+func (app *App) changeProgressOfCurrentEntry(amount int) {
+	entryType := app.getCurrentEntryType()
+	entries := app.entriesContainer.EntriesGroupedByType()[entryType]
+	entryNum := app.currentEntryNum()
+	if entryNum >= len(entries) {
+		return
+	}
+	err := app.entriesContainer.ChangeEntryProgress(entryType.Name, entries[entryNum].Id, amount)
+	if err != nil {
+		err = errors.Wrap(err, "An error occurred when changing progress of an entry. This is most likely a programming error")
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
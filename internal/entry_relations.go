@@ -0,0 +1,179 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Relations of the current entry, e.g. its sequels or adaptations, are displayed in its details and in a menu, from which
+the related entry can be selected, which switches to its tab, or the relation can be removed. Related entry is given
+by its title, followed by its entry type in parentheses when there are entries with the same title in different types,
+e.g. 'Dune (books)'.
+*/
+
+func (app *App) createAddRelationDialog() {
+	formItemFactory := widget.NewFormDialogFormItemFactory(app.mainWindow.Canvas(), app.inputHandler)
+	app.addRelationDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Add relation",
+		formItemFactory.FormItemWithSelect("Kind", relationKindsAsStrings()...),
+		formItemFactory.FormItemWithInputField("Related entry"))
+	app.relationsMenu = widget.NewPopUpMenu(app.mainWindow.Canvas(), app.inputHandler, "")
+}
+
+func relationKindsAsStrings() []string {
+	kinds := []string{}
+	for _, kind := range data.RelationKinds() {
+		kinds = append(kinds, string(kind))
+	}
+	return kinds
+}
+
+func (app *App) displayDialogForAddingRelation() {
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to add a relation to!")
+		return
+	}
+	typeName := app.getCurrentEntryType().Name
+	app.addRelationDialog.CleanItemValues()
+	app.addRelationDialog.SetItemValue("Kind", string(data.SequelRelation))
+	app.addRelationDialog.OnEnterPressed = func() { app.addRelationFromDialog(typeName, entry.Id) }
+	app.addRelationDialog.Display()
+}
+
+func (app *App) addRelationFromDialog(typeName string, entryId int) {
+	relatedTypeName, relatedEntry, err := app.entryWithTitle(app.addRelationDialog.ItemValue("Related entry"))
+	if err == nil {
+		err = app.entriesContainer.AddRelation(data.Relation{
+			Kind:            data.RelationKind(app.addRelationDialog.ItemValue("Kind")),
+			TypeName:        typeName,
+			EntryId:         entryId,
+			RelatedTypeName: relatedTypeName,
+			RelatedEntryId:  relatedEntry.Id,
+		})
+	}
+	if err != nil {
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.addRelationDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+//Titles are compared ignoring case. Entry type given in parentheses after the title is only needed when
+//there are entries with the same title in different entry types. Text in parentheses that isn't a name of an entry
+//type is a part of the title, e.g. 'Solaris (1972)'.
+func (app *App) entryWithTitle(text string) (string, data.Entry, error) {
+	title, typeName := strings.TrimSpace(text), ""
+	if strings.HasSuffix(title, ")") && strings.Contains(title, " (") {
+		openingParenthesisIndex := strings.LastIndex(title, " (")
+		parenthesisedText := title[openingParenthesisIndex+2 : len(title)-1]
+		if _, err := app.entriesContainer.EntryTypeWithName(parenthesisedText); err == nil {
+			title, typeName = title[:openingParenthesisIndex], parenthesisedText
+		}
+	}
+	typesNames := []string{}
+	var foundEntry data.Entry
+	for entryType, entries := range app.entriesContainer.EntriesGroupedByType() {
+		if typeName != "" && entryType.Name != typeName {
+			continue
+		}
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Title, title) {
+				typesNames = append(typesNames, entryType.Name)
+				foundEntry = entry
+			}
+		}
+	}
+	if len(typesNames) == 0 {
+		return "", data.Entry{}, errors.New("There is no entry with title '" + text + "'")
+	} else if len(typesNames) > 1 {
+		sort.Strings(typesNames)
+		return "", data.Entry{}, errors.New("There are many entries with title '" + title + "', choose one by its entry type, e.g. '" +
+			title + " (" + typesNames[0] + ")'")
+	}
+	return typesNames[0], foundEntry, nil
+}
+
+//Describes the related entry of the relation by its title and entry type, e.g. 'Dune Messiah (books)'
+func (app *App) describeRelatedEntry(relation data.Relation) string {
+	entries := app.entriesContainer.EntriesGroupedByType()
+	entryType, _ := app.entriesContainer.EntryTypeWithName(relation.RelatedTypeName)
+	relatedEntry, _ := entryWithIdIn(entries[entryType], relation.RelatedEntryId)
+	return relatedEntry.Title + " (" + relation.RelatedTypeName + ")"
+}
+
+func (app *App) relationsDetails(entry data.Entry) []widget.Detail {
+	details := []widget.Detail{{Value: "Relations"}}
+	relations := app.entriesContainer.RelationsOf(app.getCurrentEntryType().Name, entry.Id)
+	if len(relations) == 0 {
+		details = append(details, widget.Detail{Value: "There are no related entries"})
+	}
+	for _, relation := range relations {
+		details = append(details, widget.Detail{Name: string(relation.Kind), Value: app.describeRelatedEntry(relation)})
+	}
+	return details
+}
+
+//Chosen relation is passed to the function, after the menu of the current entry's relations gets hidden
+func (app *App) displayRelationsOfCurrentEntry(actionName string, onRelationChosen func(data.Relation)) {
+	entry, exists := app.currentEntry()
+	if !exists {
+		app.msgDialog.Display(widget.WarningPopUp, "There is no entry to "+actionName+"!")
+		return
+	}
+	relations := app.entriesContainer.RelationsOf(app.getCurrentEntryType().Name, entry.Id)
+	if len(relations) == 0 {
+		app.msgDialog.Display(widget.WarningPopUp, "Entry '"+entry.Title+"' has no related entries!")
+		return
+	}
+	choices := []string{}
+	for _, relation := range relations {
+		choices = append(choices, string(relation.Kind)+": "+app.describeRelatedEntry(relation))
+	}
+	app.relationsMenu.SetChoices(choices...)
+	app.relationsMenu.OnChoiceSelectedCallback = func(string) {
+		onRelationChosen(relations[app.relationsMenu.CurrentChoiceNum()])
+	}
+	app.relationsMenu.Show()
+}
+
+func (app *App) displayEntriesRelatedToCurrentEntry() {
+	app.displayRelationsOfCurrentEntry("go to a related entry of", func(relation data.Relation) {
+		app.selectEntry(relation.RelatedTypeName, relation.RelatedEntryId)
+	})
+}
+
+func (app *App) displayRelationsToRemoveFromCurrentEntry() {
+	app.displayRelationsOfCurrentEntry("remove a relation of", func(relation data.Relation) {
+		err := app.entriesContainer.RemoveRelation(relation)
+		if err != nil {
+			log.Error(err)
+			app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+		}
+	})
+}
+
+//Switches to the tab of the entry's type and selects the entry in its table or cover grid
+func (app *App) selectEntry(typeName string, entryId int) {
+	entryType, err := app.entriesContainer.EntryTypeWithName(typeName)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	app.entriesTypesTabs.SelectTabWithName(typeName)
+	for entryNum, entry := range app.entriesContainer.EntriesGroupedByType()[entryType] {
+		if entry.Id == entryId {
+			table := app.entriesTables[entryType]
+			table.SelectCell(entryNum, table.CurrentColumnNum())
+			if grid, gridExists := app.entriesCoverGrids[entryType]; gridExists {
+				grid.SelectItem(entryNum)
+			}
+			return
+		}
+	}
+}
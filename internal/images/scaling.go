@@ -0,0 +1,64 @@
+package images
+
+import (
+	"image"
+	"image/color"
+)
+
+//Scales the image so it fits in the given size while keeping its aspect ratio.
+//Every pixel of the scaled image is an average of the pixels of the source image that it covers.
+func scaleToFit(source image.Image, maxWidth int, maxHeight int) image.Image {
+	sourceBounds := source.Bounds()
+	width, height := fittingSize(sourceBounds.Dx(), sourceBounds.Dy(), maxWidth, maxHeight)
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sourceY0, sourceY1 := sourceRange(y, height, sourceBounds.Min.Y, sourceBounds.Dy())
+		for x := 0; x < width; x++ {
+			sourceX0, sourceX1 := sourceRange(x, width, sourceBounds.Min.X, sourceBounds.Dx())
+			scaled.Set(x, y, averageColor(source, sourceX0, sourceX1, sourceY0, sourceY1))
+		}
+	}
+	return scaled
+}
+
+func fittingSize(width int, height int, maxWidth int, maxHeight int) (int, int) {
+	if width == 0 || height == 0 {
+		return 1, 1
+	}
+	scaledWidth, scaledHeight := maxWidth, height*maxWidth/width
+	if scaledHeight > maxHeight {
+		scaledWidth, scaledHeight = width*maxHeight/height, maxHeight
+	}
+	if scaledWidth < 1 {
+		scaledWidth = 1
+	}
+	if scaledHeight < 1 {
+		scaledHeight = 1
+	}
+	return scaledWidth, scaledHeight
+}
+
+//Range of source pixels covered by the given pixel of the scaled image, it always contains at least one pixel
+func sourceRange(scaledPosition int, scaledLength int, sourceStart int, sourceLength int) (int, int) {
+	start := sourceStart + scaledPosition*sourceLength/scaledLength
+	end := sourceStart + (scaledPosition+1)*sourceLength/scaledLength
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
+
+func averageColor(source image.Image, x0 int, x1 int, y0 int, y1 int) color.Color {
+	var r, g, b, a, amount uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pixelR, pixelG, pixelB, pixelA := source.At(x, y).RGBA()
+			r += uint64(pixelR)
+			g += uint64(pixelG)
+			b += uint64(pixelB)
+			a += uint64(pixelA)
+			amount++
+		}
+	}
+	return color.RGBA64{R: uint16(r / amount), G: uint16(g / amount), B: uint16(b / amount), A: uint16(a / amount)}
+}
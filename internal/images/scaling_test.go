@@ -0,0 +1,27 @@
+package images
+
+import (
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestThatScaledImageFitsInGivenSizeAndKeepsAspectRatio(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 100, 50), scaleToFit(image.NewRGBA(image.Rect(0, 0, 400, 200)), 100, 141).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 70, 141), scaleToFit(image.NewRGBA(image.Rect(0, 0, 200, 400)), 100, 141).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 100, 100), scaleToFit(image.NewRGBA(image.Rect(0, 0, 10, 10)), 100, 141).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 1, 1), scaleToFit(image.NewRGBA(image.Rect(0, 0, 1000, 1)), 1, 141).Bounds())
+}
+
+func TestThatPixelsOfScaledImageAreAveragesOfSourcePixels(t *testing.T) {
+	source := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	source.Set(0, 0, color.RGBA{R: 200, A: 255})
+	source.Set(1, 0, color.RGBA{R: 100, A: 255})
+	scaled := scaleToFit(source, 1, 1)
+	r, g, b, a := scaled.At(0, 0).RGBA()
+	assert.Equal(t, uint32(150), r>>8)
+	assert.Equal(t, uint32(0), g)
+	assert.Equal(t, uint32(0), b)
+	assert.Equal(t, uint32(255), a>>8)
+}
@@ -0,0 +1,234 @@
+package images
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"wirwl/internal/data"
+)
+
+/*
+Store keeps cover images of entries in a directory, usually inside application's data directory.
+Every imported image is saved under a name derived from a hash of its contents, so the same image imported for many
+entries is stored only once, and thumbnails of all available sizes are generated for it right away. Entries refer
+to their images by those hashes, so images follow entries wherever the entries go, e.g. when their type gets renamed,
+and the same hash always refers to the same image.
+The directory has the following structure:
+
+	originals/<hash>.<format>
+	thumbnails/<hash>_<size>.png
+*/
+type Store struct {
+	dirPath string
+}
+
+//Thumbnail size is the width of a thumbnail, its height is calculated using covers' aspect ratio
+type ThumbnailSize int
+
+const (
+	SmallThumbnail  ThumbnailSize = 50
+	MediumThumbnail ThumbnailSize = 100
+	LargeThumbnail  ThumbnailSize = 200
+)
+
+func ThumbnailSizes() []ThumbnailSize {
+	return []ThumbnailSize{SmallThumbnail, MediumThumbnail, LargeThumbnail}
+}
+
+//Covers are usually posters or book covers which are roughly 1:1.41 in size
+const coverAspectRatio = 1.41
+
+//Images are decoded as a whole, so bigger ones could take gigabytes of memory even if their files are small. No cover
+//needs more pixels than that, it's far more than any poster or scan of a book cover has.
+const maxImagePixels = 50 * 1000 * 1000
+
+const originalsDirName = "originals"
+const thumbnailsDirName = "thumbnails"
+
+func NewStore(dirPath string) *Store {
+	return &Store{dirPath: dirPath}
+}
+
+//Creates the store's directory if it doesn't exist yet
+func (store *Store) Load() error {
+	for _, dirPath := range []string{store.dirPath, store.originalsDirPath(), store.thumbnailsDirPath()} {
+		err := data.CreateDirIfNotExist(dirPath)
+		if err != nil {
+			return errors.Wrap(err, "Failed to create a directory for cover images")
+		}
+	}
+	return nil
+}
+
+func (store *Store) DirPath() string {
+	return store.dirPath
+}
+
+func (store *Store) originalsDirPath() string {
+	return filepath.Join(store.dirPath, originalsDirName)
+}
+
+func (store *Store) thumbnailsDirPath() string {
+	return filepath.Join(store.dirPath, thumbnailsDirName)
+}
+
+//Returns the hash of the imported image, which is how entries refer to it
+func (store *Store) Import(sourcePath string) (string, error) {
+	imageData, err := ioutil.ReadFile(sourcePath)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to read an image in path "+sourcePath)
+	}
+	return store.ImportData(imageData)
+}
+
+//Works like Import but for an image that is already in memory e.g. because it has been downloaded
+func (store *Store) ImportData(imageData []byte) (string, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(imageData))
+	if err != nil {
+		return "", errors.Wrap(err, "Given file is not an image in one of the supported formats (PNG, JPEG, GIF)")
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return "", errors.New("Given image is too big to be a cover as it's " + strconv.Itoa(config.Width) + "x" + strconv.Itoa(config.Height) + " pixels")
+	}
+	decodedImage, format, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return "", errors.Wrap(err, "Given file is not an image in one of the supported formats (PNG, JPEG, GIF)")
+	}
+	hashBytes := sha256.Sum256(imageData)
+	hash := hex.EncodeToString(hashBytes[:])
+	if _, err := os.Stat(store.originalPath(hash, format)); os.IsNotExist(err) {
+		err = store.saveOriginalAndThumbnails(hash, format, imageData, decodedImage)
+		if err != nil {
+			return "", err
+		}
+	}
+	return hash, nil
+}
+
+func (store *Store) originalPath(hash string, format string) string {
+	return filepath.Join(store.originalsDirPath(), hash+"."+format)
+}
+
+func (store *Store) thumbnailPath(hash string, size ThumbnailSize) string {
+	return filepath.Join(store.thumbnailsDirPath(), hash+"_"+strconv.Itoa(int(size))+".png")
+}
+
+func (store *Store) saveOriginalAndThumbnails(hash string, format string, imageData []byte, decodedImage image.Image) error {
+	err := ioutil.WriteFile(store.originalPath(hash, format), imageData, 0600)
+	if err != nil {
+		return errors.Wrap(err, "Failed to save an image in path "+store.originalPath(hash, format))
+	}
+	for _, size := range ThumbnailSizes() {
+		err = saveThumbnail(decodedImage, size, store.thumbnailPath(hash, size))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func saveThumbnail(sourceImage image.Image, size ThumbnailSize, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create a thumbnail file in path "+path)
+	}
+	defer file.Close()
+	maxHeight := int(float64(size) * coverAspectRatio)
+	err = png.Encode(file, scaleToFit(sourceImage, int(size), maxHeight))
+	if err != nil {
+		return errors.Wrap(err, "Failed to save a thumbnail in path "+path)
+	}
+	return nil
+}
+
+//Returns the path of the original image with the given hash
+func (store *Store) ImagePath(hash string) (string, bool) {
+	if hash == "" {
+		return "", false
+	}
+	matches, _ := filepath.Glob(filepath.Join(store.originalsDirPath(), hash+".*"))
+	if len(matches) == 0 {
+		return "", false
+	}
+	return matches[0], true
+}
+
+func (store *Store) ThumbnailPath(hash string, size ThumbnailSize) (string, bool) {
+	if hash == "" {
+		return "", false
+	}
+	path := store.thumbnailPath(hash, size)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+func (store *Store) HasImage(hash string) bool {
+	_, exists := store.ImagePath(hash)
+	return exists
+}
+
+//Deletes all image files that are not used by any of the given entries. Images of deleted entries are kept until
+//this is done, as the deletion can be undone, so it should be done only for entries that have been saved.
+func (store *Store) RemoveOrphans(existingEntries map[data.EntryType][]data.Entry) error {
+	usedHashes := map[string]bool{}
+	for _, entries := range existingEntries {
+		for _, entry := range entries {
+			usedHashes[entry.Cover] = true
+		}
+	}
+	return store.deleteFilesNotIn(usedHashes)
+}
+
+func (store *Store) deleteFilesNotIn(usedHashes map[string]bool) error {
+	for _, dirPath := range []string{store.originalsDirPath(), store.thumbnailsDirPath()} {
+		files, err := ioutil.ReadDir(dirPath)
+		if err != nil {
+			return errors.Wrap(err, "Failed to read directory "+dirPath)
+		}
+		for _, file := range files {
+			hash := strings.SplitN(strings.SplitN(file.Name(), ".", 2)[0], "_", 2)[0]
+			if !usedHashes[hash] {
+				err = os.Remove(filepath.Join(dirPath, file.Name()))
+				if err != nil {
+					return errors.Wrap(err, "Failed to delete unused image "+file.Name())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+//Copies all of the images into the given directory so the copy can be used as a store on its own
+func (store *Store) CopyTo(destinationDirPath string) error {
+	for _, dirPath := range []string{destinationDirPath, filepath.Join(destinationDirPath, originalsDirName), filepath.Join(destinationDirPath, thumbnailsDirName)} {
+		err := data.CreateDirIfNotExist(dirPath)
+		if err != nil {
+			return err
+		}
+	}
+	for _, dirName := range []string{originalsDirName, thumbnailsDirName} {
+		files, err := ioutil.ReadDir(filepath.Join(store.dirPath, dirName))
+		if err != nil {
+			return errors.Wrap(err, "Failed to read directory "+filepath.Join(store.dirPath, dirName))
+		}
+		for _, file := range files {
+			err = data.CopyFile(filepath.Join(store.dirPath, dirName, file.Name()), filepath.Join(destinationDirPath, dirName, file.Name()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
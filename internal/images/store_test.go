@@ -0,0 +1,193 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+)
+
+func createTestStore() (*Store, func()) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	store := NewStore(filepath.Join(dir, "images"))
+	err = store.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return store, func() { _ = os.RemoveAll(dir) }
+}
+
+func createTestImageData(width int, height int, fillColor color.Color) []byte {
+	testImage := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			testImage.Set(x, y, fillColor)
+		}
+	}
+	buffer := bytes.Buffer{}
+	err := png.Encode(&buffer, testImage)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func createTestImageFile(dirPath string, width int, height int) string {
+	path := filepath.Join(dirPath, "test_image.png")
+	err := ioutil.WriteFile(path, createTestImageData(width, height, color.White), 0600)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return path
+}
+
+func amountOfFilesIn(dirPath string) int {
+	files, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return len(files)
+}
+
+func decodeImageFile(path string) image.Image {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	decodedImage, _, err := image.Decode(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return decodedImage
+}
+
+func TestThatImportedImageIsStoredWithThumbnails(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	imagePath := createTestImageFile(store.DirPath(), 400, 300)
+	hash, err := store.Import(imagePath)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, hash)
+	storedImagePath, exists := store.ImagePath(hash)
+	assert.True(t, exists)
+	assert.FileExists(t, storedImagePath)
+	assert.True(t, store.HasImage(hash))
+	assert.False(t, store.HasImage(""))
+	assert.False(t, store.HasImage("non existing hash"))
+	for _, size := range ThumbnailSizes() {
+		thumbnailPath, exists := store.ThumbnailPath(hash, size)
+		assert.True(t, exists)
+		thumbnail := decodeImageFile(thumbnailPath)
+		assert.Equal(t, int(size), thumbnail.Bounds().Dx())
+		assert.Equal(t, int(size)*3/4, thumbnail.Bounds().Dy())
+	}
+}
+
+func TestThatImportingFileThatIsNotAnImageFails(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	_, err := store.ImportData([]byte("not an image"))
+	assert.Equal(t, "Given file is not an image in one of the supported formats (PNG, JPEG, GIF): image: unknown format", err.Error())
+	assert.Equal(t, 0, amountOfFilesIn(store.originalsDirPath()))
+	_, err = store.Import(filepath.Join(store.DirPath(), "non existing file"))
+	assert.NotNil(t, err)
+}
+
+//Only the header of a PNG image is needed to tell its size, so it's enough to test images that are too big
+func pngHeaderOfSize(width uint32, height uint32) []byte {
+	header := make([]byte, 13)
+	binary.BigEndian.PutUint32(header[0:4], width)
+	binary.BigEndian.PutUint32(header[4:8], height)
+	header[8], header[9] = 8, 6
+	chunk := append([]byte("IHDR"), header...)
+	pngData := append([]byte("\x89PNG\r\n\x1a\n"), 0, 0, 0, byte(len(header)))
+	pngData = append(pngData, chunk...)
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(chunk))
+	return append(pngData, checksum...)
+}
+
+func TestThatImagesWithTooManyPixelsAreNotImported(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	_, err := store.ImportData(pngHeaderOfSize(20000, 20000))
+	assert.EqualError(t, err, "Given image is too big to be a cover as it's 20000x20000 pixels")
+	assert.Equal(t, 0, amountOfFilesIn(store.originalsDirPath()))
+}
+
+func TestThatTheSameImageIsStoredOnlyOnce(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	imageData := createTestImageData(10, 10, color.Black)
+	firstHash, _ := store.ImportData(imageData)
+	secondHash, _ := store.ImportData(imageData)
+	otherHash, _ := store.ImportData(createTestImageData(10, 10, color.White))
+	assert.Equal(t, firstHash, secondHash)
+	assert.NotEqual(t, firstHash, otherHash)
+	assert.Equal(t, 2, amountOfFilesIn(store.originalsDirPath()))
+	assert.Equal(t, 2*len(ThumbnailSizes()), amountOfFilesIn(store.thumbnailsDirPath()))
+}
+
+func TestThatImagesPersistAfterLoadingStoreAgain(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	hash, _ := store.ImportData(createTestImageData(10, 10, color.Black))
+	expectedPath, _ := store.ImagePath(hash)
+	loadedStore := NewStore(store.DirPath())
+	err := loadedStore.Load()
+	assert.Nil(t, err)
+	path, exists := loadedStore.ImagePath(hash)
+	assert.True(t, exists)
+	assert.Equal(t, expectedPath, path)
+}
+
+func TestThatImagesNotUsedByAnyEntryAreRemoved(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	usedHash, _ := store.ImportData(createTestImageData(10, 10, color.Black))
+	unusedHash, _ := store.ImportData(createTestImageData(10, 10, color.White))
+	leftoverFilePath := filepath.Join(store.originalsDirPath(), "leftover.png")
+	_ = ioutil.WriteFile(leftoverFilePath, []byte{}, 0600)
+	existingEntries := map[data.EntryType][]data.Entry{
+		{Name: "comics"}: {{Id: 0, Cover: usedHash}, {Id: 1}},
+		{Name: "music"}:  {{Id: 0, Cover: usedHash}},
+	}
+	err := store.RemoveOrphans(existingEntries)
+	assert.Nil(t, err)
+	assert.True(t, store.HasImage(usedHash))
+	assert.False(t, store.HasImage(unusedHash))
+	assert.Equal(t, 1, amountOfFilesIn(store.originalsDirPath()))
+	assert.Equal(t, len(ThumbnailSizes()), amountOfFilesIn(store.thumbnailsDirPath()))
+}
+
+func TestThatCopyOfStoreContainsAllImages(t *testing.T) {
+	store, cleanup := createTestStore()
+	defer cleanup()
+	firstHash, _ := store.ImportData(createTestImageData(10, 10, color.Black))
+	secondHash, _ := store.ImportData(createTestImageData(10, 10, color.White))
+	copyDirPath := filepath.Join(filepath.Dir(store.DirPath()), "backup")
+	err := store.CopyTo(copyDirPath)
+	assert.Nil(t, err)
+	copiedStore := NewStore(copyDirPath)
+	err = copiedStore.Load()
+	assert.Nil(t, err)
+	for _, hash := range []string{firstHash, secondHash} {
+		path, exists := copiedStore.ImagePath(hash)
+		assert.True(t, exists)
+		assert.FileExists(t, path)
+	}
+	assert.Equal(t, 2*len(ThumbnailSizes()), amountOfFilesIn(copiedStore.thumbnailsDirPath()))
+}
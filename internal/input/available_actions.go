@@ -12,9 +12,43 @@ const (
 	EditCurrentEntryTypeAction Action = "EDIT_CURRENT_ENTRY_TYPE"
 	MoveDownAction             Action = "MOVE_DOWN"
 	MoveUpAction               Action = "MOVE_UP"
+	MoveLeftAction             Action = "MOVE_LEFT"
+	MoveRightAction            Action = "MOVE_RIGHT"
 	EnterInputModeAction       Action = "ENTER_INPUT_MODE"
 	ExitInputModeAction        Action = "EXIT_INPUT_MODE"
 	ExitTableAction            Action = "EXIT_TABLE"
 	ConfirmAction              Action = "CONFIRM"
 	CancelAction               Action = "CANCEL"
+	EditColumnsLayoutAction    Action = "EDIT_COLUMNS_LAYOUT"
+	IncrementProgressAction    Action = "INCREMENT_PROGRESS"
+	DecrementProgressAction    Action = "DECREMENT_PROGRESS"
+	ToggleDisplayModeAction    Action = "TOGGLE_DISPLAY_MODE"
+	ImportCoverAction          Action = "IMPORT_COVER"
+	CreateBackupAction         Action = "CREATE_BACKUP"
+	DownloadCoverAction        Action = "DOWNLOAD_COVER"
+	EnrichEntryAction          Action = "ENRICH_ENTRY"
+	UndoAction                 Action = "UNDO"
+	RedoAction                 Action = "REDO"
+	ShowEntryDetailsAction     Action = "SHOW_ENTRY_DETAILS"
+	StartRewatchAction         Action = "START_REWATCH"
+	ShowStatsAction            Action = "SHOW_STATS"
+	CreateListAction           Action = "CREATE_LIST"
+	DeleteListAction           Action = "DELETE_LIST"
+	AddToListAction            Action = "ADD_TO_LIST"
+	RemoveFromListAction       Action = "REMOVE_FROM_LIST"
+	MoveDownInListAction       Action = "MOVE_DOWN_IN_LIST"
+	MoveUpInListAction         Action = "MOVE_UP_IN_LIST"
+	CreateSmartListAction      Action = "CREATE_SMART_LIST"
+	SwitchThemeAction          Action = "SWITCH_THEME"
+	AddRelationAction          Action = "ADD_RELATION"
+	RemoveRelationAction       Action = "REMOVE_RELATION"
+	GoToRelatedEntryAction     Action = "GO_TO_RELATED_ENTRY"
+	CreateSeriesAction         Action = "CREATE_SERIES"
+	DeleteSeriesAction         Action = "DELETE_SERIES"
+	AddToSeriesAction          Action = "ADD_TO_SERIES"
+	RemoveFromSeriesAction     Action = "REMOVE_FROM_SERIES"
+	FindDuplicatesAction       Action = "FIND_DUPLICATES"
+	ToggleEntryMarkAction      Action = "TOGGLE_ENTRY_MARK"
+	MoveEntriesAction          Action = "MOVE_ENTRIES"
+	CopyEntriesAction          Action = "COPY_ENTRIES"
 )
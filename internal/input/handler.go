@@ -2,6 +2,7 @@ package input
 
 import (
 	"fyne.io/fyne"
+	"sync"
 	"time"
 )
 
@@ -25,19 +26,25 @@ type callerActionPair struct {
 //	Press another key, 'U'. Combination becomes 'HU'.
 //	Also, an action can be executed based on either key of current combination (single key actions) or the combination itself.
 //	That is, in 'HU' both actions for 'H', 'U' or 'HU' can execute but order of precedence is 'HU', 'H', 'U'.
+//In normal mode digits typed in before an action, that don't have any action bound to them, make up a count e.g. '12'.
+//	The count is passed to the function executed for the action if it was bound using BindFunctionWithCountToAction
+//	and it defaults to 1 if no digits were typed in. Count is reset after any action gets executed.
 type Handler struct {
 	keymap                map[KeyCombination][]Action
-	actions               map[callerActionPair]func()
+	actions               map[callerActionPair]func(count int)
 	currentKeyCombination KeyCombination
 	lastKeyPressTime      time.Time
 	onKeyPressedCallback  func(KeyCombination)
+	count                 int
+	//Held while a function bound to an action executes, unless it's nil
+	locker sync.Locker
 }
 
 func NewHandler(actionKeyMap map[Action]KeyCombination) Handler {
 	keyActionMap := convertActionKeyKeymapToKeyCombinationActionKeymap(actionKeyMap)
 	handler := Handler{
 		keymap:                keyActionMap,
-		actions:               map[callerActionPair]func(){},
+		actions:               map[callerActionPair]func(count int){},
 		currentKeyCombination: KeyCombination{},
 		lastKeyPressTime:      time.Now(),
 		onKeyPressedCallback: func(combination KeyCombination) {
@@ -55,6 +62,10 @@ func convertActionKeyKeymapToKeyCombinationActionKeymap(actionKeyMap map[Action]
 }
 
 func (handler *Handler) BindFunctionToAction(caller interface{}, action Action, function func()) {
+	handler.BindFunctionWithCountToAction(caller, action, func(count int) { function() })
+}
+
+func (handler *Handler) BindFunctionWithCountToAction(caller interface{}, action Action, function func(count int)) {
 	callerActionPair := callerActionPair{
 		caller: caller,
 		action: action,
@@ -63,9 +74,47 @@ func (handler *Handler) BindFunctionToAction(caller interface{}, action Action,
 }
 
 func (handler *Handler) HandleInNormalMode(caller interface{}, keyName fyne.KeyName) {
+	if handler.tryAddingToCount(keyName) {
+		handler.onKeyPressedCallback(SingleKeyCombination(keyName))
+		return
+	}
 	handler.currentKeyCombination.press(keyName)
 	handler.onKeyPressedCallback(handler.currentKeyCombination)
-	handler.tryExecutingFunctionForCallerAndKeyCombination(caller, handler.currentKeyCombination)
+	functionExecuted, _ := handler.tryExecutingFunctionForCallerAndKeyCombination(caller, handler.currentKeyCombination)
+	if !functionExecuted && handler.currentKeyCombination.BothKeysPressed() {
+		handler.count = 0
+	}
+}
+
+//Digit can only be a part of count when it's not the first digit equal to 0, it is not in the middle of a key combination
+//and there is no action that should be executed when it's pressed
+func (handler *Handler) tryAddingToCount(keyName fyne.KeyName) bool {
+	digit, isDigit := digitFromKeyName(keyName)
+	if !isDigit || (digit == 0 && handler.count == 0) || handler.currentKeyCombination.OneKeyPressed() {
+		return false
+	}
+	if len(handler.keymap[SingleKeyCombination(keyName)]) > 0 {
+		return false
+	}
+	handler.count = handler.count*10 + digit
+	return true
+}
+
+func digitFromKeyName(keyName fyne.KeyName) (int, bool) {
+	if len(keyName) == 1 && keyName[0] >= '0' && keyName[0] <= '9' {
+		return int(keyName[0] - '0'), true
+	}
+	return 0, false
+}
+
+//Returns current count, 1 if there is no count, and resets it
+func (handler *Handler) consumeCount() int {
+	count := handler.count
+	handler.count = 0
+	if count == 0 {
+		return 1
+	}
+	return count
 }
 
 type HandlingResult struct {
@@ -100,7 +149,7 @@ func (handler *Handler) tryExecutingFunctionForCallerAndKeyCombination(caller in
 			}
 			function := handler.actions[callerActionPair]
 			if function != nil {
-				function()
+				handler.executeLocked(function, handler.consumeCount())
 				handler.currentKeyCombination.releaseKeys()
 				return true, HandlingResult{
 					KeyCombination: keyCombination,
@@ -112,6 +161,20 @@ func (handler *Handler) tryExecutingFunctionForCallerAndKeyCombination(caller in
 	return false, HandlingResult{}
 }
 
+func (handler *Handler) executeLocked(function func(count int), count int) {
+	if handler.locker != nil {
+		handler.locker.Lock()
+		defer handler.locker.Unlock()
+	}
+	function(count)
+}
+
+//Functions bound to actions are executed while holding the locker, so they don't run at the same time as something
+//else that holds it. Has to be set before the handler gets copied, as copies made before don't share it.
+func (handler *Handler) SetLocker(locker sync.Locker) {
+	handler.locker = locker
+}
+
 func (handler *Handler) SetOnKeyPressedCallbackFunction(function func(KeyCombination)) {
 	handler.onKeyPressedCallback = function
 }
@@ -347,4 +347,104 @@ func TestThatFunctionRebindingWorksCorrectly(t *testing.T) {
 	assert.False(t, testActionExecuted)
 	handler.HandleInNormalMode("Second caller", fyne.KeyY)
 	assert.True(t, testActionExecuted)
-}
\ No newline at end of file
+}
+
+func TestThatDigitsTypedBeforeActionArePassedAsCountToFunctionBoundWithCount(t *testing.T) {
+	passedCount := 0
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = SingleKeyCombination(fyne.KeyQ)
+	inputHandler := NewHandler(keymap)
+	inputHandler.BindFunctionWithCountToAction("", testAction, func(count int) { passedCount = count })
+	inputHandler.HandleInNormalMode("", fyne.Key1)
+	inputHandler.HandleInNormalMode("", fyne.Key0)
+	inputHandler.HandleInNormalMode("", fyne.Key5)
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 105, passedCount)
+}
+
+func TestThatCountIsOneWhenNoDigitsWereTypedAndItIsResetAfterAction(t *testing.T) {
+	passedCount := 0
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = SingleKeyCombination(fyne.KeyQ)
+	inputHandler := NewHandler(keymap)
+	inputHandler.BindFunctionWithCountToAction("", testAction, func(count int) { passedCount = count })
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 1, passedCount)
+	inputHandler.HandleInNormalMode("", fyne.Key3)
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 3, passedCount)
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 1, passedCount)
+}
+
+func TestThatCountWorksWithActionsInKeySequences(t *testing.T) {
+	passedCount := 0
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = TwoKeyCombination(fyne.KeyZ, fyne.KeyX)
+	inputHandler := NewHandler(keymap)
+	inputHandler.BindFunctionWithCountToAction("", testAction, func(count int) { passedCount = count })
+	inputHandler.HandleInNormalMode("", fyne.Key4)
+	inputHandler.HandleInNormalMode("", fyne.KeyZ)
+	inputHandler.HandleInNormalMode("", fyne.KeyX)
+	assert.Equal(t, 4, passedCount)
+}
+
+func TestThatCountIsResetWhenKeySequenceDoesNotMatchAnyAction(t *testing.T) {
+	passedCount := 0
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = SingleKeyCombination(fyne.KeyQ)
+	inputHandler := NewHandler(keymap)
+	inputHandler.BindFunctionWithCountToAction("", testAction, func(count int) { passedCount = count })
+	inputHandler.HandleInNormalMode("", fyne.Key4)
+	inputHandler.HandleInNormalMode("", fyne.KeyZ)
+	inputHandler.HandleInNormalMode("", fyne.KeyX)
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 1, passedCount)
+}
+
+func TestThatDigitsWithBoundActionsAndLeadingZeroAreNotTreatedAsCount(t *testing.T) {
+	zeroActionExecuted := false
+	passedCount := 0
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = SingleKeyCombination(fyne.KeyQ)
+	keymap[testAction2] = SingleKeyCombination(fyne.Key0)
+	inputHandler := NewHandler(keymap)
+	inputHandler.BindFunctionWithCountToAction("", testAction, func(count int) { passedCount = count })
+	inputHandler.BindFunctionToAction("", testAction2, func() { zeroActionExecuted = true })
+	inputHandler.HandleInNormalMode("", fyne.Key0)
+	assert.True(t, zeroActionExecuted)
+	inputHandler.HandleInNormalMode("", fyne.Key2)
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 2, passedCount)
+}
+
+func TestThatFunctionsBoundWithoutCountIgnoreIt(t *testing.T) {
+	timesExecuted := 0
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = SingleKeyCombination(fyne.KeyQ)
+	inputHandler := NewHandler(keymap)
+	inputHandler.BindFunctionToAction("", testAction, func() { timesExecuted++ })
+	inputHandler.HandleInNormalMode("", fyne.Key3)
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.Equal(t, 1, timesExecuted)
+}
+
+type testLocker struct {
+	locked bool
+}
+
+func (locker *testLocker) Lock()   { locker.locked = true }
+func (locker *testLocker) Unlock() { locker.locked = false }
+
+func TestThatLockerIsHeldWhileBoundFunctionExecutes(t *testing.T) {
+	locker := &testLocker{}
+	lockedDuringExecution := false
+	keymap := make(map[Action]KeyCombination)
+	keymap[testAction] = SingleKeyCombination(fyne.KeyQ)
+	inputHandler := NewHandler(keymap)
+	inputHandler.SetLocker(locker)
+	inputHandler.BindFunctionToAction("", testAction, func() { lockedDuringExecution = locker.locked })
+	inputHandler.HandleInNormalMode("", fyne.KeyQ)
+	assert.True(t, lockedDuringExecution)
+	assert.False(t, locker.locked)
+}
@@ -0,0 +1,101 @@
+package wirwl
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"net"
+	"net/http"
+	"os"
+	"time"
+	"wirwl/internal/api"
+	"wirwl/internal/data"
+	"wirwl/internal/log"
+)
+
+/*
+Only one process can change the collection at a time, so the application holds the instance lock in the app data
+directory while it's running. Commands run from the command line in the meantime are forwarded to the application
+through its API, which listens on a random port of localhost and requires a token that only the lock reveals.
+The collection can still be opened by another instance of the application read-only, in which case the instance
+doesn't hold the lock, doesn't journal its changes and can't save them.
+*/
+
+//Makes the application open the collection read-only, has to be called before it gets loaded
+func (app *App) OpenReadOnly() {
+	app.readOnly = true
+}
+
+func (app *App) acquireInstanceLock() error {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return errors.Wrap(err, "Failed to listen for commands forwarded to the application")
+	}
+	token, err := randomToken()
+	if err == nil {
+		app.instanceLock, err = data.AcquireInstanceLock(app.config.AppDataDirPath, data.LockOwner{
+			Pid:         os.Getpid(),
+			Description: "the application",
+			StartTime:   time.Now(),
+			Address:     listener.Addr().String(),
+			Token:       token,
+		})
+	}
+	if err != nil {
+		_ = listener.Close()
+		if _, isLocked := err.(data.InstanceLockedError); isLocked {
+			return errors.New(err.Error() + ". The collection can be opened read-only with the -r flag.")
+		}
+		return err
+	}
+	app.commandsListener = listener
+	app.commandsToken = token
+	return nil
+}
+
+func randomToken() (string, error) {
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to generate a token for the API of the application")
+	}
+	return hex.EncodeToString(token), nil
+}
+
+//Changes made by forwarded commands are left unsaved, so they are saved along with the other changes
+func (app *App) startServingCommands() {
+	server := api.NewServer(app.entriesContainer, func() error { return nil })
+	server.SetToken(app.commandsToken)
+	server.SetLocker(&app.entriesMutex)
+	environment := commandEnvironment{config: app.config, entriesContainer: app.entriesContainer, saveChanges: func() error { return nil }}
+	server.SetCommandRunner(environment.runForwardedCommand)
+	httpServer := &http.Server{Handler: server}
+	app.commandsServer = httpServer
+	listener := app.commandsListener
+	go func() {
+		err := httpServer.Serve(listener)
+		if err != http.ErrServerClosed {
+			log.Error(errors.Wrap(err, "Failed to serve commands forwarded to the application"))
+		}
+	}()
+}
+
+func (app *App) releaseInstanceLock() {
+	if app.instanceLock == nil {
+		return
+	}
+	var err error
+	if app.commandsServer != nil {
+		err = app.commandsServer.Close()
+	} else {
+		err = app.commandsListener.Close()
+	}
+	if err != nil {
+		log.Error(err)
+	}
+	err = app.instanceLock.Release()
+	if err != nil {
+		log.Error(err)
+	}
+	app.instanceLock = nil
+}
@@ -0,0 +1,67 @@
+package metadata
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"wirwl/internal/catalog"
+)
+
+/*
+HTTPProvider finds candidates by sending a GET request to a URL created from a template in which placeholder {title}
+is replaced with the escaped title of an entry, e.g. 'https://example.com/search?q={title}'.
+The response has to be a JSON array of candidates:
+
+	[{"title": "...", "description": "...", "totalElements": 12, "link": "...", "coverUrl": "..."}]
+
+where only title is required. Relative URLs are resolved against the URL of the request.
+*/
+type HTTPProvider struct {
+	urlTemplate   string
+	client        *catalog.Client
+	MaxCandidates int
+}
+
+const TitlePlaceholder = catalog.TitlePlaceholder
+
+//Responses with metadata are small so anything bigger is most likely not a response from a catalog
+const maxResponseSize = 5 * 1024 * 1024
+
+func NewHTTPProvider(urlTemplate string) *HTTPProvider {
+	return &HTTPProvider{
+		urlTemplate:   urlTemplate,
+		client:        catalog.NewClient(),
+		MaxCandidates: 10,
+	}
+}
+
+func (provider *HTTPProvider) SearchURL(title string) string {
+	return catalog.SearchURL(provider.urlTemplate, map[string]string{TitlePlaceholder: title})
+}
+
+func (provider *HTTPProvider) Search(title string) ([]Candidate, error) {
+	if provider.urlTemplate == "" {
+		return nil, errors.New("Cannot search for metadata as metadata URL template is not set")
+	}
+	searchURL := provider.SearchURL(title)
+	responseData, err := provider.client.Get(searchURL, maxResponseSize, "metadata", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to search for metadata of '"+title+"'")
+	}
+	candidates := []Candidate{}
+	err = json.Unmarshal(responseData, &candidates)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read metadata found for '"+title+"' as the response is not in the expected format")
+	}
+	validCandidates := []Candidate{}
+	for _, candidate := range candidates {
+		if candidate.Title == "" {
+			continue
+		}
+		catalog.ResolveURLs(searchURL, &candidate.Link, &candidate.CoverURL)
+		validCandidates = append(validCandidates, candidate)
+		if len(validCandidates) == provider.MaxCandidates {
+			break
+		}
+	}
+	return validCandidates, nil
+}
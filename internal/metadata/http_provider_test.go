@@ -0,0 +1,88 @@
+package metadata
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testCandidatesResponse = `[
+	{"title": "First", "description": "Description", "totalElements": 24, "link": "/works/1", "coverUrl": "/covers/1.png"},
+	{"description": "Without title"},
+	{"title": "Second", "link": "http://example.com/works/2"}
+]`
+
+func createTestServer(receivedTitles *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", func(writer http.ResponseWriter, request *http.Request) {
+		*receivedTitles = append(*receivedTitles, request.URL.Query().Get("q"))
+		_, _ = writer.Write([]byte(testCandidatesResponse))
+	})
+	mux.HandleFunc("/invalid", func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte("<html>not json</html>"))
+	})
+	mux.HandleFunc("/too_big", func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte(strings.Repeat(" ", maxResponseSize+1)))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestThatTitlePlaceholderInURLTemplateIsReplacedWithEscapedTitle(t *testing.T) {
+	provider := NewHTTPProvider("http://example.com/search?q={title}&type=anime")
+	assert.Equal(t, "http://example.com/search?q=Some+title+%26+more&type=anime", provider.SearchURL("Some title & more"))
+}
+
+func TestThatCandidatesAreFoundWithResolvedURLsAndWithoutOnesMissingTitle(t *testing.T) {
+	receivedTitles := []string{}
+	server := createTestServer(&receivedTitles)
+	defer server.Close()
+	provider := NewHTTPProvider(server.URL + "/search?q={title}")
+	candidates, err := provider.Search("Some title")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Some title"}, receivedTitles)
+	assert.Equal(t, []Candidate{
+		{Title: "First", Description: "Description", TotalElements: 24, Link: server.URL + "/works/1", CoverURL: server.URL + "/covers/1.png"},
+		{Title: "Second", Link: "http://example.com/works/2"},
+	}, candidates)
+}
+
+func TestThatAmountOfCandidatesIsLimited(t *testing.T) {
+	receivedTitles := []string{}
+	server := createTestServer(&receivedTitles)
+	defer server.Close()
+	provider := NewHTTPProvider(server.URL + "/search?q={title}")
+	provider.MaxCandidates = 1
+	candidates, err := provider.Search("Some title")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(candidates))
+	assert.Equal(t, "First", candidates[0].Title)
+}
+
+func TestThatErrorIsReturnedWhenSearchingWithoutURLTemplate(t *testing.T) {
+	_, err := NewHTTPProvider("").Search("Some title")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "metadata URL template is not set")
+}
+
+func TestThatErrorIsReturnedWhenResponseIsNotInExpectedFormatOrTooBig(t *testing.T) {
+	receivedTitles := []string{}
+	server := createTestServer(&receivedTitles)
+	defer server.Close()
+	_, err := NewHTTPProvider(server.URL + "/invalid").Search("Some title")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not in the expected format")
+	_, err = NewHTTPProvider(server.URL + "/too_big").Search("Some title")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "too big")
+}
+
+func TestThatErrorIsReturnedWhenServerRespondsWithErrorStatus(t *testing.T) {
+	receivedTitles := []string{}
+	server := createTestServer(&receivedTitles)
+	defer server.Close()
+	_, err := NewHTTPProvider(server.URL + "/missing").Search("Some title")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed with status 404")
+}
@@ -0,0 +1,37 @@
+package metadata
+
+import (
+	"wirwl/internal/data"
+)
+
+/*
+Metadata of entries, e.g. their descriptions or amounts of episodes, can be found using a provider which, for a given title,
+returns candidates i.e. works from an external catalog that the entry might be. A candidate chosen by a user is then
+used to fill in the fields of the entry.
+*/
+type Provider interface {
+	Search(title string) ([]Candidate, error)
+}
+
+type Candidate struct {
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	TotalElements int    `json:"totalElements"`
+	Link          string `json:"link"`
+	CoverURL      string `json:"coverUrl"`
+}
+
+//Returns a copy of the entry with its fields replaced by the values the candidate has.
+//Title is never replaced as titles in catalogs often differ from the ones users choose for their entries.
+func (candidate Candidate) AppliedTo(entry data.Entry) data.Entry {
+	if candidate.Description != "" {
+		entry.Description = candidate.Description
+	}
+	if candidate.TotalElements > 0 {
+		entry.TotalAmountOfElementsToComplete = candidate.TotalElements
+	}
+	if candidate.Link != "" {
+		entry.Link = candidate.Link
+	}
+	return entry
+}
@@ -0,0 +1,17 @@
+package metadata
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"wirwl/internal/data"
+)
+
+func TestThatCandidateReplacesOnlyFieldsItHasValuesFor(t *testing.T) {
+	entry := data.Entry{Id: 3, Title: "Title", Description: "Old", TotalAmountOfElementsToComplete: 5, Link: "old link", Comment: "Comment"}
+	candidate := Candidate{Title: "Other title", Description: "New", Link: "new link"}
+	expectedEntry := data.Entry{Id: 3, Title: "Title", Description: "New", TotalAmountOfElementsToComplete: 5, Link: "new link", Comment: "Comment"}
+	assert.Equal(t, expectedEntry, candidate.AppliedTo(entry))
+	candidate = Candidate{TotalElements: 12}
+	expectedEntry = data.Entry{Id: 3, Title: "Title", Description: "Old", TotalAmountOfElementsToComplete: 12, Link: "old link", Comment: "Comment"}
+	assert.Equal(t, expectedEntry, candidate.AppliedTo(entry))
+}
@@ -0,0 +1,154 @@
+package wirwl
+
+import (
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+	"wirwl/internal/data"
+	"wirwl/internal/widget"
+)
+
+/*
+Smart lists are saved filters whose matching entries are displayed in their own tabs, like entries of lists. Unlike lists,
+their entries are not chosen by hand but are all entries matching the filter at the moment, so their tables are recreated
+whenever entries change. Filters are stored in the config, as smart lists don't hold any data on their own.
+*/
+
+//Tabs of smart lists start with the prefix so they can't be confused with tabs of entry types and lists
+const smartListTabPrefix = data.SmartListDisplayNamePrefix
+
+func smartListTabName(smartListName string) string {
+	return smartListTabPrefix + smartListName
+}
+
+//Returns the name of the smart list whose tab is selected, if the tab of a smart list is selected
+func (app *App) currentSmartListName() (string, bool) {
+	tabText := app.getCurrentTabText()
+	if !strings.HasPrefix(tabText, smartListTabPrefix) {
+		return "", false
+	}
+	smartListName := strings.TrimPrefix(tabText, smartListTabPrefix)
+	_, exists := app.smartListsTables[smartListName]
+	return smartListName, exists
+}
+
+func (app *App) smartListItems(smartListName string) []data.ListItem {
+	items := []data.ListItem{}
+	for _, filteredEntry := range app.entriesContainer.EntriesMatching(app.config.SmartLists[smartListName], time.Now()) {
+		items = append(items, data.ListItem{TypeName: filteredEntry.Type.Name, EntryId: filteredEntry.Entry.Id})
+	}
+	return items
+}
+
+func (app *App) recreateSmartListView(smartListName string, state entriesViewState) {
+	table := app.createListTable(app.smartListItems(smartListName))
+	app.smartListsTables[smartListName] = table
+	app.entriesTypesTabs.SetTab(smartListTabName(smartListName), table)
+	restoreListTableState(table, state)
+}
+
+//Any change of entries can make them start or stop matching the filters, so all smart lists are recreated after it
+func (app *App) refreshSmartListViews() {
+	for smartListName, table := range app.smartListsTables {
+		app.recreateSmartListView(smartListName, app.listTableStateOf(table))
+	}
+}
+
+func (app *App) prepareSmartListDialog() {
+	formItemFactory := widget.NewFormDialogFormItemFactory(app.mainWindow.Canvas(), app.inputHandler)
+	app.createSmartListDialog = widget.NewFormDialog(app.mainWindow.Canvas(), app.inputHandler, "Create new smart list",
+		formItemFactory.FormItemWithInputField("Name"),
+		formItemFactory.FormItemWithInputField("Statuses"),
+		formItemFactory.FormItemWithInputField("Tags"),
+		formItemFactory.FormItemWithInputField("Types"),
+		formItemFactory.FormItemWithInputField("Title contains"),
+		formItemFactory.FormItemWithInputField("Min score"),
+		formItemFactory.FormItemWithInputField("Not touched for days"),
+		formItemFactory.FormItemWithInputField("Related as"))
+	app.createSmartListDialog.OnEnterPressed = app.onEnterPressedInSmartListDialog
+}
+
+func (app *App) displayDialogForCreatingSmartList() {
+	app.createSmartListDialog.CleanItemValues()
+	app.createSmartListDialog.Display()
+}
+
+func (app *App) onEnterPressedInSmartListDialog() {
+	name := strings.TrimSpace(app.createSmartListDialog.ItemValue("Name"))
+	filter, err := app.filterFromSmartListDialog()
+	if err == nil {
+		err = app.createSmartList(name, filter)
+	}
+	if err != nil {
+		app.msgDialog.SetOneTimeOnHideCallback(func() {
+			app.createSmartListDialog.Display()
+		})
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
+
+//Statuses, tags, types and kinds of relations are separated with commas, e.g. 'Planned, On hold'
+func (app *App) filterFromSmartListDialog() (data.Filter, error) {
+	filter := data.Filter{
+		Tags:          data.SplitCommaSeparatedValues(app.createSmartListDialog.ItemValue("Tags")),
+		TypeNames:     data.SplitCommaSeparatedValues(app.createSmartListDialog.ItemValue("Types")),
+		TitleContains: strings.TrimSpace(app.createSmartListDialog.ItemValue("Title contains")),
+	}
+	for _, status := range data.SplitCommaSeparatedValues(app.createSmartListDialog.ItemValue("Statuses")) {
+		if !app.entriesContainer.IsValidStatus(data.EntryStatus(status)) {
+			return data.Filter{}, errors.New("'" + status + "' is not a valid status of an entry")
+		}
+		filter.Statuses = append(filter.Statuses, data.EntryStatus(status))
+	}
+	for _, kind := range data.SplitCommaSeparatedValues(app.createSmartListDialog.ItemValue("Related as")) {
+		if !data.IsValidRelationKind(data.RelationKind(kind)) {
+			return data.Filter{}, errors.New("'" + kind + "' is not a valid kind of relation")
+		}
+		filter.RelatedAs = append(filter.RelatedAs, data.RelationKind(kind))
+	}
+	var err error
+	filter.MinScore, err = parseOptionalNumber(app.createSmartListDialog.ItemValue("Min score"), "Min score")
+	if err != nil {
+		return data.Filter{}, err
+	}
+	filter.NotTouchedForDays, err = parseOptionalNumber(app.createSmartListDialog.ItemValue("Not touched for days"), "Not touched for days")
+	if err != nil {
+		return data.Filter{}, err
+	}
+	return filter, nil
+}
+
+//Empty text means that the criterion is not set, which is the same as 0
+func parseOptionalNumber(text string, fieldName string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(text)
+	if err != nil || number < 0 {
+		return 0, errors.New(fieldName + " has to be a non-negative number")
+	}
+	return number, nil
+}
+
+func (app *App) createSmartList(name string, filter data.Filter) error {
+	if name == "" {
+		return errors.New("Cannot create smart list with an empty name")
+	}
+	if _, exists := app.config.SmartLists[name]; exists {
+		return errors.New("Smart list with name '" + name + "' already exists")
+	}
+	if app.config.SmartLists == nil {
+		app.config.SmartLists = map[string]data.Filter{}
+	}
+	app.config.SmartLists[name] = filter
+	app.recreateSmartListView(name, entriesViewState{})
+	return nil
+}
+
+func (app *App) deleteSmartList(smartListName string) {
+	delete(app.config.SmartLists, smartListName)
+	delete(app.smartListsTables, smartListName)
+	app.entriesTypesTabs.RemoveTab(smartListTabName(smartListName))
+}
@@ -0,0 +1,218 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"wirwl/internal/data"
+)
+
+/*
+Statistics of entries of all entry types, calculated from the data of an entries container so they don't depend on
+how the entries are displayed. Entries with score 0 are treated as not scored and are left out of score statistics.
+Scores are normalized to the scale from 1 to 10, as each entry type can have a different scoring system, see
+data.ScoringSystem, and the histogram of scores groups them by the normalized scores rounded to whole numbers.
+Elements consumed in a month are counted from increases of progress recorded in consumption histories of entries,
+so progress made before histories have been recorded is not included. Entries are counted per status by the built-in
+statuses their statuses belong to, as each entry type has its own statuses, see data.TypeStatus.
+*/
+
+//Layout of months by which consumed elements are grouped, e.g. 2020-12, so they can be sorted as text
+const MonthLayout = "2006-01"
+
+//Amount of tags listed as the most used ones
+const MostUsedTagsLimit = 10
+
+//Amount of something with given label, e.g. amount of entries with a status
+type Count struct {
+	Label  string
+	Amount int
+}
+
+type Stats struct {
+	TotalEntries     int
+	EntriesPerStatus []Count
+	EntriesPerType   []Count
+	//Part of all entries that have been completed, from 0 to 1
+	CompletionRate           float64
+	ScoredEntries            int
+	MeanScore                float64
+	MedianScore              float64
+	ScoreHistogram           []Count
+	ElementsConsumedPerMonth []Count
+	FinishedEntries          int
+	MeanDaysToFinish         float64
+	MedianDaysToFinish       float64
+	MostUsedTags             []Count
+}
+
+func Calculate(entriesByType map[data.EntryType][]data.Entry) Stats {
+	stats := Stats{}
+	entries := []data.Entry{}
+	categories := []data.EntryStatus{}
+	scores := []float64{}
+	for _, entryType := range sortedEntryTypes(entriesByType) {
+		stats.EntriesPerType = append(stats.EntriesPerType, Count{entryType.Name, len(entriesByType[entryType])})
+		entries = append(entries, entriesByType[entryType]...)
+		for _, entry := range entriesByType[entryType] {
+			categories = append(categories, entryType.CategoryOf(entry.Status))
+			if entry.Score != 0 {
+				scores = append(scores, entryType.NormalizedScoreOf(entry))
+			}
+		}
+	}
+	stats.TotalEntries = len(entries)
+	stats.EntriesPerStatus = entriesPerStatus(categories)
+	stats.CompletionRate = completionRate(categories)
+	stats.ScoredEntries = len(scores)
+	stats.MeanScore, stats.MedianScore = mean(scores), median(scores)
+	stats.ScoreHistogram = scoreHistogram(scores)
+	stats.ElementsConsumedPerMonth = elementsConsumedPerMonth(entries)
+	daysToFinish := daysToFinishOf(entries)
+	stats.FinishedEntries = len(daysToFinish)
+	stats.MeanDaysToFinish, stats.MedianDaysToFinish = mean(daysToFinish), median(daysToFinish)
+	stats.MostUsedTags = mostUsedTags(entries, MostUsedTagsLimit)
+	return stats
+}
+
+func sortedEntryTypes(entriesByType map[data.EntryType][]data.Entry) []data.EntryType {
+	entryTypes := make([]data.EntryType, 0, len(entriesByType))
+	for entryType := range entriesByType {
+		entryTypes = append(entryTypes, entryType)
+	}
+	sort.Slice(entryTypes, func(i, j int) bool { return entryTypes[i].Name < entryTypes[j].Name })
+	return entryTypes
+}
+
+//Every built-in status is included, even if no entry belongs to it, in the order statuses are listed in
+func entriesPerStatus(categories []data.EntryStatus) []Count {
+	amounts := make(map[data.EntryStatus]int)
+	for _, category := range categories {
+		amounts[category]++
+	}
+	counts := []Count{}
+	for _, status := range data.EntryStatuses() {
+		counts = append(counts, Count{string(status), amounts[status]})
+	}
+	return counts
+}
+
+func completionRate(categories []data.EntryStatus) float64 {
+	if len(categories) == 0 {
+		return 0
+	}
+	completed := 0
+	for _, category := range categories {
+		if category == data.CompletedStatus {
+			completed++
+		}
+	}
+	return float64(completed) / float64(len(categories))
+}
+
+//Only scores given to at least one entry are included, from the lowest to the highest
+func scoreHistogram(scores []float64) []Count {
+	amounts := make(map[int]int)
+	for _, score := range scores {
+		amounts[int(math.Round(score))]++
+	}
+	distinctScores := make([]int, 0, len(amounts))
+	for score := range amounts {
+		distinctScores = append(distinctScores, score)
+	}
+	sort.Ints(distinctScores)
+	histogram := []Count{}
+	for _, score := range distinctScores {
+		histogram = append(histogram, Count{strconv.Itoa(score), amounts[score]})
+	}
+	return histogram
+}
+
+//Months are in local time and sorted chronologically. Decreases of progress, e.g. when starting a rewatch, are ignored.
+func elementsConsumedPerMonth(entries []data.Entry) []Count {
+	amounts := make(map[string]int)
+	for _, entry := range entries {
+		for _, event := range entry.ConsumptionHistory {
+			if event.Kind != data.ProgressChangedEvent {
+				continue
+			}
+			from, fromErr := strconv.Atoi(event.From)
+			to, toErr := strconv.Atoi(event.To)
+			if fromErr == nil && toErr == nil && to > from {
+				amounts[event.Date.Local().Format(MonthLayout)] += to - from
+			}
+		}
+	}
+	months := make([]string, 0, len(amounts))
+	for month := range amounts {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	counts := []Count{}
+	for _, month := range months {
+		counts = append(counts, Count{month, amounts[month]})
+	}
+	return counts
+}
+
+//Only entries with valid start and finish dates, where finish is not before start, are included
+func daysToFinishOf(entries []data.Entry) []float64 {
+	days := []float64{}
+	for _, entry := range entries {
+		start, startErr := time.Parse(data.DateLayout, entry.StartDate)
+		finish, finishErr := time.Parse(data.DateLayout, entry.FinishDate)
+		if startErr == nil && finishErr == nil && !finish.Before(start) {
+			days = append(days, math.Round(finish.Sub(start).Hours()/24))
+		}
+	}
+	return days
+}
+
+//Tags of an entry are separated with commas. Tags used equally often are sorted alphabetically.
+func mostUsedTags(entries []data.Entry, limit int) []Count {
+	amounts := make(map[string]int)
+	for _, entry := range entries {
+		for _, tag := range entry.TagsList() {
+			amounts[tag]++
+		}
+	}
+	tags := []Count{}
+	for tag, amount := range amounts {
+		tags = append(tags, Count{tag, amount})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Amount != tags[j].Amount {
+			return tags[i].Amount > tags[j].Amount
+		}
+		return tags[i].Label < tags[j].Label
+	})
+	if len(tags) > limit {
+		tags = tags[:limit]
+	}
+	return tags
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
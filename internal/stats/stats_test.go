@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"wirwl/internal/data"
+)
+
+var booksType = data.EntryType{Name: "books"}
+var gamesType = data.EntryType{Name: "games"}
+
+func middleOfMonth(month time.Month) time.Time {
+	return time.Date(2020, month, 15, 12, 0, 0, 0, time.Local)
+}
+
+func getTestEntries() map[data.EntryType][]data.Entry {
+	return map[data.EntryType][]data.Entry{
+		gamesType: {
+			{Status: data.CompletedStatus, Score: 8, Tags: "rpg, fantasy", StartDate: "01/01/2020", FinishDate: "11/01/2020"},
+			{Status: data.DroppedStatus, Score: 3, Tags: "rpg"},
+		},
+		booksType: {
+			{Status: data.CompletedStatus, Score: 8, Tags: "fantasy,rpg", StartDate: "01/02/2020", FinishDate: "21/02/2020",
+				ConsumptionHistory: []data.ConsumptionEvent{
+					{Kind: data.ProgressChangedEvent, Date: middleOfMonth(time.March), From: "0", To: "2"},
+					{Kind: data.ProgressChangedEvent, Date: middleOfMonth(time.January), From: "2", To: "3"},
+					{Kind: data.RewatchStartedEvent, Date: middleOfMonth(time.March)},
+					{Kind: data.ProgressChangedEvent, Date: middleOfMonth(time.March), From: "3", To: "0"},
+					{Kind: data.ProgressChangedEvent, Date: middleOfMonth(time.March), From: "0", To: "1"},
+				}},
+			{Status: data.PlannedStatus, Tags: "sci-fi"},
+		},
+	}
+}
+
+func TestThatEntriesAreCountedPerStatusAndType(t *testing.T) {
+	stats := Calculate(getTestEntries())
+	assert.Equal(t, 4, stats.TotalEntries)
+	assert.Equal(t, []Count{{"In progress", 0}, {"Completed", 2}, {"On hold", 0}, {"Dropped", 1}, {"Planned", 1}}, stats.EntriesPerStatus)
+	assert.Equal(t, []Count{{"books", 2}, {"games", 2}}, stats.EntriesPerType)
+	assert.Equal(t, 0.5, stats.CompletionRate)
+}
+
+func TestThatEntriesAreCountedPerBuiltInStatusesTheirStatusesBelongTo(t *testing.T) {
+	musicType := data.EntryType{Name: "music", Statuses: "Listening=In progress, Favourite=Completed, Completed"}
+	stats := Calculate(map[data.EntryType][]data.Entry{musicType: {{Status: "Listening"}, {Status: "Favourite"}, {Status: data.CompletedStatus}}})
+	assert.Equal(t, []Count{{"In progress", 1}, {"Completed", 2}, {"On hold", 0}, {"Dropped", 0}, {"Planned", 0}}, stats.EntriesPerStatus)
+	assert.InDelta(t, 2.0/3, stats.CompletionRate, 0.0001)
+}
+
+func TestThatScoreStatisticsIgnoreNotScoredEntries(t *testing.T) {
+	stats := Calculate(getTestEntries())
+	assert.Equal(t, 3, stats.ScoredEntries)
+	assert.InDelta(t, 19.0/3, stats.MeanScore, 0.0001)
+	assert.Equal(t, 8.0, stats.MedianScore)
+	assert.Equal(t, []Count{{"3", 1}, {"8", 2}}, stats.ScoreHistogram)
+}
+
+func TestThatElementsConsumedPerMonthAreCountedFromIncreasesOfProgress(t *testing.T) {
+	stats := Calculate(getTestEntries())
+	assert.Equal(t, []Count{{"2020-01", 1}, {"2020-03", 3}}, stats.ElementsConsumedPerMonth)
+}
+
+func TestThatTimeToFinishIsCalculatedFromDatesOfEntries(t *testing.T) {
+	entries := getTestEntries()
+	entries[booksType] = append(entries[booksType], data.Entry{StartDate: "10/01/2020", FinishDate: "01/01/2020"})
+	stats := Calculate(entries)
+	assert.Equal(t, 2, stats.FinishedEntries)
+	assert.Equal(t, 15.0, stats.MeanDaysToFinish)
+	assert.Equal(t, 15.0, stats.MedianDaysToFinish)
+}
+
+func TestThatMostUsedTagsAreSortedByAmountOfUses(t *testing.T) {
+	stats := Calculate(getTestEntries())
+	assert.Equal(t, []Count{{"rpg", 3}, {"fantasy", 2}, {"sci-fi", 1}}, stats.MostUsedTags)
+	assert.Equal(t, []Count{{"rpg", 2}}, mostUsedTags(getTestEntries()[gamesType], 1))
+}
+
+func TestThatStatisticsOfNoEntriesAreZero(t *testing.T) {
+	stats := Calculate(map[data.EntryType][]data.Entry{})
+	assert.Equal(t, 0, stats.TotalEntries)
+	assert.Equal(t, 0.0, stats.CompletionRate)
+	assert.Equal(t, 0.0, stats.MeanScore)
+	assert.Equal(t, 0.0, stats.MedianDaysToFinish)
+	assert.Empty(t, stats.ScoreHistogram)
+	assert.Empty(t, stats.MostUsedTags)
+}
+
+func TestThatScoresOfDifferentScoringSystemsAreNormalized(t *testing.T) {
+	stats := Calculate(map[data.EntryType][]data.Entry{
+		{Name: "books", ScoringSystem: data.HundredPointScoring}: {{Score: 100}, {Score: 45}},
+		{Name: "games", ScoringSystem: data.ThumbsScoring}:       {{Score: 1}, {Score: 0}},
+	})
+	assert.Equal(t, 3, stats.ScoredEntries)
+	assert.Equal(t, 5.0, stats.MedianScore)
+	assert.Equal(t, []Count{{"1", 1}, {"5", 1}, {"10", 1}}, stats.ScoreHistogram)
+}
@@ -0,0 +1,58 @@
+package wirwl
+
+import (
+	"strconv"
+	"wirwl/internal/stats"
+	"wirwl/internal/widget"
+)
+
+/*
+Statistics of entries of all entry types are displayed in a dialog, as text tables for single values and as bar charts
+for values counted per something, e.g. per status. They are calculated every time the dialog gets displayed,
+so they include changes that haven't been saved yet.
+*/
+
+func (app *App) displayStats() {
+	entriesStats := stats.Calculate(app.entriesContainer.EntriesGroupedByType())
+	app.statsDialog.Display("Statistics",
+		widget.StatsSection{Title: "Summary", Rows: []widget.Detail{
+			{Name: "Entries", Value: strconv.Itoa(entriesStats.TotalEntries)},
+			{Name: "Completion rate", Value: formatPercentage(entriesStats.CompletionRate)},
+			{Name: "Scored entries", Value: strconv.Itoa(entriesStats.ScoredEntries)},
+			{Name: "Mean score", Value: formatStat(entriesStats.MeanScore)},
+			{Name: "Median score", Value: formatStat(entriesStats.MedianScore)},
+			{Name: "Finished entries with dates", Value: strconv.Itoa(entriesStats.FinishedEntries)},
+			{Name: "Mean days to finish", Value: formatStat(entriesStats.MeanDaysToFinish)},
+			{Name: "Median days to finish", Value: formatStat(entriesStats.MedianDaysToFinish)},
+		}},
+		widget.StatsSection{Title: "Entries per status", Bars: countsToBars(entriesStats.EntriesPerStatus)},
+		widget.StatsSection{Title: "Entries per type", Bars: countsToBars(entriesStats.EntriesPerType)},
+		widget.StatsSection{Title: "Scores", Bars: countsToBars(entriesStats.ScoreHistogram)},
+		widget.StatsSection{Title: "Elements consumed per month", Bars: countsToBars(entriesStats.ElementsConsumedPerMonth)},
+		widget.StatsSection{Title: "Most used tags", Rows: countsToRows(entriesStats.MostUsedTags)},
+	)
+}
+
+func countsToBars(counts []stats.Count) []widget.Bar {
+	bars := []widget.Bar{}
+	for _, count := range counts {
+		bars = append(bars, widget.Bar{Label: count.Label, Value: float64(count.Amount)})
+	}
+	return bars
+}
+
+func countsToRows(counts []stats.Count) []widget.Detail {
+	rows := []widget.Detail{}
+	for _, count := range counts {
+		rows = append(rows, widget.Detail{Name: count.Label, Value: strconv.Itoa(count.Amount)})
+	}
+	return rows
+}
+
+func formatStat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func formatPercentage(fraction float64) string {
+	return strconv.FormatFloat(fraction*100, 'f', 1, 64) + "%"
+}
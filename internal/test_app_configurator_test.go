@@ -56,6 +56,14 @@ func (configurator *TestAppConfigurator) createTestConfig() *TestAppConfigurator
 	return configurator
 }
 
+//Config of an application that has not been configured yet which still uses test directories
+func (configurator *TestAppConfigurator) createEmptyTestConfig() *TestAppConfigurator {
+	config := NewConfig(testConfigDirPath)
+	config.AppDataDirPath = testAppDataDirPath
+	configurator.config = config
+	return configurator
+}
+
 //Should be only called if config has been already created
 func (configurator *TestAppConfigurator) createTestConfigFile() *TestAppConfigurator {
 	configurator.config.saveConfigIn(configurator.config.ConfigDirPath + "wirwl.cfg")
@@ -123,6 +131,7 @@ func (configurator *TestAppConfigurator) createTestApplicationThatUsesExistingDa
 func (configurator *TestAppConfigurator) createTestApplicationThatWillRunForFirstTime() *TestAppConfigurator {
 	configurator.
 		createTestDirectories().
+		createEmptyTestConfig().
 		createDefaultDataProvider().
 		createEmptyLoadingErrors().
 		createTestApplication()
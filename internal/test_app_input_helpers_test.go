@@ -1,6 +1,10 @@
 package wirwl
 
-import "fyne.io/fyne"
+import (
+	"fyne.io/fyne"
+	"wirwl/internal/data"
+	"wirwl/internal/widget"
+)
 
 func (app *App) simulateKeyPress(key fyne.KeyName) {
 	event := &fyne.KeyEvent{Name: key}
@@ -55,3 +59,172 @@ func (app *App) simulateEditionOfCurrentEntryTypeTo(text string) {
 	app.editEntryTypeDialog.Type(text)
 	app.simulateKeyPress(fyne.KeyReturn)
 }
+
+func (app *App) simulateOpeningDialogForEditingColumnsLayout() {
+	app.simulateKeyPress(fyne.KeyC)
+	app.simulateKeyPress(fyne.KeyE)
+}
+
+//Columns not present in the passed specifications are hidden
+func (app *App) simulateEditionOfColumnsLayout(specifications map[string]string) {
+	app.simulateOpeningDialogForEditingColumnsLayout()
+	app.editColumnsLayoutDialog.CleanItemValues()
+	for columnName, specification := range specifications {
+		app.editColumnsLayoutDialog.SetItemValue(columnName, specification)
+	}
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+//Default columns layout is assumed so e.g. column 3 is 'Title'
+func (app *App) simulateStartingEditionOfCellInCurrentEntryTypeTable(rowNum int, columnNum int) {
+	app.simulateKeyPress(fyne.KeyI)
+	for i := 0; i < rowNum; i++ {
+		app.simulateKeyPress(fyne.KeyJ)
+	}
+	for i := 0; i < columnNum; i++ {
+		app.simulateKeyPress(fyne.KeyL)
+	}
+	app.simulateKeyPress(fyne.KeyI)
+}
+
+//Replaces the value in the editor of currently edited cell instead of appending to it
+func (app *App) simulateTypingIntoCellEditor(text string) {
+	editor := app.getCurrentEntryTypeTable().Editor()
+	editor.SetText("")
+	widget.TypeIntoFocusable(editor, text)
+}
+
+func (app *App) simulateEditionOfCellInCurrentEntryTypeTable(rowNum int, columnNum int, text string) {
+	app.simulateStartingEditionOfCellInCurrentEntryTypeTable(rowNum, columnNum)
+	app.simulateTypingIntoCellEditor(text)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateTogglingDisplayModeOfCurrentEntryType() {
+	app.simulateKeyPress(fyne.KeyV)
+	app.simulateKeyPress(fyne.KeyC)
+}
+
+//Cover is imported for the entry that is currently selected in the current entry type's table or grid
+func (app *App) simulateImportingCover(path string) {
+	app.simulateKeyPress(fyne.KeyC)
+	app.simulateKeyPress(fyne.KeyI)
+	app.simulateKeyPress(fyne.KeyI)
+	app.importCoverDialog.Type(path)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+//Covers are searched for the entry that is currently selected in the current entry type's table or grid
+func (app *App) simulateFindingCovers() {
+	app.simulateKeyPress(fyne.KeyC)
+	app.simulateKeyPress(fyne.KeyD)
+}
+
+func (app *App) simulateSearchingForMetadataOfCurrentEntry() {
+	app.simulateKeyPress(fyne.KeyE)
+	app.simulateKeyPress(fyne.KeyN)
+}
+
+func (app *App) simulateUndo() {
+	app.simulateKeyPress(fyne.KeyU)
+}
+
+func (app *App) simulateRedo() {
+	app.simulateKeyPress(fyne.KeyR)
+}
+
+func (app *App) simulateDisplayingDetailsOfCurrentEntry() {
+	app.simulateKeyPress(fyne.KeyV)
+	app.simulateKeyPress(fyne.KeyD)
+}
+
+func (app *App) simulateStartingRewatchOfCurrentEntry() {
+	app.simulateKeyPress(fyne.KeyS)
+	app.simulateKeyPress(fyne.KeyR)
+}
+
+func (app *App) simulateDisplayingStats() {
+	app.simulateKeyPress(fyne.KeyV)
+	app.simulateKeyPress(fyne.KeyS)
+}
+
+func (app *App) simulateCreatingListWithName(name string) {
+	app.simulateKeyPress(fyne.KeyN)
+	app.simulateKeyPress(fyne.KeyL)
+	app.simulateKeyPress(fyne.KeyI)
+	app.createListDialog.Type(name)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateAddingCurrentEntryToList(listName string) {
+	app.simulateKeyPress(fyne.KeyA)
+	app.simulateKeyPress(fyne.KeyL)
+	app.listsMenu.SelectChoiceWithText(listName)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateRemovingCurrentEntryFromList() {
+	app.simulateKeyPress(fyne.KeyX)
+	app.simulateKeyPress(fyne.KeyL)
+}
+
+func (app *App) simulateMovingCurrentEntryDownInList() {
+	app.simulateKeyPress(fyne.KeyM)
+	app.simulateKeyPress(fyne.KeyJ)
+}
+
+//Criteria of the filter are set by the names of their fields in the dialog, e.g. 'Statuses', criteria not present are not set
+func (app *App) simulateCreatingSmartList(name string, criteria map[string]string) {
+	app.simulateKeyPress(fyne.KeyN)
+	app.simulateKeyPress(fyne.KeyS)
+	app.createSmartListDialog.SetItemValue("Name", name)
+	for fieldName, value := range criteria {
+		app.createSmartListDialog.SetItemValue(fieldName, value)
+	}
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateAddingRelationToCurrentEntry(kind data.RelationKind, relatedEntry string) {
+	app.simulateKeyPress(fyne.KeyA)
+	app.simulateKeyPress(fyne.KeyR)
+	app.addRelationDialog.SetItemValue("Kind", string(kind))
+	app.addRelationDialog.SetItemValue("Related entry", relatedEntry)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateGoingToFirstRelatedEntry() {
+	app.simulateKeyPress(fyne.KeyG)
+	app.simulateKeyPress(fyne.KeyR)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateRemovingFirstRelationOfCurrentEntry() {
+	app.simulateKeyPress(fyne.KeyD)
+	app.simulateKeyPress(fyne.KeyR)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateCreatingSeriesWithName(name string) {
+	app.simulateKeyPress(fyne.KeyN)
+	app.simulateKeyPress(fyne.KeyF)
+	app.simulateKeyPress(fyne.KeyI)
+	app.createSeriesDialog.Type(name)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateAddingCurrentEntryToSeries(seriesName string) {
+	app.simulateKeyPress(fyne.KeyA)
+	app.simulateKeyPress(fyne.KeyF)
+	app.seriesMenu.SelectChoiceWithText(seriesName)
+	app.simulateKeyPress(fyne.KeyReturn)
+}
+
+func (app *App) simulateRemovingCurrentEntryFromSeries() {
+	app.simulateKeyPress(fyne.KeyX)
+	app.simulateKeyPress(fyne.KeyF)
+}
+
+//Test windows are closed without calling the close intercept so it has to be called directly
+func (app *App) simulateClosingWindow() {
+	app.onCloseRequested()
+}
@@ -3,7 +3,11 @@ package wirwl
 import (
 	"bytes"
 	"github.com/BurntSushi/toml"
+	"image"
+	"image/png"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -173,3 +177,63 @@ func (config *Config) saveConfigIn(configFilePath string) {
 		log.Fatal(err)
 	}
 }
+
+//Creates a small PNG image in test data directory and returns the path to it
+func createTestImageFile(fileName string) string {
+	path := filepath.Join(testDataDirPath, fileName)
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	err = png.Encode(file, image.NewRGBA(image.Rect(0, 0, 20, 30)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return path
+}
+
+//Server responding to any search with given amount of candidates whose images are 20x30 PNGs.
+//Requested paths are recorded so tests can check what has been downloaded.
+func startTestCoverServer(candidatesAmount int, requestedPaths *[]string) *httptest.Server {
+	imageData := bytes.Buffer{}
+	err := png.Encode(&imageData, image.NewRGBA(image.Rect(0, 0, 20, 30)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		*requestedPaths = append(*requestedPaths, request.URL.Path)
+		if request.URL.Path != "/search" {
+			writer.Write(imageData.Bytes())
+			return
+		}
+		candidates := "["
+		for i := 0; i < candidatesAmount; i++ {
+			if i > 0 {
+				candidates += ","
+			}
+			candidates += `{"title": "Cover", "imageUrl": "/image` + string(rune('0'+i)) + `.png", "thumbnailUrl": "/thumbnail` + string(rune('0'+i)) + `.png"}`
+		}
+		writer.Write([]byte(candidates + "]"))
+	}))
+}
+
+//Server responding to any search with two candidates, the first one of which has a cover that is a 20x30 PNG
+func startTestMetadataServer() *httptest.Server {
+	coverData := bytes.Buffer{}
+	err := png.Encode(&coverData, image.NewRGBA(image.Rect(0, 0, 20, 30)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte(`[
+			{"title": "Found comic", "description": "Found description", "totalElements": 2, "link": "/works/1", "coverUrl": "/covers/1.png"},
+			{"title": "Other comic", "totalElements": 10}
+		]`))
+	})
+	mux.HandleFunc("/covers/1.png", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write(coverData.Bytes())
+	})
+	return httptest.NewServer(mux)
+}
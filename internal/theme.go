@@ -0,0 +1,49 @@
+package wirwl
+
+import (
+	"fyne.io/fyne/theme"
+	"github.com/pkg/errors"
+	"os"
+	"wirwl/internal/log"
+	"wirwl/internal/themes"
+	"wirwl/internal/widget"
+)
+
+/*
+The theme set in the config is applied when the application starts and can be switched to the next one while it runs,
+in which case the config gets updated so the application starts with the same theme next time.
+The custom theme is skipped when switching if its definition file doesn't exist.
+*/
+
+const themeLoadError = "THEME_LOAD_ERROR"
+
+func (app *App) applyThemeFromConfig() {
+	err := app.applyTheme(app.config.Theme)
+	if err != nil {
+		log.Error(err)
+		app.loadingErrors[themeLoadError] = "Theme '" + app.config.Theme + "' could not be applied so the light theme is used instead. " + err.Error()
+		app.fyneApp.Settings().SetTheme(theme.LightTheme())
+	}
+}
+
+func (app *App) applyTheme(name string) error {
+	appTheme, err := themes.Named(name, app.config.CustomThemeFilePath())
+	if err != nil {
+		return errors.Wrap(err, "Failed to apply theme '"+name+"'")
+	}
+	app.fyneApp.Settings().SetTheme(appTheme)
+	app.config.Theme = name
+	return nil
+}
+
+func (app *App) switchToNextTheme() {
+	name := themes.Next(app.config.Theme)
+	if _, err := os.Stat(app.config.CustomThemeFilePath()); name == themes.Custom && os.IsNotExist(err) {
+		name = themes.Next(name)
+	}
+	err := app.applyTheme(name)
+	if err != nil {
+		log.Error(err)
+		app.msgDialog.Display(widget.ErrorPopUp, err.Error())
+	}
+}
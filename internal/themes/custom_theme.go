@@ -0,0 +1,180 @@
+package themes
+
+import (
+	"encoding/hex"
+	"fyne.io/fyne"
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"image/color"
+	"io/ioutil"
+	"strings"
+)
+
+/*
+Custom theme is based on one of the built-in themes and replaces some of its colors and sizes. It's defined in a TOML
+file, e.g.:
+
+	Base = "dark"
+	TextSize = 16
+	[Colors]
+	Background = "#1d2021"
+	Text = "#ebdbb2"
+	Focus = "#d7992180"
+
+Colors are named after the methods of fyne.Theme returning them, without the Color suffix, and given in format #RRGGBB
+or #RRGGBBAA. Colors and sizes that are not defined are taken from the base theme.
+*/
+type Definition struct {
+	//Either light or dark, light if it's empty
+	Base           string
+	Colors         map[string]string
+	TextSize       int
+	Padding        int
+	IconInlineSize int
+	ScrollBarSize  int
+}
+
+type customTheme struct {
+	fyne.Theme
+	colors     map[string]color.Color
+	definition Definition
+}
+
+var colorNames = []string{"Background", "Button", "DisabledButton", "Text", "DisabledText", "PlaceHolder", "Primary",
+	"Hover", "Focus", "ScrollBar", "Shadow"}
+
+func LoadCustomTheme(path string) (fyne.Theme, error) {
+	fileData, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the definition of the custom theme from "+path)
+	}
+	definition := Definition{}
+	_, err = toml.Decode(string(fileData), &definition)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the definition of the custom theme from "+path)
+	}
+	return NewCustomTheme(definition)
+}
+
+func NewCustomTheme(definition Definition) (fyne.Theme, error) {
+	if definition.Base == Custom {
+		return nil, errors.New("Custom theme has to be based on either the light or the dark theme")
+	}
+	base, err := Named(definition.Base, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "Base of the custom theme is not valid")
+	}
+	colors := map[string]color.Color{}
+	for name, value := range definition.Colors {
+		if !isColorName(name) {
+			return nil, errors.New("'" + name + "' is not a color of a theme, it has to be one of: " + strings.Join(colorNames, ", "))
+		}
+		colors[name], err = parseColor(value)
+		if err != nil {
+			return nil, errors.Wrap(err, "Color '"+name+"' of the custom theme is not valid")
+		}
+	}
+	for name, size := range map[string]int{"TextSize": definition.TextSize, "Padding": definition.Padding,
+		"IconInlineSize": definition.IconInlineSize, "ScrollBarSize": definition.ScrollBarSize} {
+		if size < 0 {
+			return nil, errors.New(name + " of the custom theme cannot be negative")
+		}
+	}
+	return &customTheme{Theme: base, colors: colors, definition: definition}, nil
+}
+
+func isColorName(name string) bool {
+	for _, colorName := range colorNames {
+		if colorName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func parseColor(value string) (color.Color, error) {
+	bytes, err := hex.DecodeString(strings.TrimPrefix(value, "#"))
+	if err != nil || !strings.HasPrefix(value, "#") || (len(bytes) != 3 && len(bytes) != 4) {
+		return nil, errors.New("'" + value + "' is not in format #RRGGBB or #RRGGBBAA")
+	}
+	parsedColor := color.NRGBA{R: bytes[0], G: bytes[1], B: bytes[2], A: 0xff}
+	if len(bytes) == 4 {
+		parsedColor.A = bytes[3]
+	}
+	return parsedColor, nil
+}
+
+//Base color is only asked for if there is no custom color, as some colors of the built-in themes need a running app
+func (theme *customTheme) color(name string, baseColor func() color.Color) color.Color {
+	if customColor, exists := theme.colors[name]; exists {
+		return customColor
+	}
+	return baseColor()
+}
+
+func size(customSize int, baseSize int) int {
+	if customSize > 0 {
+		return customSize
+	}
+	return baseSize
+}
+
+func (theme *customTheme) BackgroundColor() color.Color {
+	return theme.color("Background", theme.Theme.BackgroundColor)
+}
+
+func (theme *customTheme) ButtonColor() color.Color {
+	return theme.color("Button", theme.Theme.ButtonColor)
+}
+
+func (theme *customTheme) DisabledButtonColor() color.Color {
+	return theme.color("DisabledButton", theme.Theme.DisabledButtonColor)
+}
+
+func (theme *customTheme) TextColor() color.Color {
+	return theme.color("Text", theme.Theme.TextColor)
+}
+
+func (theme *customTheme) DisabledTextColor() color.Color {
+	return theme.color("DisabledText", theme.Theme.DisabledTextColor)
+}
+
+func (theme *customTheme) PlaceHolderColor() color.Color {
+	return theme.color("PlaceHolder", theme.Theme.PlaceHolderColor)
+}
+
+func (theme *customTheme) PrimaryColor() color.Color {
+	return theme.color("Primary", theme.Theme.PrimaryColor)
+}
+
+func (theme *customTheme) HoverColor() color.Color {
+	return theme.color("Hover", theme.Theme.HoverColor)
+}
+
+func (theme *customTheme) FocusColor() color.Color {
+	return theme.color("Focus", theme.Theme.FocusColor)
+}
+
+func (theme *customTheme) ScrollBarColor() color.Color {
+	return theme.color("ScrollBar", theme.Theme.ScrollBarColor)
+}
+
+func (theme *customTheme) ShadowColor() color.Color {
+	return theme.color("Shadow", theme.Theme.ShadowColor)
+}
+
+func (theme *customTheme) TextSize() int {
+	return size(theme.definition.TextSize, theme.Theme.TextSize())
+}
+
+func (theme *customTheme) Padding() int {
+	return size(theme.definition.Padding, theme.Theme.Padding())
+}
+
+func (theme *customTheme) IconInlineSize() int {
+	return size(theme.definition.IconInlineSize, theme.Theme.IconInlineSize())
+}
+
+func (theme *customTheme) ScrollBarSize() int {
+	return size(theme.definition.ScrollBarSize, theme.Theme.ScrollBarSize())
+}
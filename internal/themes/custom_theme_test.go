@@ -0,0 +1,46 @@
+package themes
+
+import (
+	"fyne.io/fyne/theme"
+	"github.com/stretchr/testify/assert"
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"wirwl/internal/log"
+)
+
+func TestThatCustomThemeReplacesColorsAndSizesOfItsBase(t *testing.T) {
+	dirPath, err := ioutil.TempDir("", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dirPath)
+	path := filepath.Join(dirPath, "theme.toml")
+	definition := "Base = \"dark\"\nTextSize = 16\n[Colors]\nBackground = \"#1d2021\"\nFocus = \"#d7992180\"\n"
+	err = ioutil.WriteFile(path, []byte(definition), 0600)
+	if err != nil {
+		log.Fatal(err)
+	}
+	customTheme, err := LoadCustomTheme(path)
+	assert.Nil(t, err)
+	assert.Equal(t, color.NRGBA{R: 0x1d, G: 0x20, B: 0x21, A: 0xff}, customTheme.BackgroundColor())
+	assert.Equal(t, color.NRGBA{R: 0xd7, G: 0x99, B: 0x21, A: 0x80}, customTheme.FocusColor())
+	assert.Equal(t, theme.DarkTheme().TextColor(), customTheme.TextColor())
+	assert.Equal(t, 16, customTheme.TextSize())
+	assert.Equal(t, theme.DarkTheme().Padding(), customTheme.Padding())
+}
+
+func TestThatInvalidCustomThemeIsRejected(t *testing.T) {
+	_, err := NewCustomTheme(Definition{Colors: map[string]string{"Border": "#000000"}})
+	assert.Contains(t, err.Error(), "'Border' is not a color of a theme")
+	_, err = NewCustomTheme(Definition{Colors: map[string]string{"Text": "black"}})
+	assert.EqualError(t, err, "Color 'Text' of the custom theme is not valid: 'black' is not in format #RRGGBB or #RRGGBBAA")
+	_, err = NewCustomTheme(Definition{Base: Custom})
+	assert.NotNil(t, err)
+	_, err = NewCustomTheme(Definition{Padding: -1})
+	assert.EqualError(t, err, "Padding of the custom theme cannot be negative")
+	_, err = LoadCustomTheme(filepath.Join(os.TempDir(), "nonexistent", "theme.toml"))
+	assert.Contains(t, err.Error(), "Failed to read the definition of the custom theme")
+}
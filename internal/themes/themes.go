@@ -0,0 +1,44 @@
+package themes
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+	"github.com/pkg/errors"
+)
+
+/*
+The application can use one of the built-in themes, light or dark, or a custom theme defined in a file, see Definition.
+*/
+
+const (
+	Light  = "light"
+	Dark   = "dark"
+	Custom = "custom"
+)
+
+//Themes are switched between in this order
+var names = []string{Light, Dark, Custom}
+
+//Empty name means the light theme. Custom theme is loaded from the definition file in the given path.
+func Named(name string, customThemeFilePath string) (fyne.Theme, error) {
+	switch name {
+	case "", Light:
+		return theme.LightTheme(), nil
+	case Dark:
+		return theme.DarkTheme(), nil
+	case Custom:
+		return LoadCustomTheme(customThemeFilePath)
+	}
+	return nil, errors.New("There is no theme named '" + name + "', it has to be one of: light, dark, custom")
+}
+
+//Returns the name of the theme that follows the theme with the given name. Unknown names, including the empty one,
+//are treated as the light theme.
+func Next(name string) string {
+	for i, themeName := range names {
+		if themeName == name {
+			return names[(i+1)%len(names)]
+		}
+	}
+	return Dark
+}
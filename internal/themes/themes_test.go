@@ -0,0 +1,25 @@
+package themes
+
+import (
+	"fyne.io/fyne/theme"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatBuiltInThemesAreReturnedByName(t *testing.T) {
+	lightTheme, err := Named("", "")
+	assert.Nil(t, err)
+	assert.Equal(t, theme.LightTheme().BackgroundColor(), lightTheme.BackgroundColor())
+	darkTheme, err := Named(Dark, "")
+	assert.Nil(t, err)
+	assert.Equal(t, theme.DarkTheme().BackgroundColor(), darkTheme.BackgroundColor())
+	_, err = Named("solarized", "")
+	assert.EqualError(t, err, "There is no theme named 'solarized', it has to be one of: light, dark, custom")
+}
+
+func TestThatThemesAreSwitchedBetweenInOrder(t *testing.T) {
+	assert.Equal(t, Dark, Next(""))
+	assert.Equal(t, Dark, Next(Light))
+	assert.Equal(t, Custom, Next(Dark))
+	assert.Equal(t, Light, Next(Custom))
+}
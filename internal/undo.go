@@ -0,0 +1,49 @@
+package wirwl
+
+import (
+	"wirwl/internal/data"
+)
+
+/*
+Changes made to entries can be undone and redone. What has been undone or redone is described in the status bar,
+as unlike errors it's not something that has to be acknowledged. Columns layouts and cover images are not a part of
+entries so they are kept when an entry type gets deleted, allowing the deletion to be undone. Those that are no longer
+used are removed when the application starts.
+*/
+
+func (app *App) undoLastChange() {
+	app.makeHistoryChange(app.entriesContainer.Undo, "Undone: ")
+}
+
+func (app *App) redoLastUndoneChange() {
+	app.makeHistoryChange(app.entriesContainer.Redo, "Redone: ")
+}
+
+//Tab of the entry type affected by the change gets selected so the result of the change can be seen
+func (app *App) makeHistoryChange(makeChange func() (data.HistoryChange, error), statusPrefix string) {
+	change, err := makeChange()
+	if err != nil {
+		app.statusLabel.SetText(err.Error())
+		return
+	}
+	affectedTabName := change.Made.TypeName
+	if change.Made.EntryType != nil {
+		affectedTabName = change.Made.EntryType.Name
+	}
+	if change.Made.TargetTypeName != "" {
+		affectedTabName = change.Made.TargetTypeName
+	}
+	if change.Made.Relation != nil {
+		affectedTabName = change.Made.Relation.TypeName
+	}
+	if change.Made.ListName != "" {
+		affectedTabName = listTabName(change.Made.ListName)
+	} else if change.Made.List != nil {
+		affectedTabName = listTabName(change.Made.List.Name)
+	}
+	if change.Made.SeriesName != "" || change.Made.Series != nil {
+		affectedTabName = seriesTabName
+	}
+	app.entriesTypesTabs.SelectTabWithName(affectedTabName)
+	app.statusLabel.SetText(statusPrefix + change.Description)
+}
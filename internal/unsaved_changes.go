@@ -0,0 +1,68 @@
+package wirwl
+
+import (
+	"wirwl/internal/log"
+	"wirwl/internal/widget"
+)
+
+/*
+Changes to entries are kept only in memory until they get saved, so the window's title shows whether there are any
+unsaved changes and closing the window with unsaved changes asks whether to save them, discard them or not close at all.
+*/
+
+const modifiedIndicator = " (modified)"
+const readOnlyIndicator = " (read-only)"
+const readOnlySavingWarning = "Changes cannot be saved as the collection has been opened read-only."
+
+func (app *App) updateWindowTitle() {
+	title := appName
+	if app.readOnly {
+		title += readOnlyIndicator
+	}
+	if app.entriesContainer.HasUnsavedChanges() {
+		title += modifiedIndicator
+	}
+	app.mainWindow.SetTitle(title)
+}
+
+//Changes made in a collection opened read-only can only be discarded, so closing the window asks whether to discard them
+func (app *App) createUnsavedChangesDialog() {
+	app.unsavedChangesDialog = app.newConfirmationDialog()
+	if app.readOnly {
+		app.unsavedChangesDialog.OnConfirm = app.mainWindow.Close
+		return
+	}
+	app.unsavedChangesDialog.OnConfirm = app.saveChangesAndClose
+	app.unsavedChangesDialog.OnCancel = app.discardChangesAndClose
+}
+
+func (app *App) onCloseRequested() {
+	if !app.entriesContainer.HasUnsavedChanges() {
+		app.mainWindow.Close()
+		return
+	}
+	if app.readOnly {
+		app.unsavedChangesDialog.Display(readOnlySavingWarning + " Do you want to close the application and discard them?")
+		return
+	}
+	app.unsavedChangesDialog.DisplayDismissible("There are unsaved changes. Do you want to save them before closing?")
+}
+
+//Discarded changes must not be restored from the journal the next time the application starts
+func (app *App) discardChangesAndClose() {
+	app.clearJournal()
+	app.mainWindow.Close()
+}
+
+func (app *App) saveChangesAndClose() {
+	err := app.entriesContainer.SaveData()
+	if err != nil {
+		log.Error(err)
+		//The confirmation dialog hides after its callback so the error can only be displayed once that happens
+		app.unsavedChangesDialog.SetOneTimeOnHideCallback(func() {
+			app.msgDialog.Display(widget.ErrorPopUp, "Changes could not be saved so the application has not been closed. "+err.Error())
+		})
+		return
+	}
+	app.mainWindow.Close()
+}
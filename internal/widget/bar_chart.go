@@ -0,0 +1,50 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+)
+
+/*
+A widget that displays values as a horizontal bar chart, one bar in a row with its label to the left of it and its value
+to the right of it. Length of bars is proportional to their values, the bar with the highest value takes the whole
+available length. Bars with values below or equal to 0 are not drawn, only their labels and values are.
+*/
+type BarChart struct {
+	widget.BaseWidget
+	bars []Bar
+}
+
+type Bar struct {
+	Label string
+	Value float64
+}
+
+func NewBarChart(bars ...Bar) *BarChart {
+	chart := &BarChart{bars: bars}
+	chart.ExtendBaseWidget(chart)
+	return chart
+}
+
+func (chart *BarChart) CreateRenderer() fyne.WidgetRenderer {
+	return newBarChartRenderer(chart)
+}
+
+func (chart *BarChart) Bars() []Bar {
+	return chart.bars
+}
+
+func (chart *BarChart) SetBars(bars ...Bar) {
+	chart.bars = bars
+	chart.Refresh()
+}
+
+func (chart *BarChart) maxValue() float64 {
+	maxValue := 0.0
+	for _, bar := range chart.bars {
+		if bar.Value > maxValue {
+			maxValue = bar.Value
+		}
+	}
+	return maxValue
+}
@@ -0,0 +1,114 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/theme"
+	"image/color"
+	"strconv"
+)
+
+const barMaxLength = 300
+const spaceAroundBar = 10
+
+/*
+A renderer for bar chart widget.
+Labels take as much width as the widest of them needs, so all bars start at the same position.
+Bars are drawn with theme's primary color, their height is the height of a text line.
+*/
+type barChartRenderer struct {
+	chart  *BarChart
+	labels []*canvas.Text
+	rects  []*canvas.Rectangle
+	values []*canvas.Text
+}
+
+func newBarChartRenderer(chart *BarChart) *barChartRenderer {
+	renderer := &barChartRenderer{chart: chart}
+	renderer.createObjects()
+	return renderer
+}
+
+func (renderer *barChartRenderer) createObjects() {
+	renderer.labels, renderer.rects, renderer.values = nil, nil, nil
+	for _, bar := range renderer.chart.bars {
+		renderer.labels = append(renderer.labels, canvas.NewText(bar.Label, theme.TextColor()))
+		renderer.rects = append(renderer.rects, canvas.NewRectangle(theme.PrimaryColor()))
+		renderer.values = append(renderer.values, canvas.NewText(formatBarValue(bar.Value), theme.TextColor()))
+	}
+}
+
+//Whole numbers are displayed without a fractional part, as most charts show amounts of something
+func formatBarValue(value float64) string {
+	if value == float64(int(value)) {
+		return strconv.Itoa(int(value))
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func (renderer *barChartRenderer) BackgroundColor() color.Color {
+	return color.Transparent
+}
+
+func (renderer *barChartRenderer) Destroy() {
+	//No resources to clear
+}
+
+func (renderer *barChartRenderer) Layout(size fyne.Size) {
+	labelsWidth := renderer.labelsWidth()
+	maxValue := renderer.chart.maxValue()
+	for i, bar := range renderer.chart.bars {
+		y := i * renderer.rowHeight()
+		renderer.labels[i].Move(fyne.NewPos(0, y))
+		barLength := 0
+		if maxValue > 0 && bar.Value > 0 {
+			barLength = int(bar.Value / maxValue * barMaxLength)
+		}
+		renderer.rects[i].Move(fyne.NewPos(labelsWidth+spaceAroundBar, y))
+		renderer.rects[i].Resize(fyne.NewSize(barLength, renderer.rowHeight()-theme.Padding()))
+		renderer.values[i].Move(fyne.NewPos(labelsWidth+2*spaceAroundBar+barLength, y))
+	}
+}
+
+func (renderer *barChartRenderer) labelsWidth() int {
+	width := 0
+	for _, label := range renderer.labels {
+		if label.MinSize().Width > width {
+			width = label.MinSize().Width
+		}
+	}
+	return width
+}
+
+func (renderer *barChartRenderer) valuesWidth() int {
+	width := 0
+	for _, value := range renderer.values {
+		if value.MinSize().Width > width {
+			width = value.MinSize().Width
+		}
+	}
+	return width
+}
+
+func (renderer *barChartRenderer) rowHeight() int {
+	return canvas.NewText("", color.Black).MinSize().Height + theme.Padding()
+}
+
+func (renderer *barChartRenderer) MinSize() fyne.Size {
+	width := renderer.labelsWidth() + 2*spaceAroundBar + barMaxLength + renderer.valuesWidth()
+	return fyne.NewSize(width, len(renderer.chart.bars)*renderer.rowHeight())
+}
+
+func (renderer *barChartRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{}
+	for i := range renderer.chart.bars {
+		objects = append(objects, renderer.labels[i], renderer.rects[i], renderer.values[i])
+	}
+	return objects
+}
+
+func (renderer *barChartRenderer) Refresh() {
+	renderer.createObjects()
+	renderer.Layout(renderer.chart.Size())
+	canvas.Refresh(renderer.chart)
+}
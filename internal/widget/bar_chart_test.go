@@ -0,0 +1,37 @@
+package widget
+
+import (
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatLengthOfBarsIsProportionalToTheirValues(t *testing.T) {
+	chart := NewBarChart(Bar{"first", 2}, Bar{"second", 4}, Bar{"third", 0})
+	renderer := test.WidgetRenderer(chart).(*barChartRenderer)
+	renderer.Layout(renderer.MinSize())
+	assert.Equal(t, barMaxLength/2, renderer.rects[0].Size().Width)
+	assert.Equal(t, barMaxLength, renderer.rects[1].Size().Width)
+	assert.Equal(t, 0, renderer.rects[2].Size().Width)
+	assert.Equal(t, renderer.rects[0].Position().X, renderer.rects[1].Position().X)
+	assert.Equal(t, "4", renderer.values[1].Text)
+	assert.Equal(t, 9, len(renderer.Objects()))
+}
+
+func TestThatBarsAreDisplayedInSeparateRows(t *testing.T) {
+	chart := NewBarChart(Bar{"first", 1.5}, Bar{"second", 1})
+	renderer := test.WidgetRenderer(chart).(*barChartRenderer)
+	renderer.Layout(renderer.MinSize())
+	assert.Equal(t, 0, renderer.labels[0].Position().Y)
+	assert.Equal(t, renderer.rowHeight(), renderer.labels[1].Position().Y)
+	assert.Equal(t, 2*renderer.rowHeight(), renderer.MinSize().Height)
+	assert.Equal(t, "1.50", renderer.values[0].Text)
+}
+
+func TestThatChangingBarsUpdatesTheChart(t *testing.T) {
+	chart := NewBarChart(Bar{"first", 1})
+	renderer := test.WidgetRenderer(chart).(*barChartRenderer)
+	chart.SetBars(Bar{"first", 1}, Bar{"second", 2})
+	assert.Equal(t, 2, len(chart.Bars()))
+	assert.Equal(t, "second", renderer.labels[1].Text)
+}
@@ -0,0 +1,68 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/layout"
+	"fyne.io/fyne/widget"
+)
+
+/*
+A dialog comparing two things side by side, e.g. fields of two entries, with the names of the compared values in
+the first column. Names of the values that differ are displayed in bold, so differences can be spotted at a glance.
+Like a details dialog, it hides when any key gets pressed.
+*/
+type ComparisonDialog struct {
+	*FocusableDialog
+	values *fyne.Container
+}
+
+type ComparedValues struct {
+	Name       string
+	Value      string
+	OtherValue string
+}
+
+func NewComparisonDialog(canvas fyne.Canvas) *ComparisonDialog {
+	values := fyne.NewContainerWithLayout(layout.NewGridLayout(3))
+	dialog := &ComparisonDialog{
+		FocusableDialog: newFocusableDialog(canvas, values),
+		values:          values,
+	}
+	dialog.ExtendBaseWidget(dialog)
+	return dialog
+}
+
+//Headers name the compared things and are displayed above their values
+func (dialog *ComparisonDialog) Display(title string, header string, otherHeader string, comparedValues ...ComparedValues) {
+	dialog.values.Objects = []fyne.CanvasObject{widget.NewLabel(""),
+		widget.NewLabelWithStyle(header, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(otherHeader, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})}
+	for _, compared := range comparedValues {
+		name := widget.NewLabelWithStyle(compared.Name, fyne.TextAlignTrailing, fyne.TextStyle{Bold: compared.Value != compared.OtherValue})
+		dialog.values.Objects = append(dialog.values.Objects, name, widget.NewLabel(compared.Value), widget.NewLabel(compared.OtherValue))
+	}
+	dialog.values.Refresh()
+	dialog.FocusableDialog.Display(title)
+	dialog.Canvas.Focus(dialog)
+}
+
+//Returns texts of all displayed labels row by row, starting with the headers
+func (dialog *ComparisonDialog) Texts() []string {
+	texts := []string{}
+	for _, object := range dialog.values.Objects {
+		texts = append(texts, object.(*widget.Label).Text)
+	}
+	return texts
+}
+
+//Returns names of the compared values that differ
+func (dialog *ComparisonDialog) Differences() []string {
+	differences := []string{}
+	for i := 3; i < len(dialog.values.Objects); i += 3 {
+		name := dialog.values.Objects[i].(*widget.Label)
+		if name.TextStyle.Bold {
+			differences = append(differences, name.Text)
+		}
+	}
+	return differences
+}
@@ -0,0 +1,21 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatComparisonDialogDisplaysValuesSideBySideAndHidesOnKeyPress(t *testing.T) {
+	dialog := NewComparisonDialog(test.Canvas())
+	dialog.Display("Duplicates", "first", "second", ComparedValues{"Title", "Dune", "Dune"}, ComparedValues{"Score", "5", "7"})
+	assert.Equal(t, "Duplicates", dialog.Title())
+	assert.Equal(t, dialog, dialog.Canvas.Focused())
+	assert.Equal(t, []string{"", "first", "second", "Title", "Dune", "Dune", "Score", "5", "7"}, dialog.Texts())
+	assert.Equal(t, []string{"Score"}, dialog.Differences())
+	SimulateKeyPress(dialog, fyne.KeyEscape)
+	assert.True(t, dialog.Hidden)
+	dialog.Display("", "a", "b", ComparedValues{"Link", "x", "y"})
+	assert.Equal(t, []string{"", "a", "b", "Link", "x", "y"}, dialog.Texts())
+}
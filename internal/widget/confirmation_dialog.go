@@ -1,6 +1,9 @@
 package widget
 
-import "fyne.io/fyne"
+import (
+	"fyne.io/fyne"
+	"sync"
+)
 
 /*
 Implementation of a typical yes/no dialog except that confirmation and cancellation are done by using 'y' and 'n' keys
@@ -8,12 +11,16 @@ respectively, therefore no buttons are shown.
 Dialog disappears only when any of those two buttons is pressed.
 Message passed into has an instruction message '(y)es or (n)o' appended at the end so caller doesn't have to add it
 every time.
+When displayed as dismissible, the dialog can also be hidden with 'c' or escape keys, in which case neither of the callbacks
+is called, e.g. to give up on closing the application instead of choosing whether to save changes or not.
 */
 type ConfirmationDialog struct {
 	*MsgDialog
-	OnConfirm func()
-	OnCancel  func()
-	focused   bool
+	OnConfirm   func()
+	OnCancel    func()
+	focused     bool
+	dismissible bool
+	locker      sync.Locker
 }
 
 func NewConfirmationDialog(canvas fyne.Canvas) *ConfirmationDialog {
@@ -27,16 +34,39 @@ func NewConfirmationDialog(canvas fyne.Canvas) *ConfirmationDialog {
 
 func (dialog *ConfirmationDialog) TypedKey(key *fyne.KeyEvent) {
 	if key.Name == fyne.KeyY {
-		dialog.OnConfirm()
+		dialog.executeLocked(dialog.OnConfirm)
 		dialog.MsgDialog.TypedKey(key)
 	} else if key.Name == fyne.KeyN {
-		dialog.OnCancel()
+		dialog.executeLocked(dialog.OnCancel)
+		dialog.MsgDialog.TypedKey(key)
+	} else if dialog.dismissible && (key.Name == fyne.KeyC || key.Name == fyne.KeyEscape) {
 		dialog.MsgDialog.TypedKey(key)
 	}
 }
 
+func (dialog *ConfirmationDialog) executeLocked(callback func()) {
+	if dialog.locker != nil {
+		dialog.locker.Lock()
+		defer dialog.locker.Unlock()
+	}
+	callback()
+}
+
+//Callbacks are executed while holding the locker, like functions bound to actions, see input.Handler.SetLocker
+func (dialog *ConfirmationDialog) SetLocker(locker sync.Locker) {
+	dialog.locker = locker
+}
+
 func (dialog *ConfirmationDialog) Display(msg string) {
+	dialog.dismissible = false
 	msg += " (y)es or (n)o?"
 	dialog.MsgDialog.Display(InfoPopUp, msg)
 	dialog.Canvas.Focus(dialog)
 }
+
+func (dialog *ConfirmationDialog) DisplayDismissible(msg string) {
+	dialog.dismissible = true
+	msg += " (y)es, (n)o or (c)ancel?"
+	dialog.MsgDialog.Display(InfoPopUp, msg)
+	dialog.Canvas.Focus(dialog)
+}
@@ -45,3 +45,50 @@ func TestThatItIsNotPossibleToExitDialogWithOtherButtonThanYOrN(t *testing.T) {
 	assert.Equal(t, true, dialog.Visible())
 	assert.Equal(t, true, dialog.Focused())
 }
+
+func TestThatDismissibleDialogCanBeHiddenWithoutCallingCallbacks(t *testing.T) {
+	for _, key := range []fyne.KeyName{fyne.KeyC, fyne.KeyEscape} {
+		called := false
+		dialog := NewConfirmationDialog(test.Canvas())
+		dialog.OnConfirm = func() { called = true }
+		dialog.OnCancel = func() { called = true }
+		dialog.DisplayDismissible("Some message.")
+		assert.Equal(t, "Some message. (y)es, (n)o or (c)ancel?", dialog.Msg())
+		SimulateKeyPress(dialog, key)
+		assert.False(t, called)
+		assert.True(t, dialog.Hidden)
+		assert.False(t, dialog.Focused())
+	}
+}
+
+func TestThatDialogIsNotDismissibleAfterBeingDisplayedNormallyAgain(t *testing.T) {
+	dialog := NewConfirmationDialog(test.Canvas())
+	dialog.DisplayDismissible("message")
+	SimulateKeyPress(dialog, fyne.KeyC)
+	dialog.Display("message")
+	SimulateKeyPress(dialog, fyne.KeyC)
+	assert.True(t, dialog.Visible())
+}
+
+type testLocker struct {
+	locked bool
+}
+
+func (locker *testLocker) Lock()   { locker.locked = true }
+func (locker *testLocker) Unlock() { locker.locked = false }
+
+func TestThatLockerIsHeldWhileCallbacksExecute(t *testing.T) {
+	locker := &testLocker{}
+	dialog := NewConfirmationDialog(test.Canvas())
+	dialog.SetLocker(locker)
+	lockedDuringConfirmation, lockedDuringCancellation := false, false
+	dialog.OnConfirm = func() { lockedDuringConfirmation = locker.locked }
+	dialog.OnCancel = func() { lockedDuringCancellation = locker.locked }
+	dialog.Display("")
+	SimulateKeyPress(dialog, fyne.KeyY)
+	dialog.Display("")
+	SimulateKeyPress(dialog, fyne.KeyN)
+	assert.True(t, lockedDuringConfirmation)
+	assert.True(t, lockedDuringCancellation)
+	assert.False(t, locker.locked)
+}
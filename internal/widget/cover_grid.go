@@ -0,0 +1,130 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+	"wirwl/internal/input"
+)
+
+/*
+A widget that displays items as a grid of cover images with titles below them.
+Amount of items in a row depends on the width the grid has been given, items that don't fit go to the next row.
+When focused, one of the items is the current item which can be changed using move up/down/left/right actions.
+*/
+type CoverGrid struct {
+	widget.BaseWidget
+	inputHandler   input.Handler
+	items          []CoverGridItem
+	titleLabels    []*widget.Label
+	canvas         fyne.Canvas
+	focused        bool
+	onExit         func()
+	currentItemNum int
+	itemsPerRow    int
+	minItemsPerRow int
+}
+
+type CoverGridItem struct {
+	Cover fyne.CanvasObject
+	Title string
+}
+
+func NewCoverGrid(canvas fyne.Canvas, inputHandler input.Handler, items []CoverGridItem) *CoverGrid {
+	grid := &CoverGrid{
+		inputHandler:   inputHandler,
+		items:          items,
+		titleLabels:    createTitleLabels(items),
+		canvas:         canvas,
+		onExit:         func() {},
+		itemsPerRow:    1,
+		minItemsPerRow: 1,
+	}
+	grid.ExtendBaseWidget(grid)
+	grid.inputHandler.BindFunctionToAction(grid, input.ExitTableAction, func() { grid.onExit() })
+	grid.inputHandler.BindFunctionToAction(grid, input.MoveDownAction, func() { grid.SelectItem(grid.currentItemNum + grid.itemsPerRow) })
+	grid.inputHandler.BindFunctionToAction(grid, input.MoveUpAction, func() { grid.SelectItem(grid.currentItemNum - grid.itemsPerRow) })
+	grid.inputHandler.BindFunctionToAction(grid, input.MoveRightAction, func() { grid.SelectItem(grid.currentItemNum + 1) })
+	grid.inputHandler.BindFunctionToAction(grid, input.MoveLeftAction, func() { grid.SelectItem(grid.currentItemNum - 1) })
+	return grid
+}
+
+func createTitleLabels(items []CoverGridItem) []*widget.Label {
+	labels := []*widget.Label{}
+	for _, item := range items {
+		label := widget.NewLabel(item.Title)
+		label.Alignment = fyne.TextAlignCenter
+		label.Wrapping = fyne.TextTruncate
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+func (grid *CoverGrid) CreateRenderer() fyne.WidgetRenderer {
+	return newCoverGridRenderer(grid)
+}
+
+func (grid *CoverGrid) FocusGained() {
+	grid.focused = true
+}
+
+func (grid *CoverGrid) FocusLost() {
+	grid.focused = false
+}
+
+func (grid *CoverGrid) Focused() bool {
+	return grid.focused
+}
+
+func (grid *CoverGrid) TypedRune(rune) {
+	//Cover grid will not support any sort of typing therefore no implementation is needed
+}
+
+func (grid *CoverGrid) TypedKey(event *fyne.KeyEvent) {
+	grid.inputHandler.HandleInNormalMode(grid, event.Name)
+}
+
+func (grid *CoverGrid) EnterInputMode() {
+	grid.canvas.Focus(grid)
+	grid.Refresh()
+}
+
+func (grid *CoverGrid) ExitInputMode() {
+	grid.canvas.Unfocus()
+	grid.Refresh()
+}
+
+func (grid *CoverGrid) SetOnExitCallbackFunction(function func()) {
+	grid.onExit = function
+}
+
+func (grid *CoverGrid) CurrentItemNum() int {
+	return grid.currentItemNum
+}
+
+func (grid *CoverGrid) Item(itemNum int) CoverGridItem {
+	return grid.items[itemNum]
+}
+
+func (grid *CoverGrid) ItemAmount() int {
+	return len(grid.items)
+}
+
+//Amount of items displayed in a single row, it changes whenever grid's width changes
+func (grid *CoverGrid) ItemsPerRow() int {
+	return grid.itemsPerRow
+}
+
+//Makes the grid wide enough to display the given amount of items in a row, or all of them if there are fewer.
+//It's useful when the grid is displayed in a container that has the same size as its content, e.g. a dialog.
+func (grid *CoverGrid) SetMinItemsPerRow(amount int) {
+	grid.minItemsPerRow = amount
+	grid.Refresh()
+}
+
+//Changes current item to the given one. Nothing happens if such item doesn't exist.
+func (grid *CoverGrid) SelectItem(itemNum int) {
+	if itemNum >= 0 && itemNum < len(grid.items) {
+		grid.currentItemNum = itemNum
+		grid.Refresh()
+	}
+}
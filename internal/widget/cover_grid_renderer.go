@@ -0,0 +1,116 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/theme"
+	"image/color"
+)
+
+//Covers have the same size as images in image columns of a table
+const coverWidth = imageColumnWidth
+const coverHeight = rowHeight
+const coverTitleHeight = 40
+const spaceBetweenCovers = 20
+
+/*
+A renderer for cover grid widget.
+Every item takes the same amount of space, cover is drawn at the top of it and title is drawn below the cover.
+Current item is marked with a border of theme's focus color.
+*/
+type coverGridRenderer struct {
+	grid              *CoverGrid
+	currentItemBorder *canvas.Rectangle
+}
+
+func newCoverGridRenderer(grid *CoverGrid) *coverGridRenderer {
+	return &coverGridRenderer{
+		grid:              grid,
+		currentItemBorder: canvas.NewRectangle(color.Transparent),
+	}
+}
+
+func (renderer *coverGridRenderer) BackgroundColor() color.Color {
+	return theme.BackgroundColor()
+}
+
+func (renderer *coverGridRenderer) Destroy() {
+	//No resources to clear
+}
+
+func (renderer *coverGridRenderer) Layout(size fyne.Size) {
+	renderer.grid.itemsPerRow = itemsPerRowForWidth(size.Width)
+	for i, item := range renderer.grid.items {
+		position := renderer.itemPosition(i)
+		if image, isImage := item.Cover.(*canvas.Image); isImage {
+			image.FillMode = canvas.ImageFillContain
+		}
+		item.Cover.Move(position.Add(fyne.NewPos(spaceBetweenCovers/2, spaceBetweenCovers/2)))
+		item.Cover.Resize(fyne.NewSize(coverWidth, coverHeight))
+		titleLabel := renderer.grid.titleLabels[i]
+		titleLabel.Move(position.Add(fyne.NewPos(0, spaceBetweenCovers/2+coverHeight)))
+		titleLabel.Resize(fyne.NewSize(itemWidth(), coverTitleHeight))
+	}
+	renderer.renderCurrentItemBorder()
+}
+
+//There is always at least one item in a row, even if it doesn't fit
+func itemsPerRowForWidth(width int) int {
+	itemsPerRow := width / itemWidth()
+	if itemsPerRow < 1 {
+		return 1
+	}
+	return itemsPerRow
+}
+
+func itemWidth() int {
+	return coverWidth + spaceBetweenCovers
+}
+
+func itemHeight() int {
+	return coverHeight + coverTitleHeight + spaceBetweenCovers
+}
+
+func (renderer *coverGridRenderer) itemPosition(itemNum int) fyne.Position {
+	rowNum := itemNum / renderer.grid.itemsPerRow
+	columnNum := itemNum % renderer.grid.itemsPerRow
+	return fyne.NewPos(columnNum*itemWidth(), rowNum*itemHeight())
+}
+
+func (renderer *coverGridRenderer) renderCurrentItemBorder() {
+	if renderer.grid.focused && len(renderer.grid.items) > 0 {
+		renderer.currentItemBorder.Show()
+	} else {
+		renderer.currentItemBorder.Hide()
+	}
+	renderer.currentItemBorder.StrokeWidth = 3
+	renderer.currentItemBorder.FillColor = color.Transparent
+	renderer.currentItemBorder.StrokeColor = theme.FocusColor()
+	renderer.currentItemBorder.Move(renderer.itemPosition(renderer.grid.currentItemNum))
+	renderer.currentItemBorder.Resize(fyne.NewSize(itemWidth(), itemHeight()))
+}
+
+func (renderer *coverGridRenderer) MinSize() fyne.Size {
+	rowAmount := (len(renderer.grid.items) + renderer.grid.itemsPerRow - 1) / renderer.grid.itemsPerRow
+	minItemsPerRow := renderer.grid.minItemsPerRow
+	if minItemsPerRow > len(renderer.grid.items) {
+		minItemsPerRow = len(renderer.grid.items)
+	}
+	if minItemsPerRow < 1 {
+		minItemsPerRow = 1
+	}
+	return fyne.NewSize(minItemsPerRow*itemWidth(), rowAmount*itemHeight())
+}
+
+func (renderer *coverGridRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{}
+	for i, item := range renderer.grid.items {
+		objects = append(objects, item.Cover, renderer.grid.titleLabels[i])
+	}
+	objects = append(objects, renderer.currentItemBorder)
+	return objects
+}
+
+func (renderer *coverGridRenderer) Refresh() {
+	renderer.Layout(renderer.grid.Size())
+}
@@ -0,0 +1,112 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"image/color"
+	"strconv"
+	"testing"
+)
+
+func createCoverGridItemsForTesting(amount int) []CoverGridItem {
+	items := []CoverGridItem{}
+	for i := 0; i < amount; i++ {
+		items = append(items, CoverGridItem{Cover: canvas.NewImageFromImage(nil), Title: "Title " + strconv.Itoa(i)})
+	}
+	return items
+}
+
+func createCoverGridForTesting(canvas fyne.Canvas, itemAmount int, width int) *CoverGrid {
+	grid := NewCoverGrid(canvas, getInputHandlerForTesting(), createCoverGridItemsForTesting(itemAmount))
+	grid.Resize(fyne.NewSize(width, 0))
+	test.WidgetRenderer(grid).Layout(grid.Size())
+	return grid
+}
+
+func TestThatAmountOfItemsPerRowDependsOnWidth(t *testing.T) {
+	grid := createCoverGridForTesting(test.Canvas(), 10, 3*itemWidth()+10)
+	assert.Equal(t, 3, grid.ItemsPerRow())
+	grid = createCoverGridForTesting(test.Canvas(), 10, 10)
+	assert.Equal(t, 1, grid.ItemsPerRow())
+}
+
+func TestThatCoversAndTitlesHaveCorrectPositions(t *testing.T) {
+	grid := createCoverGridForTesting(test.Canvas(), 5, 2*itemWidth())
+	for i, item := range grid.items {
+		expectedX := (i%2)*itemWidth() + spaceBetweenCovers/2
+		expectedY := (i/2)*itemHeight() + spaceBetweenCovers/2
+		assert.Equal(t, fyne.NewPos(expectedX, expectedY), item.Cover.Position(), "Position of cover num "+strconv.Itoa(i)+" is incorrect")
+		assert.Equal(t, fyne.NewSize(coverWidth, coverHeight), item.Cover.Size())
+		assert.Equal(t, expectedY+coverHeight, grid.titleLabels[i].Position().Y, "Position of title num "+strconv.Itoa(i)+" is incorrect")
+		assert.Equal(t, "Title "+strconv.Itoa(i), grid.titleLabels[i].Text)
+	}
+	assert.Equal(t, fyne.NewSize(itemWidth(), 3*itemHeight()), grid.MinSize())
+}
+
+func TestThatCoverImagesKeepTheirAspectRatio(t *testing.T) {
+	grid := createCoverGridForTesting(test.Canvas(), 2, 500)
+	for _, item := range grid.items {
+		assert.Equal(t, canvas.ImageFillContain, item.Cover.(*canvas.Image).FillMode)
+	}
+}
+
+func TestThatCurrentItemCanBeChangedUsingMovementKeys(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	grid := createCoverGridForTesting(testWindow.Canvas(), 7, 3*itemWidth())
+	testWindow.Canvas().SetContent(grid)
+	grid.Resize(fyne.NewSize(3*itemWidth(), grid.MinSize().Height))
+	grid.EnterInputMode()
+	assert.Equal(t, grid, testWindow.Canvas().Focused())
+	SimulateKeyPress(grid, fyne.KeyJ)
+	assert.Equal(t, 3, grid.CurrentItemNum())
+	SimulateKeyPress(grid, fyne.KeyL)
+	assert.Equal(t, 4, grid.CurrentItemNum())
+	SimulateKeyPress(grid, fyne.KeyK)
+	assert.Equal(t, 1, grid.CurrentItemNum())
+	SimulateKeyPress(grid, fyne.KeyH)
+	assert.Equal(t, 0, grid.CurrentItemNum())
+}
+
+func TestThatCurrentItemDoesNotChangeWhenTryingToMoveOutsideOfTheGrid(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	grid := createCoverGridForTesting(testWindow.Canvas(), 5, 3*itemWidth())
+	testWindow.Canvas().SetContent(grid)
+	grid.Resize(fyne.NewSize(3*itemWidth(), grid.MinSize().Height))
+	grid.EnterInputMode()
+	SimulateKeyPress(grid, fyne.KeyK)
+	SimulateKeyPress(grid, fyne.KeyH)
+	assert.Equal(t, 0, grid.CurrentItemNum())
+	grid.SelectItem(4)
+	SimulateKeyPress(grid, fyne.KeyJ)
+	SimulateKeyPress(grid, fyne.KeyL)
+	assert.Equal(t, 4, grid.CurrentItemNum())
+	grid.SelectItem(2)
+	SimulateKeyPress(grid, fyne.KeyJ)
+	assert.Equal(t, 2, grid.CurrentItemNum())
+}
+
+func TestThatCoverGridCallsOnExitCallbackFunctionAndLosesFocusAfterExitingInputMode(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	grid := createCoverGridForTesting(testWindow.Canvas(), 2, 500)
+	testWindow.Canvas().SetContent(grid)
+	grid.SetOnExitCallbackFunction(grid.ExitInputMode)
+	grid.EnterInputMode()
+	SimulateKeyPress(grid, fyne.KeySpace)
+	assert.NotEqual(t, grid, testWindow.Canvas().Focused())
+}
+
+func TestThatCurrentItemIsMarkedOnlyWhenGridIsFocused(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	grid := createCoverGridForTesting(testWindow.Canvas(), 4, 2*itemWidth())
+	testWindow.Canvas().SetContent(grid)
+	renderer := test.WidgetRenderer(grid).(*coverGridRenderer)
+	assert.False(t, renderer.currentItemBorder.Visible())
+	grid.EnterInputMode()
+	grid.Resize(fyne.NewSize(2*itemWidth(), grid.MinSize().Height))
+	grid.SelectItem(3)
+	assert.True(t, renderer.currentItemBorder.Visible())
+	assert.Equal(t, fyne.NewPos(itemWidth(), itemHeight()), renderer.currentItemBorder.Position())
+	assert.Equal(t, color.Transparent, renderer.currentItemBorder.FillColor)
+}
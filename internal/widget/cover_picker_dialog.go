@@ -0,0 +1,78 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+	"wirwl/internal/input"
+)
+
+/*
+A dialog that displays covers in a grid and lets a user pick one of them.
+Current cover is changed using move up/down/left/right actions, confirm action picks it and cancel action closes the dialog.
+*/
+type CoverPickerDialog struct {
+	*FocusableDialog
+	inputHandler  input.Handler
+	gridContainer *widget.Box
+	grid          *CoverGrid
+	OnPick        func(itemNum int)
+}
+
+const coversInPickerRow = 4
+
+func NewCoverPickerDialog(canvas fyne.Canvas, inputHandler input.Handler) *CoverPickerDialog {
+	gridContainer := widget.NewVBox()
+	dialog := &CoverPickerDialog{
+		FocusableDialog: newFocusableDialog(canvas, gridContainer),
+		inputHandler:    inputHandler,
+		gridContainer:   gridContainer,
+		grid:            NewCoverGrid(canvas, inputHandler, []CoverGridItem{}),
+		OnPick:          func(int) {},
+	}
+	dialog.ExtendBaseWidget(dialog)
+	dialog.inputHandler.BindFunctionToAction(dialog, input.MoveDownAction, func() { dialog.grid.SelectItem(dialog.grid.CurrentItemNum() + dialog.grid.ItemsPerRow()) })
+	dialog.inputHandler.BindFunctionToAction(dialog, input.MoveUpAction, func() { dialog.grid.SelectItem(dialog.grid.CurrentItemNum() - dialog.grid.ItemsPerRow()) })
+	dialog.inputHandler.BindFunctionToAction(dialog, input.MoveRightAction, func() { dialog.grid.SelectItem(dialog.grid.CurrentItemNum() + 1) })
+	dialog.inputHandler.BindFunctionToAction(dialog, input.MoveLeftAction, func() { dialog.grid.SelectItem(dialog.grid.CurrentItemNum() - 1) })
+	dialog.inputHandler.BindFunctionToAction(dialog, input.ConfirmAction, dialog.pickCurrentCover)
+	dialog.inputHandler.BindFunctionToAction(dialog, input.CancelAction, dialog.close)
+	return dialog
+}
+
+//Displays given covers, first one of them becomes the current one
+func (dialog *CoverPickerDialog) Display(title string, items []CoverGridItem) {
+	dialog.grid = NewCoverGrid(dialog.Canvas, dialog.inputHandler, items)
+	dialog.grid.SetMinItemsPerRow(coversInPickerRow)
+	//Grid is only used to display covers, it never gets focused so its current item has to be marked as if it was
+	dialog.grid.FocusGained()
+	dialog.gridContainer.Children = []fyne.CanvasObject{dialog.grid}
+	dialog.gridContainer.Refresh()
+	dialog.FocusableDialog.Display(title)
+	dialog.Canvas.Focus(dialog)
+	dialog.Resize(dialog.MinSize())
+}
+
+func (dialog *CoverPickerDialog) TypedKey(key *fyne.KeyEvent) {
+	dialog.inputHandler.HandleInNormalMode(dialog, key.Name)
+}
+
+func (dialog *CoverPickerDialog) pickCurrentCover() {
+	itemNum := dialog.grid.CurrentItemNum()
+	dialog.close()
+	if itemNum < dialog.grid.ItemAmount() {
+		dialog.OnPick(itemNum)
+	}
+}
+
+func (dialog *CoverPickerDialog) close() {
+	dialog.Canvas.Unfocus()
+	dialog.Hide()
+}
+
+func (dialog *CoverPickerDialog) CurrentItemNum() int {
+	return dialog.grid.CurrentItemNum()
+}
+
+func (dialog *CoverPickerDialog) ItemAmount() int {
+	return dialog.grid.ItemAmount()
+}
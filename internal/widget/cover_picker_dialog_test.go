@@ -0,0 +1,44 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatCoverPickerDialogDisplaysGivenCovers(t *testing.T) {
+	dialog := NewCoverPickerDialog(test.Canvas(), getInputHandlerForTesting())
+	dialog.Display("Pick a cover", createCoverGridItemsForTesting(6))
+	assert.True(t, dialog.Visible())
+	assert.Equal(t, dialog, dialog.Canvas.Focused())
+	assert.Equal(t, "Pick a cover", dialog.Title())
+	assert.Equal(t, 6, dialog.ItemAmount())
+	assert.Equal(t, 0, dialog.CurrentItemNum())
+	assert.Equal(t, coversInPickerRow*itemWidth(), dialog.grid.MinSize().Width)
+}
+
+func TestThatCoverCanBePickedAfterChangingCurrentCover(t *testing.T) {
+	pickedItemNum := -1
+	dialog := NewCoverPickerDialog(test.Canvas(), getInputHandlerForTesting())
+	dialog.OnPick = func(itemNum int) { pickedItemNum = itemNum }
+	dialog.Display("", createCoverGridItemsForTesting(6))
+	SimulateKeyPress(dialog, fyne.KeyL)
+	SimulateKeyPress(dialog, fyne.KeyL)
+	SimulateKeyPress(dialog, fyne.KeyH)
+	assert.Equal(t, 1, dialog.CurrentItemNum())
+	SimulateKeyPress(dialog, fyne.KeyReturn)
+	assert.Equal(t, 1, pickedItemNum)
+	assert.True(t, dialog.Hidden)
+	assert.False(t, dialog.Focused())
+}
+
+func TestThatCancellingCoverPickerDialogDoesNotPickAnything(t *testing.T) {
+	picked := false
+	dialog := NewCoverPickerDialog(test.Canvas(), getInputHandlerForTesting())
+	dialog.OnPick = func(int) { picked = true }
+	dialog.Display("", createCoverGridItemsForTesting(2))
+	SimulateKeyPress(dialog, fyne.KeyEscape)
+	assert.False(t, picked)
+	assert.True(t, dialog.Hidden)
+}
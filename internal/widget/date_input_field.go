@@ -0,0 +1,23 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"unicode"
+	"wirwl/internal/input"
+)
+
+//Input field allowing to type in only characters that dates consist of, that is digits and slashes
+type DateInputField struct {
+	*InputField
+}
+
+func NewDateInputField(canvas fyne.Canvas, inputHandler input.Handler) *DateInputField {
+	inputField := &DateInputField{
+		InputField: newInputField(canvas, inputHandler),
+	}
+	inputField.ExtendBaseWidget(inputField)
+	inputField.SetRuneFilteringFunction(func(r rune) bool {
+		return unicode.IsDigit(r) || r == '/'
+	})
+	return inputField
+}
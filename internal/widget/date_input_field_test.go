@@ -0,0 +1,14 @@
+package widget
+
+import (
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatItIsOnlyPossibleToInputDigitsAndSlashes(t *testing.T) {
+	inputField := NewDateInputField(test.Canvas(), getInputHandlerForTesting())
+	inputField.canvas.Focus(inputField)
+	TypeIntoFocusable(inputField, "a3b1/-12c/.2020 ")
+	assert.Equal(t, "31/12/2020", inputField.Text)
+}
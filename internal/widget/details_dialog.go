@@ -0,0 +1,67 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/layout"
+	"fyne.io/fyne/widget"
+)
+
+/*
+A dialog displaying details of something as a list of names and their values, e.g. fields of an entry.
+Like a message dialog, it hides when any key gets pressed.
+*/
+type DetailsDialog struct {
+	*FocusableDialog
+	details *fyne.Container
+}
+
+//Detail with an empty name is displayed as a header of the details that follow it
+type Detail struct {
+	Name  string
+	Value string
+}
+
+func NewDetailsDialog(canvas fyne.Canvas) *DetailsDialog {
+	details := fyne.NewContainerWithLayout(layout.NewFormLayout())
+	dialog := &DetailsDialog{
+		FocusableDialog: newFocusableDialog(canvas, details),
+		details:         details,
+	}
+	dialog.ExtendBaseWidget(dialog)
+	return dialog
+}
+
+func (dialog *DetailsDialog) Display(title string, details ...Detail) {
+	dialog.details.Objects = []fyne.CanvasObject{}
+	for _, detail := range details {
+		name := widget.NewLabelWithStyle(detail.Name, fyne.TextAlignTrailing, fyne.TextStyle{Bold: true})
+		if detail.Name == "" {
+			name = widget.NewLabelWithStyle(detail.Value, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			dialog.details.Objects = append(dialog.details.Objects, name, widget.NewLabel(""))
+			continue
+		}
+		dialog.details.Objects = append(dialog.details.Objects, name, widget.NewLabel(detail.Value))
+	}
+	dialog.details.Refresh()
+	dialog.FocusableDialog.Display(title)
+	dialog.Canvas.Focus(dialog)
+}
+
+//Returns the displayed value of the first detail with given name
+func (dialog *DetailsDialog) Value(name string) (string, bool) {
+	for i := 0; i+1 < len(dialog.details.Objects); i += 2 {
+		if dialog.details.Objects[i].(*widget.Label).Text == name {
+			return dialog.details.Objects[i+1].(*widget.Label).Text, true
+		}
+	}
+	return "", false
+}
+
+//Returns texts of all displayed labels in the order they are displayed, names followed by their values
+func (dialog *DetailsDialog) Texts() []string {
+	texts := []string{}
+	for _, object := range dialog.details.Objects {
+		texts = append(texts, object.(*widget.Label).Text)
+	}
+	return texts
+}
@@ -0,0 +1,31 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatDetailsDialogDisplaysDetailsAndHidesOnKeyPress(t *testing.T) {
+	dialog := NewDetailsDialog(test.Canvas())
+	dialog.Display("Entry", Detail{"Title", "some title"}, Detail{"", "History"}, Detail{"date", "event"})
+	assert.Equal(t, "Entry", dialog.Title())
+	assert.Equal(t, dialog, dialog.Canvas.Focused())
+	value, exists := dialog.Value("Title")
+	assert.True(t, exists)
+	assert.Equal(t, "some title", value)
+	_, exists = dialog.Value("Score")
+	assert.False(t, exists)
+	assert.Equal(t, []string{"Title", "some title", "History", "", "date", "event"}, dialog.Texts())
+	SimulateKeyPress(dialog, fyne.KeyEscape)
+	assert.True(t, dialog.Hidden)
+}
+
+func TestThatDetailsDialogDisplaysOnlyTheMostRecentDetails(t *testing.T) {
+	dialog := NewDetailsDialog(test.Canvas())
+	dialog.Display("", Detail{"Title", "first"})
+	dialog.Hide()
+	dialog.Display("", Detail{"Score", "5"})
+	assert.Equal(t, []string{"Score", "5"}, dialog.Texts())
+}
@@ -19,22 +19,26 @@ type InputField struct {
 	firstRuneIgnored     bool
 }
 
+//Colors are taken from the current theme every time, so they change when the theme does
 type backgroundRenderer struct {
 	fyne.WidgetRenderer
-	color color.Color
+	highlighted bool
 }
 
 func (renderer *backgroundRenderer) BackgroundColor() color.Color {
-	return renderer.color
+	if renderer.highlighted {
+		return theme.FocusColor()
+	}
+	return theme.BackgroundColor()
 }
 
-func (renderer *backgroundRenderer) SetColor(color color.Color) {
-	renderer.color = color
+func (renderer *backgroundRenderer) SetHighlighted(highlighted bool) {
+	renderer.highlighted = highlighted
 }
 
 func (inputField *InputField) CreateRenderer() fyne.WidgetRenderer {
 	renderer := inputField.Entry.CreateRenderer()
-	bgRenderer := &backgroundRenderer{renderer, theme.BackgroundColor()}
+	bgRenderer := &backgroundRenderer{renderer, inputField.bgRenderer.highlighted}
 	inputField.bgRenderer = bgRenderer
 	return bgRenderer
 }
@@ -112,17 +116,17 @@ func (inputField *InputField) FocusGained() {
 	inputField.Entry.FocusGained()
 }
 
-func (inputField *InputField) setBgColor(color color.Color) {
-	inputField.bgRenderer.SetColor(color)
+func (inputField *InputField) setHighlighted(highlighted bool) {
+	inputField.bgRenderer.SetHighlighted(highlighted)
 	inputField.Refresh()
 }
 
 func (inputField *InputField) Highlight() {
-	inputField.setBgColor(theme.FocusColor())
+	inputField.setHighlighted(true)
 }
 
 func (inputField *InputField) Unhighlight() {
-	inputField.setBgColor(theme.BackgroundColor())
+	inputField.setHighlighted(false)
 }
 
 func (inputField *InputField) EnterInputMode() {
@@ -59,6 +59,17 @@ func TestHighlightingAndUnhiglighting(t *testing.T) {
 	assert.Equal(t, inputField.bgRenderer.BackgroundColor(), theme.BackgroundColor())
 }
 
+func TestThatBackgroundColorFollowsThemeChange(t *testing.T) {
+	inputField := NewInputField(test.Canvas(), getInputHandlerForTesting())
+	app := test.NewApp()
+	app.NewWindow("").SetContent(inputField)
+	app.Settings().SetTheme(theme.DarkTheme())
+	assert.Equal(t, theme.DarkTheme().BackgroundColor(), inputField.bgRenderer.BackgroundColor())
+	inputField.Highlight()
+	app.Settings().SetTheme(theme.LightTheme())
+	assert.Equal(t, theme.LightTheme().FocusColor(), inputField.bgRenderer.BackgroundColor())
+}
+
 func TestThatWhenExitingInputModeWithTwoKeyCombinationNeitherKeyOfCombinationGetsLeftInFieldsText(t *testing.T) {
 	keymap := make(map[input.Action]input.KeyCombination)
 	keymap[input.ExitInputModeAction] = input.TwoKeyCombination(fyne.KeyJ, fyne.KeyJ)
@@ -8,6 +8,7 @@ import (
 
 /*
 A pop up menu allowing a user to choose one of the choices presented vertically using up and down actions.
+Cancel action hides the menu without choosing anything.
 */
 type PopUpMenu struct {
 	fyneWidget.PopUp
@@ -16,6 +17,7 @@ type PopUpMenu struct {
 	choices                  []*fyneWidget.Label
 	currentChoiceNum         int
 	OnChoiceSelectedCallback func(string)
+	OnCancelCallback         func()
 }
 
 func NewPopUpMenu(canvas fyne.Canvas, handler input.Handler, choicesNames ...string) *PopUpMenu {
@@ -31,15 +33,26 @@ func NewPopUpMenu(canvas fyne.Canvas, handler input.Handler, choicesNames ...str
 		choices:                  choices,
 		currentChoiceNum:         0,
 		OnChoiceSelectedCallback: func(s string) {},
+		OnCancelCallback:         func() {},
 	}
 	menu.ExtendBaseWidget(menu)
 	menu.inputHandler.BindFunctionToAction(menu, input.MoveDownAction, func() { menu.selectNextChoice() })
 	menu.inputHandler.BindFunctionToAction(menu, input.MoveUpAction, func() { menu.selectPreviousChoice() })
 	menu.inputHandler.BindFunctionToAction(menu, input.ConfirmAction, func() { menu.onChoiceSelected() })
+	menu.inputHandler.BindFunctionToAction(menu, input.CancelAction, func() { menu.onCancel() })
 	menu.currentChoice().TextStyle = fyne.TextStyle{Bold: true}
 	return menu
 }
 
+//Replaces the choices with new ones, making the first of them the current one
+func (menu *PopUpMenu) SetChoices(choicesNames ...string) {
+	menu.choices = generateChoicesFromNames(choicesNames...)
+	menu.currentChoiceNum = 0
+	menu.Content = fyneWidget.NewVBox(choicesAsCanvasObjects(menu.choices)...)
+	menu.currentChoice().TextStyle = fyne.TextStyle{Bold: true}
+	menu.Refresh()
+}
+
 func generateChoicesFromNames(choicesNames ...string) []*fyneWidget.Label {
 	choices := []*fyneWidget.Label{}
 	for _, itemName := range choicesNames {
@@ -60,6 +73,10 @@ func (menu *PopUpMenu) currentChoice() *fyneWidget.Label {
 	return menu.choices[menu.currentChoiceNum]
 }
 
+func (menu *PopUpMenu) CurrentChoiceNum() int {
+	return menu.currentChoiceNum
+}
+
 func (menu *PopUpMenu) selectNextChoice() {
 	menu.selectChoice(menu.currentChoiceNum + 1)
 }
@@ -68,6 +85,16 @@ func (menu *PopUpMenu) selectPreviousChoice() {
 	menu.selectChoice(menu.currentChoiceNum - 1)
 }
 
+//Makes the first choice with given text the current one. Nothing happens if there is no such choice.
+func (menu *PopUpMenu) SelectChoiceWithText(text string) {
+	for i, choice := range menu.choices {
+		if choice.Text == text {
+			menu.selectChoice(i)
+			return
+		}
+	}
+}
+
 func (menu *PopUpMenu) selectChoice(num int) {
 	if num >= 0 && num < len(menu.choices) {
 		menu.unselectCurrentChoice()
@@ -121,3 +148,9 @@ func (menu *PopUpMenu) onChoiceSelected() {
 	menu.Hide()
 	menu.OnChoiceSelectedCallback(menu.currentChoice().Text)
 }
+
+func (menu *PopUpMenu) onCancel() {
+	menu.Canvas.Unfocus()
+	menu.Hide()
+	menu.OnCancelCallback()
+}
@@ -74,3 +74,49 @@ func TestThatWhenChoiceGetsSelectedMenuReturnsProperValue(t *testing.T) {
 	SimulateKeyPress(menu, fyne.KeyReturn)
 	assert.Equal(t, "2", returnedValue)
 }
+
+func TestThatPopUpMenuHidesAndCallsCancelCallbackOnCancel(t *testing.T) {
+	functionCalled := false
+	choiceSelected := false
+	menu := NewPopUpMenu(test.Canvas(), getInputHandlerForTesting(), "1", "2")
+	menu.OnCancelCallback = func() { functionCalled = true }
+	menu.OnChoiceSelectedCallback = func(s string) { choiceSelected = true }
+	menu.Show()
+	SimulateKeyPress(menu, fyne.KeyEscape)
+	assert.True(t, functionCalled)
+	assert.False(t, choiceSelected)
+	assert.False(t, menu.Visible())
+	assert.NotEqual(t, menu, test.Canvas().Focused())
+}
+
+func TestThatChoiceCanBeSelectedUsingItsText(t *testing.T) {
+	menu := NewPopUpMenu(test.Canvas(), getInputHandlerForTesting(), "1", "2", "3")
+	menu.SelectChoiceWithText("3")
+	assert.Equal(t, menu.choices[2], menu.currentChoice())
+	assert.True(t, menu.choices[2].TextStyle.Bold)
+	assert.False(t, menu.choices[0].TextStyle.Bold)
+	menu.SelectChoiceWithText("non existing choice")
+	assert.Equal(t, menu.choices[2], menu.currentChoice())
+}
+
+func TestThatNumberOfCurrentChoiceCanBeReadWhenChoicesHaveTheSameText(t *testing.T) {
+	selectedChoiceNum := -1
+	menu := NewPopUpMenu(test.Canvas(), getInputHandlerForTesting(), "same", "same", "same")
+	menu.OnChoiceSelectedCallback = func(string) { selectedChoiceNum = menu.CurrentChoiceNum() }
+	menu.Show()
+	assert.Equal(t, 0, menu.CurrentChoiceNum())
+	SimulateKeyPress(menu, fyne.KeyJ)
+	SimulateKeyPress(menu, fyne.KeyJ)
+	SimulateKeyPress(menu, fyne.KeyReturn)
+	assert.Equal(t, 2, selectedChoiceNum)
+}
+
+func TestThatChoicesOfPopUpMenuCanBeReplaced(t *testing.T) {
+	menu := NewPopUpMenu(test.Canvas(), getInputHandlerForTesting(), "1", "2", "3")
+	menu.Show()
+	SimulateKeyPress(menu, fyne.KeyJ)
+	menu.SetChoices("a", "b")
+	assert.Equal(t, 2, len(menu.choices))
+	assert.Equal(t, "a", menu.currentChoice().Text)
+	assert.True(t, menu.currentChoice().TextStyle.Bold)
+}
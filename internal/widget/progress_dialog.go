@@ -0,0 +1,50 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/widget"
+)
+
+/*
+A dialog displaying progress of a task running in the background.
+Unlike other dialogs it doesn't hide when a key gets pressed as it should only be hidden once the task is finished.
+*/
+type ProgressDialog struct {
+	*FocusableDialog
+	progressBar *widget.ProgressBar
+}
+
+func NewProgressDialog(canvas fyne.Canvas) *ProgressDialog {
+	progressBar := widget.NewProgressBar()
+	dialog := &ProgressDialog{
+		FocusableDialog: newFocusableDialog(canvas, progressBar),
+		progressBar:     progressBar,
+	}
+	dialog.ExtendBaseWidget(dialog)
+	return dialog
+}
+
+//Progress is reset every time the dialog gets displayed
+func (dialog *ProgressDialog) Display(title string) {
+	dialog.progressBar.SetValue(0)
+	dialog.FocusableDialog.Display(title)
+	dialog.Canvas.Focus(dialog)
+}
+
+func (dialog *ProgressDialog) TypedKey(key *fyne.KeyEvent) {
+	//Key presses are ignored as the dialog is hidden by the task it displays progress of
+}
+
+//Progress should be a value between 0 and 1
+func (dialog *ProgressDialog) SetProgress(progress float64) {
+	dialog.progressBar.SetValue(progress)
+}
+
+func (dialog *ProgressDialog) Progress() float64 {
+	return dialog.progressBar.Value
+}
+
+func (dialog *ProgressDialog) Close() {
+	dialog.Canvas.Unfocus()
+	dialog.Hide()
+}
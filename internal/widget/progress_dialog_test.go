@@ -0,0 +1,31 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatProgressDialogDisplaysProgressAndDoesNotHideOnKeyPress(t *testing.T) {
+	dialog := NewProgressDialog(test.Canvas())
+	dialog.Display("Downloading")
+	assert.Equal(t, "Downloading", dialog.Title())
+	assert.Equal(t, dialog, dialog.Canvas.Focused())
+	dialog.SetProgress(0.5)
+	assert.Equal(t, 0.5, dialog.Progress())
+	SimulateKeyPress(dialog, fyne.KeyEscape)
+	assert.True(t, dialog.Visible())
+	dialog.Close()
+	assert.True(t, dialog.Hidden)
+	assert.False(t, dialog.Focused())
+}
+
+func TestThatProgressIsResetWhenProgressDialogIsDisplayedAgain(t *testing.T) {
+	dialog := NewProgressDialog(test.Canvas())
+	dialog.Display("")
+	dialog.SetProgress(1)
+	dialog.Close()
+	dialog.Display("")
+	assert.Equal(t, 0.0, dialog.Progress())
+}
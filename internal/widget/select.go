@@ -18,22 +18,26 @@ type Select struct {
 	onExitInputMode    func()
 }
 
+//Colors are taken from the current theme every time, so they change when the theme does
 type selectBackgroundRenderer struct {
 	fyne.WidgetRenderer
-	color color.Color
+	highlighted bool
 }
 
 func (renderer *selectBackgroundRenderer) BackgroundColor() color.Color {
-	return renderer.color
+	if renderer.highlighted {
+		return theme.FocusColor()
+	}
+	return theme.BackgroundColor()
 }
 
-func (renderer *selectBackgroundRenderer) SetColor(color color.Color) {
-	renderer.color = color
+func (renderer *selectBackgroundRenderer) SetHighlighted(highlighted bool) {
+	renderer.highlighted = highlighted
 }
 
 func (selectWidget *Select) CreateRenderer() fyne.WidgetRenderer {
 	renderer := selectWidget.Select.CreateRenderer()
-	bgRenderer := &selectBackgroundRenderer{renderer, theme.BackgroundColor()}
+	bgRenderer := &selectBackgroundRenderer{renderer, selectWidget.backgroundRenderer.highlighted}
 	selectWidget.backgroundRenderer = bgRenderer
 	return bgRenderer
 }
@@ -53,6 +57,9 @@ func NewSelect(canvas fyne.Canvas, handler input.Handler, choices ...string) *Se
 		selectWidget.SetSelected(s)
 		selectWidget.onExitInputMode()
 	}
+	menu.OnCancelCallback = func() {
+		selectWidget.onExitInputMode()
+	}
 	selectWidget.ExtendBaseWidget(selectWidget)
 	selectWidget.inputHandler.BindFunctionToAction(selectWidget, input.ExitInputModeAction, func() {
 		selectWidget.canvas.Unfocus()
@@ -61,7 +68,14 @@ func NewSelect(canvas fyne.Canvas, handler input.Handler, choices ...string) *Se
 	return selectWidget
 }
 
+//Replaces the choices that can be selected, keeping the selected value
+func (selectWidget *Select) SetChoices(choices ...string) {
+	selectWidget.Options = choices
+	selectWidget.menu.SetChoices(choices...)
+}
+
 func (selectWidget *Select) EnterInputMode() {
+	selectWidget.menu.SelectChoiceWithText(selectWidget.Selected)
 	selectWidget.menu.ShowAtPosition(fyne.CurrentApp().Driver().AbsolutePositionForObject(selectWidget))
 	selectWidget.menu.Resize(fyne.NewSize(selectWidget.Size().Width, selectWidget.menu.MinSize().Height))
 }
@@ -95,12 +109,12 @@ func (selectWidget *Select) SetOnExitInputModeFunction(function func()) {
 }
 
 func (selectWidget *Select) Highlight() {
-	selectWidget.backgroundRenderer.SetColor(theme.FocusColor())
+	selectWidget.backgroundRenderer.SetHighlighted(true)
 	selectWidget.Refresh()
 }
 
 func (selectWidget *Select) Unhighlight() {
-	selectWidget.backgroundRenderer.SetColor(theme.BackgroundColor())
+	selectWidget.backgroundRenderer.SetHighlighted(false)
 	selectWidget.Refresh()
 }
 
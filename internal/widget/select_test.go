@@ -77,3 +77,34 @@ func TestThatDisplayedMenuHasCorrectSize(t *testing.T) {
 	assert.Equal(t, selectWidget.Size().Width, selectWidget.menu.Size().Width)
 	assert.Equal(t, selectWidget.menu.MinSize().Height, selectWidget.menu.Size().Height)
 }
+
+func TestThatMenuStartsAtCurrentlySelectedChoiceWhenEnteringInputMode(t *testing.T) {
+	selectWidget := NewSelect(test.Canvas(), getInputHandlerForTesting(), "1", "2", "3")
+	selectWidget.SetText("2")
+	selectWidget.EnterInputMode()
+	assert.Equal(t, "2", selectWidget.menu.currentChoice().Text)
+}
+
+func TestThatCancellingMenuKeepsSelectedValueAndCallsOnExitInputModeFunction(t *testing.T) {
+	functionCalled := false
+	selectWidget := NewSelect(test.Canvas(), getInputHandlerForTesting(), "1", "2")
+	selectWidget.SetText("1")
+	selectWidget.SetOnExitInputModeFunction(func() { functionCalled = true })
+	selectWidget.EnterInputMode()
+	SimulateKeyPress(selectWidget.menu, fyne.KeyJ)
+	SimulateKeyPress(selectWidget.menu, fyne.KeyEscape)
+	assert.Equal(t, "1", selectWidget.GetText())
+	assert.True(t, functionCalled)
+}
+
+func TestThatChoicesOfSelectCanBeReplaced(t *testing.T) {
+	selectWidget := NewSelect(test.Canvas(), getInputHandlerForTesting(), "1", "2")
+	selectWidget.SetChoices("a", "b", "c")
+	selectWidget.SetText("b")
+	selectWidget.EnterInputMode()
+	assert.Equal(t, []string{"a", "b", "c"}, selectWidget.Options)
+	assert.Equal(t, 3, len(selectWidget.menu.choices))
+	SimulateKeyPress(selectWidget.menu, fyne.KeyJ)
+	SimulateKeyPress(selectWidget.menu, fyne.KeyReturn)
+	assert.Equal(t, "c", selectWidget.GetText())
+}
@@ -0,0 +1,85 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/layout"
+	"fyne.io/fyne/widget"
+)
+
+//Sections that don't fit into this size can be scrolled to
+const statsDialogWidth = 900
+const statsDialogHeight = 600
+
+/*
+A dialog displaying statistics divided into titled sections, each containing a table of names and values,
+a bar chart or both. Sections are displayed in two columns.
+Like a message dialog, it hides when any key gets pressed.
+*/
+type StatsDialog struct {
+	*FocusableDialog
+	sectionsContainer *fyne.Container
+	sections          []StatsSection
+}
+
+type StatsSection struct {
+	Title string
+	Rows  []Detail
+	Bars  []Bar
+}
+
+func NewStatsDialog(canvas fyne.Canvas) *StatsDialog {
+	sectionsContainer := fyne.NewContainerWithLayout(layout.NewGridLayout(2))
+	scrollContainer := widget.NewScrollContainer(sectionsContainer)
+	scrollContainer.SetMinSize(fyne.NewSize(statsDialogWidth, statsDialogHeight))
+	dialog := &StatsDialog{
+		FocusableDialog:   newFocusableDialog(canvas, scrollContainer),
+		sectionsContainer: sectionsContainer,
+	}
+	dialog.ExtendBaseWidget(dialog)
+	return dialog
+}
+
+func (dialog *StatsDialog) Display(title string, sections ...StatsSection) {
+	dialog.sections = sections
+	dialog.sectionsContainer.Objects = []fyne.CanvasObject{}
+	for _, section := range sections {
+		dialog.sectionsContainer.Objects = append(dialog.sectionsContainer.Objects, createStatsSectionContent(section))
+	}
+	dialog.sectionsContainer.Refresh()
+	dialog.FocusableDialog.Display(title)
+	dialog.Canvas.Focus(dialog)
+}
+
+func createStatsSectionContent(section StatsSection) fyne.CanvasObject {
+	content := widget.NewVBox(widget.NewLabelWithStyle(section.Title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	if len(section.Rows) > 0 {
+		rows := fyne.NewContainerWithLayout(layout.NewFormLayout())
+		for _, row := range section.Rows {
+			rows.AddObject(widget.NewLabel(row.Name))
+			rows.AddObject(widget.NewLabel(row.Value))
+		}
+		content.Append(rows)
+	}
+	if len(section.Bars) > 0 {
+		content.Append(NewBarChart(section.Bars...))
+	}
+	return content
+}
+
+//Returns the displayed section with given title
+func (dialog *StatsDialog) Section(title string) (StatsSection, bool) {
+	for _, section := range dialog.sections {
+		if section.Title == title {
+			return section, true
+		}
+	}
+	return StatsSection{}, false
+}
+
+func (dialog *StatsDialog) SectionsTitles() []string {
+	titles := []string{}
+	for _, section := range dialog.sections {
+		titles = append(titles, section.Title)
+	}
+	return titles
+}
@@ -0,0 +1,35 @@
+package widget
+
+import (
+	"fyne.io/fyne"
+	"fyne.io/fyne/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestThatStatsDialogDisplaysSectionsAndHidesOnKeyPress(t *testing.T) {
+	dialog := NewStatsDialog(test.Canvas())
+	dialog.Display("Statistics",
+		StatsSection{Title: "Summary", Rows: []Detail{{"Entries", "5"}}},
+		StatsSection{Title: "Per type", Bars: []Bar{{"books", 3}, {"games", 2}}})
+	assert.Equal(t, "Statistics", dialog.Title())
+	assert.Equal(t, dialog, dialog.Canvas.Focused())
+	assert.Equal(t, []string{"Summary", "Per type"}, dialog.SectionsTitles())
+	section, exists := dialog.Section("Per type")
+	assert.True(t, exists)
+	assert.Equal(t, []Bar{{"books", 3}, {"games", 2}}, section.Bars)
+	_, exists = dialog.Section("Tags")
+	assert.False(t, exists)
+	assert.Equal(t, 2, len(dialog.sectionsContainer.Objects))
+	SimulateKeyPress(dialog, fyne.KeyEscape)
+	assert.True(t, dialog.Hidden)
+}
+
+func TestThatStatsDialogDisplaysOnlyTheMostRecentSections(t *testing.T) {
+	dialog := NewStatsDialog(test.Canvas())
+	dialog.Display("", StatsSection{Title: "first"}, StatsSection{Title: "second"})
+	dialog.Hide()
+	dialog.Display("", StatsSection{Title: "third"})
+	assert.Equal(t, []string{"third"}, dialog.SectionsTitles())
+	assert.Equal(t, 1, len(dialog.sectionsContainer.Objects))
+}
@@ -9,6 +9,7 @@ import (
 /*
 Tab container in which every tab contains a list of CanvasObjects. Tabs are displayed alphabetically.
 It allows to switch tab to next/previous which is done cyclically, setting next tab when on last tab goes to the first and vice versa.
+Tabs can be added, renamed, removed and have their content replaced without affecting which tab is selected.
 The way selected items display graphically should be controlled by onElementSelected and onElementUnselected functions e.g. labels becoming bold on selection.
 */
 type TabContainer struct {
@@ -33,9 +34,13 @@ func newTabContainer(
 	tabsData map[string][]fyne.CanvasObject,
 	onElementSelected func(element *fyne.CanvasObject),
 	onElementUnselected func(element *fyne.CanvasObject)) *TabContainer {
+	tabsContent := make(map[string][]fyne.CanvasObject, len(tabsData))
+	for tabName, elements := range tabsData {
+		tabsContent[tabName] = elements
+	}
 	container := &TabContainer{
 		selectedElementIndex: 0,
-		tabsContent:          tabsData,
+		tabsContent:          tabsContent,
 		onElementSelected:    onElementSelected,
 		onElementUnselected:  onElementUnselected,
 	}
@@ -112,6 +117,70 @@ func (container *TabContainer) setTabTo(index int) {
 	container.selectElement(0)
 }
 
+//Nothing happens if there is no tab with given name
+func (container *TabContainer) SelectTabWithName(name string) {
+	for i, tab := range container.Items() {
+		if tab.Text == name && i != container.CurrentTabIndex() {
+			container.setTabTo(i)
+		}
+	}
+}
+
 func (container *TabContainer) Items() []*fyneWidget.TabItem {
 	return container.TabContainer.Items
 }
+
+//Replaces content of the tab with given name or adds a new tab if there is no such tab
+func (container *TabContainer) SetTab(name string, elements ...fyne.CanvasObject) {
+	selectedTabName := container.currentTabName()
+	container.tabsContent[name] = elements
+	container.rebuildTabs(selectedTabName, container.CurrentTabIndex())
+}
+
+//If the removed tab was selected, the tab that takes its place gets selected
+func (container *TabContainer) RemoveTab(name string) {
+	selectedTabName := container.currentTabName()
+	delete(container.tabsContent, name)
+	container.rebuildTabs(selectedTabName, container.CurrentTabIndex())
+}
+
+//Renamed tab is moved so the tabs stay in alphabetical order and stays selected if it was selected
+func (container *TabContainer) RenameTab(oldName string, newName string) {
+	selectedTabName := container.currentTabName()
+	if selectedTabName == oldName {
+		selectedTabName = newName
+	}
+	elements := container.tabsContent[oldName]
+	delete(container.tabsContent, oldName)
+	container.tabsContent[newName] = elements
+	container.rebuildTabs(selectedTabName, container.CurrentTabIndex())
+}
+
+func (container *TabContainer) currentTabName() string {
+	currentTab := container.CurrentTab()
+	if currentTab != nil {
+		return currentTab.Text
+	}
+	return ""
+}
+
+//Selects the tab with given name or, if it no longer exists, the tab with given index or the last tab
+func (container *TabContainer) rebuildTabs(tabNameToSelect string, fallbackTabIndex int) {
+	//Items are refreshed only after the tab to select is known, as fyne's tab container can't be refreshed while its
+	//selected tab index is out of range of its items
+	container.TabContainer.Items = getTabsFromData(container.tabsContent)
+	tabIndexToSelect := fallbackTabIndex
+	if tabIndexToSelect >= len(container.Items()) {
+		tabIndexToSelect = len(container.Items()) - 1
+	}
+	for i, tab := range container.Items() {
+		if tab.Text == tabNameToSelect {
+			tabIndexToSelect = i
+		}
+	}
+	container.SelectTabIndex(tabIndexToSelect)
+	container.Refresh()
+	if !container.currentTabHasElements() || container.selectedElementIndex >= len(container.tabsContent[container.currentTabName()]) {
+		container.selectedElementIndex = 0
+	}
+}
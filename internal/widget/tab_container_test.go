@@ -101,3 +101,43 @@ func TestThatThereIsNoPanicWhenCheckingIfTabHasElementsOnContainerWithoutElement
 		func(element *fyne.CanvasObject) {})
 	container.currentTabHasElements()
 }
+
+func TestThatAddedTabIsPlacedAlphabeticallyWithoutChangingSelectedTab(t *testing.T) {
+	container := createTabContainerForTesting()
+	container.SelectNextTab()
+	container.SetTab("Fourth tab", widget.NewLabel("a4"))
+	assert.Equal(t, 4, len(container.Items()))
+	assert.Equal(t, "Fourth tab", container.Items()[1].Text)
+	assert.Equal(t, "Second tab", container.CurrentTab().Text)
+	assert.Equal(t, 3, len(tabsData))
+}
+
+func TestThatReplacingContentOfTabKeepsItSelected(t *testing.T) {
+	container := createTabContainerForTesting()
+	container.SelectNextTab()
+	container.SetTab("Second tab", widget.NewLabel("new"))
+	assert.Equal(t, "Second tab", container.CurrentTab().Text)
+	assert.NotNil(t, GetLabelFromContent(container.CurrentTab().Content, "new"))
+	assert.Nil(t, GetLabelFromContent(container.CurrentTab().Content, "a2"))
+}
+
+func TestThatRenamedTabStaysSelectedAndTabsStayInAlphabeticalOrder(t *testing.T) {
+	container := createTabContainerForTesting()
+	container.RenameTab("First tab", "Zeroth tab")
+	assert.Equal(t, "Zeroth tab", container.CurrentTab().Text)
+	assert.Equal(t, 2, container.CurrentTabIndex())
+	assert.NotNil(t, GetLabelFromContent(container.CurrentTab().Content, "a1"))
+}
+
+func TestThatTabTakingPlaceOfRemovedSelectedTabGetsSelected(t *testing.T) {
+	container := createTabContainerForTesting()
+	container.SelectNextTab()
+	container.RemoveTab("Second tab")
+	assert.Equal(t, 2, len(container.Items()))
+	assert.Equal(t, "Third tab", container.CurrentTab().Text)
+	container.RemoveTab("Third tab")
+	assert.Equal(t, "First tab", container.CurrentTab().Text)
+	container.SetTab("Second tab")
+	container.RemoveTab("Second tab")
+	assert.Equal(t, "First tab", container.CurrentTab().Text)
+}
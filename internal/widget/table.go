@@ -9,21 +9,34 @@ import (
 /*
 A widget that consists of data displayed like in a table.
 It consists of a header with labels displaying the column names and rows below containing the actual data.
+When focused, one of the cells is the current cell which can be changed using move up/down/left/right actions.
+Content of the current cell can be edited in place by displaying an editor widget over it. The table itself doesn't know
+how cell's data is edited so it only calls a function set for editing when enter input mode action happens and it's up
+to that function to provide an editor by calling StartEditingCurrentCell and to stop editing once it's done.
 */
 type Table struct {
 	widget.BaseWidget
-	inputHandler input.Handler
-	columnData   []TableColumn
-	columnLabels []fyne.CanvasObject
-	rowData      []TableRow
-	canvas       fyne.Canvas
-	focused      bool
-	onExit       func()
-}
-
+	inputHandler     input.Handler
+	columnData       []TableColumn
+	columnLabels     []fyne.CanvasObject
+	rowData          []TableRow
+	canvas           fyne.Canvas
+	focused          bool
+	onExit           func()
+	currentRowNum    int
+	currentColumnNum int
+	editor           FormDialogEmbeddableWidget
+	editingError     string
+	onEditCell       func(rowNum int, columnNum int)
+}
+
+//Width of 0 means that the column will be as wide as it's header label, or as wide as an image in case of image columns,
+//otherwise the column always has the given width.
+//Cells in image columns should contain canvas.Image which is scaled to fit the cell while keeping its aspect ratio.
 type TableColumn struct {
-	Type ColumnType
-	Name string
+	Type  ColumnType
+	Name  string
+	Width int
 }
 
 type ColumnType string
@@ -43,9 +56,16 @@ func NewTable(canvas fyne.Canvas, inputHandler input.Handler, columnData []Table
 		rowData:      rowData,
 		canvas:       canvas,
 		focused:      false,
+		onExit:       func() {},
+		onEditCell:   func(rowNum int, columnNum int) {},
 	}
 	table.ExtendBaseWidget(table)
 	table.inputHandler.BindFunctionToAction(table, input.ExitTableAction, func() { table.onExit() })
+	table.inputHandler.BindFunctionToAction(table, input.MoveDownAction, func() { table.SelectCell(table.currentRowNum+1, table.currentColumnNum) })
+	table.inputHandler.BindFunctionToAction(table, input.MoveUpAction, func() { table.SelectCell(table.currentRowNum-1, table.currentColumnNum) })
+	table.inputHandler.BindFunctionToAction(table, input.MoveRightAction, func() { table.SelectCell(table.currentRowNum, table.currentColumnNum+1) })
+	table.inputHandler.BindFunctionToAction(table, input.MoveLeftAction, func() { table.SelectCell(table.currentRowNum, table.currentColumnNum-1) })
+	table.inputHandler.BindFunctionToAction(table, input.EnterInputModeAction, func() { table.editCurrentCell() })
 	return table
 }
 
@@ -69,6 +89,17 @@ func (table *Table) columnAmount() int {
 	return len(table.columnData)
 }
 
+func (table *Table) columnWidth(columnNum int) int {
+	width := table.columnData[columnNum].Width
+	if width > 0 {
+		return width
+	}
+	if table.columnData[columnNum].Type == ImageColumn {
+		return imageColumnWidth
+	}
+	return table.columnLabels[columnNum].MinSize().Width
+}
+
 func (table *Table) FocusGained() {
 	table.focused = true
 }
@@ -107,3 +138,72 @@ func (table *Table) AddRow(row TableRow) {
 func (table *Table) SetOnExitCallbackFunction(function func()) {
 	table.onExit = function
 }
+
+func (table *Table) SetOnEditCellCallbackFunction(function func(rowNum int, columnNum int)) {
+	table.onEditCell = function
+}
+
+func (table *Table) CurrentRowNum() int {
+	return table.currentRowNum
+}
+
+func (table *Table) CurrentColumnNum() int {
+	return table.currentColumnNum
+}
+
+func (table *Table) Cell(rowNum int, columnNum int) fyne.CanvasObject {
+	return table.rowData[rowNum][columnNum]
+}
+
+func (table *Table) RowAmount() int {
+	return len(table.rowData)
+}
+
+//Changes current cell to the given one. Nothing happens if such cell doesn't exist.
+func (table *Table) SelectCell(rowNum int, columnNum int) {
+	if rowNum >= 0 && rowNum < len(table.rowData) && columnNum >= 0 && columnNum < table.columnAmount() {
+		table.currentRowNum = rowNum
+		table.currentColumnNum = columnNum
+		table.Refresh()
+	}
+}
+
+func (table *Table) editCurrentCell() {
+	if len(table.rowData) > 0 && table.editor == nil {
+		table.onEditCell(table.currentRowNum, table.currentColumnNum)
+	}
+}
+
+//Displays the editor over the current cell and makes it enter input mode
+func (table *Table) StartEditingCurrentCell(editor FormDialogEmbeddableWidget) {
+	table.editor = editor
+	table.editingError = ""
+	table.Refresh()
+	editor.EnterInputMode()
+}
+
+//Removes the editor and gives focus back to the table
+func (table *Table) StopEditing() {
+	table.editor = nil
+	table.editingError = ""
+	table.Refresh()
+	table.canvas.Focus(table)
+}
+
+func (table *Table) IsEditing() bool {
+	return table.editor != nil
+}
+
+func (table *Table) Editor() FormDialogEmbeddableWidget {
+	return table.editor
+}
+
+//Displays an error below the current cell until editing stops or another error is set
+func (table *Table) SetEditingError(msg string) {
+	table.editingError = msg
+	table.Refresh()
+}
+
+func (table *Table) EditingError() string {
+	return table.editingError
+}
@@ -11,37 +11,44 @@ import (
 const headerHeight = 50
 const rowHeight = 141
 const widthBetweenColumns = 35
+const imageColumnWidth = 100
+
+var editingErrorColor = color.NRGBA{R: 0xd3, G: 0x2f, B: 0x2f, A: 0xff}
 
 /*
 A renderer for table widget.
 Header labels, data cells content and borders are all rendered separately.
 Borders are created by drawing rectangles horizontally for every row and vertically for every column.
+Borders have the theme's text color, so they are visible on the theme's background.
+Current cell is marked with a border of theme's focus color and an editor, if present, is drawn over it.
 */
 type tableRenderer struct {
-	table           *Table
-	headerRowBorder *canvas.Rectangle
-	dataRowsBorders []*canvas.Rectangle
-	columnBorders   []*canvas.Rectangle
-	focusedBorder   *canvas.Rectangle
-	borderColor     color.Color
+	table             *Table
+	headerRowBorder   *canvas.Rectangle
+	dataRowsBorders   []*canvas.Rectangle
+	columnBorders     []*canvas.Rectangle
+	focusedBorder     *canvas.Rectangle
+	currentCellBorder *canvas.Rectangle
+	editingErrorText  *canvas.Text
 }
 
 func newTableRenderer(table *Table) *tableRenderer {
 	dataRowsBorders := createBorders(len(table.rowData))
 	return &tableRenderer{
-		table:           table,
-		headerRowBorder: canvas.NewRectangle(color.Black),
-		dataRowsBorders: dataRowsBorders,
-		columnBorders:   createBorders(table.columnAmount()),
-		focusedBorder:   canvas.NewRectangle(color.Transparent),
-		borderColor:     color.Black,
+		table:             table,
+		headerRowBorder:   canvas.NewRectangle(color.Transparent),
+		dataRowsBorders:   dataRowsBorders,
+		columnBorders:     createBorders(table.columnAmount()),
+		focusedBorder:     canvas.NewRectangle(color.Transparent),
+		currentCellBorder: canvas.NewRectangle(color.Transparent),
+		editingErrorText:  canvas.NewText("", editingErrorColor),
 	}
 }
 
 func createBorders(amount int) []*canvas.Rectangle {
 	borders := []*canvas.Rectangle{}
 	for i := 1; i <= amount; i++ {
-		borders = append(borders, canvas.NewRectangle(color.Black))
+		borders = append(borders, canvas.NewRectangle(color.Transparent))
 	}
 	return borders
 }
@@ -58,6 +65,7 @@ func (renderer *tableRenderer) Layout(_ fyne.Size) {
 	renderer.renderHeader()
 	renderer.renderData()
 	renderer.renderFocusedBorder()
+	renderer.renderCurrentCell()
 }
 
 func (renderer *tableRenderer) renderHeader() {
@@ -67,11 +75,11 @@ func (renderer *tableRenderer) renderHeader() {
 
 func (renderer *tableRenderer) renderHeaderColumnLabels() {
 	position := fyne.NewPos(widthBetweenColumns/2, 0)
-	for _, columnLabel := range renderer.table.columnLabels {
+	for i, columnLabel := range renderer.table.columnLabels {
 		label := columnLabel.(*widget.Label)
 		label.TextStyle.Bold = true
 		label.Move(position)
-		size := fyne.NewSize(label.MinSize().Width, headerHeight)
+		size := fyne.NewSize(renderer.table.columnWidth(i), headerHeight)
 		label.Resize(size)
 		position = position.Add(fyne.NewPos(size.Width+widthBetweenColumns, 0))
 	}
@@ -127,6 +135,10 @@ func (renderer *tableRenderer) setContentProperties(content fyne.CanvasObject, c
 		contentLabel := content.(*widget.Label)
 		contentLabel.Wrapping = fyne.TextWrapWord
 		contentLabel.Alignment = fyne.TextAlignCenter
+	case ImageColumn:
+		if contentImage, isImage := content.(*canvas.Image); isImage {
+			contentImage.FillMode = canvas.ImageFillContain
+		}
 	}
 }
 
@@ -156,7 +168,7 @@ func (renderer *tableRenderer) renderColumnBorders() {
 func (renderer *tableRenderer) setBorderProperties(border *canvas.Rectangle) {
 	border.StrokeWidth = 2
 	border.FillColor = color.Transparent
-	border.StrokeColor = renderer.borderColor
+	border.StrokeColor = theme.TextColor()
 }
 
 func (renderer *tableRenderer) renderFocusedBorder() {
@@ -173,6 +185,57 @@ func (renderer *tableRenderer) renderFocusedBorder() {
 	renderer.focusedBorder.Resize(size)
 }
 
+func (renderer *tableRenderer) renderCurrentCell() {
+	if !renderer.table.focused || len(renderer.table.rowData) == 0 || renderer.table.columnAmount() == 0 {
+		renderer.currentCellBorder.Hide()
+	} else {
+		renderer.currentCellBorder.Show()
+	}
+	position := renderer.cellPosition(renderer.table.currentRowNum, renderer.table.currentColumnNum)
+	size := fyne.NewSize(renderer.columnWidthWithPadding(renderer.table.currentColumnNum), rowHeight)
+	renderer.currentCellBorder.StrokeWidth = 3
+	renderer.currentCellBorder.FillColor = color.Transparent
+	renderer.currentCellBorder.StrokeColor = theme.FocusColor()
+	renderer.currentCellBorder.Move(position)
+	renderer.currentCellBorder.Resize(size)
+	renderer.renderEditor(position, size)
+}
+
+func (renderer *tableRenderer) renderEditor(cellPosition fyne.Position, cellSize fyne.Size) {
+	editor := renderer.table.editor
+	if editor == nil {
+		renderer.editingErrorText.Hide()
+		return
+	}
+	editorHeight := editor.MinSize().Height
+	editor.Move(cellPosition.Add(fyne.NewPos(0, (cellSize.Height-editorHeight)/2)))
+	editor.Resize(fyne.NewSize(cellSize.Width, editorHeight))
+	renderer.editingErrorText.Text = renderer.table.editingError
+	if renderer.table.editingError == "" {
+		renderer.editingErrorText.Hide()
+	} else {
+		renderer.editingErrorText.Show()
+		renderer.editingErrorText.Move(editor.Position().Add(fyne.NewPos(0, editorHeight)))
+		renderer.editingErrorText.Resize(renderer.editingErrorText.MinSize())
+	}
+}
+
+//Position of the top left corner of the cell including space between the columns
+func (renderer *tableRenderer) cellPosition(rowNum int, columnNum int) fyne.Position {
+	x := 0
+	for i := 0; i < columnNum; i++ {
+		x += renderer.columnWidthWithPadding(i)
+	}
+	return fyne.NewPos(x, headerHeight+rowNum*rowHeight)
+}
+
+func (renderer *tableRenderer) columnWidthWithPadding(columnNum int) int {
+	if columnNum >= len(renderer.table.columnLabels) {
+		return 0
+	}
+	return renderer.table.columnLabels[columnNum].Size().Width + widthBetweenColumns
+}
+
 func (renderer *tableRenderer) MinSize() fyne.Size {
 	return fyne.NewSize(renderer.tableWidth(), renderer.tableHeight())
 }
@@ -187,6 +250,10 @@ func (renderer *tableRenderer) Objects() []fyne.CanvasObject {
 	objects = append(objects, convertRectanglesToCanvasObjects(renderer.dataRowsBorders)...)
 	objects = append(objects, convertRectanglesToCanvasObjects(renderer.columnBorders)...)
 	objects = append(objects, renderer.focusedBorder)
+	objects = append(objects, renderer.currentCellBorder)
+	if renderer.table.editor != nil {
+		objects = append(objects, renderer.table.editor, renderer.editingErrorText)
+	}
 	return objects
 }
 
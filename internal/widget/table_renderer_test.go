@@ -16,7 +16,7 @@ func TestThatHeaderRowBorderIsDrawnCorrectly(t *testing.T) {
 	assert.Equal(t, expectedTableWidth, rectangle.Size().Width)
 	assert.Equal(t, expectedHeaderHeight, rectangle.Size().Height)
 	assert.Equal(t, float32(2), rectangle.StrokeWidth)
-	assert.Equal(t, color.Black, rectangle.StrokeColor)
+	assert.Equal(t, theme.TextColor(), rectangle.StrokeColor)
 	assert.Equal(t, color.Transparent, rectangle.FillColor)
 }
 
@@ -46,7 +46,7 @@ func TestThatAllDataBordersHaveCorrectProperties(t *testing.T) {
 	renderer := createDefaultTableRendererForTesting()
 	for i, border := range renderer.dataRowsBorders {
 		assert.Equal(t, float32(2), border.StrokeWidth, "Border with number "+strconv.Itoa(i)+" does not have correct stroke width")
-		assert.Equal(t, color.Black, border.StrokeColor, "Border with number "+strconv.Itoa(i)+" does not have correct stroke color")
+		assert.Equal(t, theme.TextColor(), border.StrokeColor, "Border with number "+strconv.Itoa(i)+" does not have correct stroke color")
 		assert.Equal(t, color.Transparent, border.FillColor, "Border with number "+strconv.Itoa(i)+" does not have correct fill color")
 	}
 }
@@ -77,7 +77,7 @@ func TestThatAllColumnBordersHaveCorrectProperties(t *testing.T) {
 	renderer := createDefaultTableRendererForTesting()
 	for i, border := range renderer.columnBorders {
 		assert.Equal(t, float32(2), border.StrokeWidth, "Border with number "+strconv.Itoa(i)+" does not have correct stroke width")
-		assert.Equal(t, color.Black, border.StrokeColor, "Border with number "+strconv.Itoa(i)+" does not have correct stroke color")
+		assert.Equal(t, theme.TextColor(), border.StrokeColor, "Border with number "+strconv.Itoa(i)+" does not have correct stroke color")
 		assert.Equal(t, color.Transparent, border.FillColor, "Border with number "+strconv.Itoa(i)+" does not have correct fill color")
 	}
 }
@@ -106,3 +106,39 @@ func TestThatAfterRowIsAddedThereIsCorrectAmountOfRowBorders(t *testing.T) {
 	renderer := test.WidgetRenderer(table).(*tableRenderer)
 	assert.Equal(t, testRowAmount+1, len(renderer.dataRowsBorders))
 }
+
+func TestThatCurrentCellBorderIsDisplayedOnlyWhenTableIsFocusedAndCoversCurrentCell(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	table := createDefaultTableForTestingWithCustomCanvas(testWindow.Canvas())
+	testWindow.SetContent(table)
+	renderer := test.WidgetRenderer(table).(*tableRenderer)
+	assert.False(t, renderer.currentCellBorder.Visible())
+	table.EnterInputMode()
+	table.SelectCell(2, 1)
+	assert.True(t, renderer.currentCellBorder.Visible())
+	expectedX := table.columnLabels[0].Size().Width + expectedPadding
+	assert.Equal(t, fyne.NewPos(expectedX, expectedHeaderHeight+2*expectedRowHeight), renderer.currentCellBorder.Position())
+	assert.Equal(t, fyne.NewSize(table.columnLabels[1].Size().Width+expectedPadding, expectedRowHeight), renderer.currentCellBorder.Size())
+	assert.Equal(t, theme.FocusColor(), renderer.currentCellBorder.StrokeColor)
+}
+
+func TestThatEditorAndEditingErrorAreDisplayedOverCurrentCell(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	table := createDefaultTableForTestingWithCustomCanvas(testWindow.Canvas())
+	testWindow.SetContent(table)
+	renderer := test.WidgetRenderer(table).(*tableRenderer)
+	editor := NewInputField(testWindow.Canvas(), getInputHandlerForTesting())
+	table.EnterInputMode()
+	table.SelectCell(1, 0)
+	table.StartEditingCurrentCell(editor)
+	assert.Contains(t, renderer.Objects(), editor)
+	assert.Equal(t, 0, editor.Position().X)
+	assert.True(t, editor.Position().Y >= expectedHeaderHeight+expectedRowHeight)
+	assert.True(t, editor.Position().Y < expectedHeaderHeight+2*expectedRowHeight)
+	assert.False(t, renderer.editingErrorText.Visible())
+	table.SetEditingError("error")
+	assert.True(t, renderer.editingErrorText.Visible())
+	assert.Equal(t, "error", renderer.editingErrorText.Text)
+	table.StopEditing()
+	assert.NotContains(t, renderer.Objects(), editor)
+}
@@ -2,6 +2,7 @@ package widget
 
 import (
 	"fyne.io/fyne"
+	"fyne.io/fyne/canvas"
 	"fyne.io/fyne/test"
 	"fyne.io/fyne/widget"
 	"github.com/stretchr/testify/assert"
@@ -113,3 +114,96 @@ func TestThatTableCallsOnExitCallbackFunction(t *testing.T) {
 	SimulateKeyPress(table, fyne.KeySpace)
 	assert.True(t, functionExecuted)
 }
+
+func TestThatColumnsWithFixedWidthHaveThatWidthRegardlessOfTheirHeaderLabel(t *testing.T) {
+	columnData := createColumnDataForTesting(testColumnAmount)
+	columnData[0].Width = 300
+	columnData[1].Width = 5
+	table := NewTable(test.Canvas(), getInputHandlerForTesting(), columnData, createLabelsForTesting(testColumnAmount, testRowAmount))
+	test.WidgetRenderer(table).Layout(fyne.NewSize(0, 0))
+	assert.Equal(t, 300, table.columnLabels[0].Size().Width)
+	assert.Equal(t, 5, table.columnLabels[1].Size().Width)
+	assert.Equal(t, table.columnLabels[2].MinSize().Width, table.columnLabels[2].Size().Width)
+	for _, row := range table.rowData {
+		assert.Equal(t, 300, row[0].Size().Width)
+		assert.Equal(t, 5, row[1].Size().Width)
+	}
+}
+
+func TestThatCurrentCellCanBeChangedUsingMovementKeys(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	table := createTableForTesting(testWindow.Canvas(), 3, 3)
+	testWindow.SetContent(table)
+	table.EnterInputMode()
+	assert.Equal(t, 0, table.CurrentRowNum())
+	assert.Equal(t, 0, table.CurrentColumnNum())
+	SimulateKeyPress(table, fyne.KeyJ)
+	SimulateKeyPress(table, fyne.KeyL)
+	SimulateKeyPress(table, fyne.KeyL)
+	assert.Equal(t, 1, table.CurrentRowNum())
+	assert.Equal(t, 2, table.CurrentColumnNum())
+	SimulateKeyPress(table, fyne.KeyK)
+	SimulateKeyPress(table, fyne.KeyH)
+	assert.Equal(t, 0, table.CurrentRowNum())
+	assert.Equal(t, 1, table.CurrentColumnNum())
+}
+
+func TestThatCurrentCellDoesNotChangeWhenTryingToMoveOutsideOfTheTable(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	table := createTableForTesting(testWindow.Canvas(), 2, 2)
+	testWindow.SetContent(table)
+	table.EnterInputMode()
+	SimulateKeyPress(table, fyne.KeyK)
+	SimulateKeyPress(table, fyne.KeyH)
+	assert.Equal(t, 0, table.CurrentRowNum())
+	assert.Equal(t, 0, table.CurrentColumnNum())
+	table.SelectCell(1, 1)
+	SimulateKeyPress(table, fyne.KeyJ)
+	SimulateKeyPress(table, fyne.KeyL)
+	assert.Equal(t, 1, table.CurrentRowNum())
+	assert.Equal(t, 1, table.CurrentColumnNum())
+}
+
+func TestThatEnteringInputModeOnFocusedTableCallsOnEditCellFunctionWithCurrentCell(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	table := createTableForTesting(testWindow.Canvas(), 3, 3)
+	testWindow.SetContent(table)
+	editedRowNum, editedColumnNum := -1, -1
+	table.SetOnEditCellCallbackFunction(func(rowNum int, columnNum int) {
+		editedRowNum = rowNum
+		editedColumnNum = columnNum
+	})
+	table.EnterInputMode()
+	table.SelectCell(2, 1)
+	SimulateKeyPress(table, fyne.KeyI)
+	assert.Equal(t, 2, editedRowNum)
+	assert.Equal(t, 1, editedColumnNum)
+}
+
+func TestThatEditorGetsFocusedWhenEditingStartsAndTableGetsFocusBackWhenItStops(t *testing.T) {
+	testWindow := test.NewApp().NewWindow("")
+	table := createTableForTesting(testWindow.Canvas(), 3, 3)
+	testWindow.SetContent(table)
+	editor := NewInputField(testWindow.Canvas(), getInputHandlerForTesting())
+	table.EnterInputMode()
+	table.StartEditingCurrentCell(editor)
+	assert.True(t, table.IsEditing())
+	assert.Equal(t, editor, testWindow.Canvas().Focused())
+	table.SetEditingError("some error")
+	assert.Equal(t, "some error", table.EditingError())
+	table.StopEditing()
+	assert.False(t, table.IsEditing())
+	assert.Empty(t, table.EditingError())
+	assert.Equal(t, table, testWindow.Canvas().Focused())
+}
+
+func TestThatImageColumnsHaveImageWidthByDefaultAndScaleImagesToFitCells(t *testing.T) {
+	columnData := []TableColumn{{Type: ImageColumn, Name: "Image"}, {Type: TextColumn, Name: "Text"}}
+	rowData := []TableRow{{canvas.NewImageFromImage(nil), widget.NewLabel("text")}}
+	table := NewTable(test.Canvas(), getInputHandlerForTesting(), columnData, rowData)
+	test.WidgetRenderer(table).Layout(fyne.NewSize(0, 0))
+	assert.Equal(t, imageColumnWidth, table.columnLabels[0].Size().Width)
+	image := rowData[0][0].(*canvas.Image)
+	assert.Equal(t, canvas.ImageFillContain, image.FillMode)
+	assert.Equal(t, fyne.NewSize(imageColumnWidth, expectedRowHeight), image.Size())
+}
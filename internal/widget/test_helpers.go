@@ -80,6 +80,8 @@ func getInputHandlerForTesting() input.Handler {
 	//Default keys are the same as if they were set by default config
 	keymap[input.MoveDownAction] = input.SingleKeyCombination(fyne.KeyJ)
 	keymap[input.MoveUpAction] = input.SingleKeyCombination(fyne.KeyK)
+	keymap[input.MoveLeftAction] = input.SingleKeyCombination(fyne.KeyH)
+	keymap[input.MoveRightAction] = input.SingleKeyCombination(fyne.KeyL)
 	keymap[input.EnterInputModeAction] = input.SingleKeyCombination(fyne.KeyI)
 	keymap[input.ExitInputModeAction] = input.SingleKeyCombination(fyne.KeyEscape)
 	keymap[input.ExitTableAction] = input.SingleKeyCombination(fyne.KeySpace)